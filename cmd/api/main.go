@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"aiki/internal/ai"
@@ -15,7 +16,10 @@ import (
 	"aiki/internal/config"
 	"aiki/internal/database"
 	"aiki/internal/handler"
+	"aiki/internal/jobimport"
+	"aiki/internal/jobsource"
 	"aiki/internal/middleware"
+	"aiki/internal/pkg/currency"
 	"aiki/internal/pkg/jwt"
 	"aiki/internal/pkg/mailer"
 	"aiki/internal/pkg/scheduler"
@@ -26,6 +30,8 @@ import (
 	"aiki/internal/service"
 
 	_ "aiki/docs"
+	// Users' time zones are loaded by name; the Alpine image has no zoneinfo.
+	_ "time/tzdata"
 
 	"github.com/labstack/echo/v4"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -71,9 +77,41 @@ func main() {
 	homeRepo := repository.NewHomeRepository(db)
 	notifRepo := repository.NewNotificationRepository(db)
 	serpRepo := repository.NewSerpJobRepository(db)
+	pipelineRepo := repository.NewPipelineRepository(db)
+	contactRepo := repository.NewContactRepository(db)
+	offerRepo := repository.NewOfferRepository(db)
+	analyticsRepo := repository.NewAnalyticsRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	automationRepo := repository.NewAutomationRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	savedSearchRepo := repository.NewSavedSearchRepository(db)
 
 	// Services
-	serpClient := serp.NewClient(cfg.SerpAPI.Key)
+	searchQuota := jobsource.NewQuotaManager(
+		jobsource.NewRedisCounter(redis),
+		map[string]int64{"serpapi": cfg.JobSources.SerpMonthlyQuota},
+		cfg.JobSources.QuotaThresholdPercent,
+		cfg.JobSources.RefreshLimit,
+		cfg.JobSources.RefreshWindow,
+	)
+	sources := buildJobSources(cfg, searchQuota)
+	if cfg.JobSources.CacheTTL > 0 {
+		cacheStore := jobsource.NewRedisCacheStore(redis)
+		for i, src := range sources {
+			sources[i] = jobsource.NewCached(src, cacheStore, cfg.JobSources.CacheTTL)
+		}
+	}
+	jobSources := jobsource.NewAggregator(cfg.JobSources.Timeout, sources...)
+	log.Printf("✓ Job sources enabled: %s", strings.Join(jobSources.Names(), ", "))
+	jobImportClient := jobimport.NewClient()
+	exchangeRates := currency.DefaultRates()
+	if cfg.Offers.ExchangeRates != "" {
+		overrides, err := currency.ParseRates(cfg.Offers.ExchangeRates)
+		if err != nil {
+			log.Fatalf("Invalid OFFER_EXCHANGE_RATES: %v", err)
+		}
+		exchangeRates = exchangeRates.Merge(overrides)
+	}
 	emailSender := mailer.NewResendSender(
 		cfg.Email.ResendAPIKey,
 		cfg.Email.FromEmail,
@@ -93,10 +131,30 @@ func main() {
 		cfg.Server.Env,
 	)
 	userService := service.NewUserService(userRepo)
-	jobService := service.NewJobService(jobRepo)
+	jobService := service.NewJobService(jobRepo, pipelineRepo, contactRepo, offerRepo, attachmentRepo, cfg.Jobs.TrashRetention)
+	pipelineService := service.NewPipelineService(pipelineRepo, jobRepo)
+	contactService := service.NewContactService(contactRepo, jobRepo)
+	offerService := service.NewOfferService(offerRepo, jobRepo, exchangeRates, cfg.Offers.DefaultCurrency)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, userRepo)
+	attachmentService := service.NewAttachmentService(
+		attachmentRepo,
+		jobRepo,
+		cfg.Attachments.MaxFileBytes,
+		cfg.Attachments.QuotaBytes,
+	)
+	jobImportService := service.NewJobImportService(jobImportClient)
 	notifService := service.NewNotificationService(notifRepo)
-	homeService := service.NewHomeService(homeRepo, notifService)
-	serpJobService := service.NewSerpJobService(serpRepo, userRepo, jobRepo, serpClient)
+	goalService := service.NewGoalService(goalRepo, userRepo, notifService)
+	homeService := service.NewHomeService(homeRepo, userRepo, jobRepo, goalRepo, notifService, goalService, cfg.Sessions.PauseLimit)
+	automationService := service.NewAutomationService(automationRepo, jobRepo, notifService)
+	serpJobService := service.NewSerpJobService(serpRepo, userRepo, jobRepo, attachmentRepo, jobSources, exchangeRates, searchQuota, service.PrefetchOptions{
+		Workers:      int(cfg.Prefetch.Workers),
+		Stagger:      cfg.Prefetch.Stagger,
+		Lead:         2 * cfg.Prefetch.Interval,
+		ActiveWindow: cfg.Prefetch.ActiveWindow,
+		MaxUsers:     int32(cfg.Prefetch.MaxUsers),
+	})
+	savedSearchService := service.NewSavedSearchService(savedSearchRepo, jobSources, notifService)
 
 	// AI providers & chat service
 	aiRegistry := ai.NewRegistry()
@@ -130,16 +188,29 @@ func main() {
 	)
 	userHandler := handler.NewUserHandler(userService, e.Validator)
 	jobHandler := handler.NewJobHandler(jobService, e.Validator)
+	jobImportHandler := handler.NewJobImportHandler(jobImportService, e.Validator)
+	pipelineHandler := handler.NewPipelineHandler(pipelineService, e.Validator)
+	contactHandler := handler.NewContactHandler(contactService, e.Validator)
+	offerHandler := handler.NewOfferHandler(offerService, e.Validator)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService)
+	automationHandler := handler.NewAutomationHandler(automationService, e.Validator)
+	goalHandler := handler.NewGoalHandler(goalService, e.Validator)
+	savedSearchHandler := handler.NewSavedSearchHandler(savedSearchService, e.Validator)
 	homeHandler := handler.NewHomeHandler(homeService, e.Validator)
 	notifHandler := handler.NewNotificationHandler(notifService)
-	serpHandler := handler.NewSerpJobHandler(serpJobService)
+	serpHandler := handler.NewSerpJobHandler(serpJobService, e.Validator)
 	chatHandler := handler.NewChatHandler(chatService)
 
 	// Routes
-	router.Setup(e, authHandler, userHandler, jobHandler, homeHandler, notifHandler, serpHandler, chatHandler, jwtManager)
+	router.Setup(e, authHandler, userHandler, jobHandler, jobImportHandler, pipelineHandler, contactHandler, offerHandler, attachmentHandler, analyticsHandler, automationHandler, goalHandler, savedSearchHandler, homeHandler, notifHandler, serpHandler, chatHandler, jwtManager, jobsource.SplitList(cfg.Admin.Emails))
 
 	// Scheduler
-	sched := scheduler.NewScheduler(notifService)
+	sched := scheduler.NewScheduler(
+		notifService, jobService, automationService, goalService, savedSearchService,
+		serpJobService, homeService, scheduler.NewRedisLocker(redis), cfg.Prefetch.Interval,
+		cfg.Sessions.SweepInterval,
+	)
 	sched.Start()
 	log.Println("✓ Notification scheduler started")
 
@@ -164,3 +235,45 @@ func main() {
 	}
 	log.Println("Server exited!")
 }
+
+// buildJobSources returns the recommendation sources listed in JOB_SOURCES,
+// skipping any that lack the settings they need. Calls to SerpApi, the paid
+// one, are counted against its plan by quota.
+func buildJobSources(cfg *config.Config, quota *jobsource.QuotaManager) []jobsource.Source {
+	var sources []jobsource.Source
+	for _, name := range jobsource.SplitList(cfg.JobSources.Enabled) {
+		switch name {
+		case "serpapi":
+			if cfg.SerpAPI.Key == "" {
+				log.Println("job source serpapi skipped: SERP_API_KEY is not set")
+				continue
+			}
+			sources = append(sources, jobsource.NewMetered(serp.NewClient(cfg.SerpAPI.Key), quota))
+		case "remotive":
+			sources = append(sources, jobsource.NewRemotive())
+		case "greenhouse":
+			boards := jobsource.SplitList(cfg.JobSources.GreenhouseBoards)
+			if len(boards) == 0 {
+				log.Println("job source greenhouse skipped: GREENHOUSE_BOARDS is empty")
+				continue
+			}
+			sources = append(sources, jobsource.NewGreenhouse(boards))
+		case "lever":
+			companies := jobsource.SplitList(cfg.JobSources.LeverCompanies)
+			if len(companies) == 0 {
+				log.Println("job source lever skipped: LEVER_COMPANIES is empty")
+				continue
+			}
+			sources = append(sources, jobsource.NewLever(companies))
+		case "adzuna":
+			if cfg.JobSources.AdzunaAppID == "" || cfg.JobSources.AdzunaAppKey == "" {
+				log.Println("job source adzuna skipped: ADZUNA_APP_ID and ADZUNA_APP_KEY are required")
+				continue
+			}
+			sources = append(sources, jobsource.NewAdzuna(cfg.JobSources.AdzunaAppID, cfg.JobSources.AdzunaAppKey, cfg.JobSources.AdzunaCountry))
+		default:
+			log.Printf("unknown job source %q in JOB_SOURCES", name)
+		}
+	}
+	return sources
+}
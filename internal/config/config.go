@@ -3,20 +3,98 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	LinkedIn LinkedInConfig
-	SerpAPI  SerpConfig
-	AI       AIConfig
-	Email    EmailConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Redis       RedisConfig
+	JWT         JWTConfig
+	LinkedIn    LinkedInConfig
+	SerpAPI     SerpConfig
+	AI          AIConfig
+	Email       EmailConfig
+	Offers      OfferConfig
+	Jobs        JobConfig
+	Attachments AttachmentConfig
+	JobSources  JobSourceConfig
+	Prefetch    PrefetchConfig
+	Sessions    SessionConfig
+	Admin       AdminConfig
+}
+
+// PrefetchConfig controls the background refresh of recommendations. Every
+// Interval the searches of users active within ActiveWindow are refreshed
+// before they expire, at most MaxUsers a run, by Workers at once and starting
+// one every Stagger. An Interval of zero turns it off.
+type PrefetchConfig struct {
+	Interval     time.Duration
+	ActiveWindow time.Duration
+	MaxUsers     int64
+	Workers      int64
+	Stagger      time.Duration
+}
+
+// SessionConfig controls the cleanup of focus sessions left behind by a
+// client that went away. Every SweepInterval, active sessions past their
+// planned duration are completed and sessions paused for longer than
+// PauseLimit are abandoned. A SweepInterval of zero turns it off.
+type SessionConfig struct {
+	SweepInterval time.Duration
+	PauseLimit    time.Duration
+}
+
+// AdminConfig lists, comma-separated, the account emails allowed to use the
+// admin endpoints.
+type AdminConfig struct {
+	Emails string
+}
+
+// JobSourceConfig selects the boards recommendations are searched on.
+// Enabled is a comma-separated list of source names (serpapi, remotive,
+// greenhouse, lever, adzuna); a source missing the settings it needs is
+// skipped. Each source gets at most Timeout to answer. Search pages are
+// shared between users for CacheTTL; zero turns the shared cache off.
+// SerpMonthlyQuota is the SerpApi plan's monthly searches; fetching from it
+// stops at QuotaThresholdPercent of that. A user may start RefreshLimit new
+// searches per RefreshWindow.
+type JobSourceConfig struct {
+	Enabled               string
+	Timeout               time.Duration
+	CacheTTL              time.Duration
+	SerpMonthlyQuota      int64
+	QuotaThresholdPercent int64
+	RefreshLimit          int64
+	RefreshWindow         time.Duration
+	GreenhouseBoards      string
+	LeverCompanies        string
+	AdzunaAppID           string
+	AdzunaAppKey          string
+	AdzunaCountry         string
+}
+
+// AttachmentConfig limits job attachments: the size of a single file and the
+// total a user may store.
+type AttachmentConfig struct {
+	MaxFileBytes int64
+	QuotaBytes   int64
+}
+
+// JobConfig controls the job tracker. Trashed jobs are purged once they have
+// been in the trash for TrashRetention.
+type JobConfig struct {
+	TrashRetention time.Duration
+}
+
+// OfferConfig controls offer comparison. ExchangeRates is a "CODE=rate" list
+// (e.g. "EUR=1.08,NGN=0.00065") that overrides or extends the built-in table.
+type OfferConfig struct {
+	DefaultCurrency string
+	ExchangeRates   string
 }
 
 type AIConfig struct {
@@ -133,6 +211,45 @@ func Load() (*Config, error) {
 			FromEmail:    getEnv("EMAIL_FROM_ADDRESS", "no-reply@aiki.app"),
 			FromName:     getEnv("EMAIL_FROM_NAME", "Aiki"),
 		},
+		Offers: OfferConfig{
+			DefaultCurrency: getEnv("OFFER_DEFAULT_CURRENCY", "USD"),
+			ExchangeRates:   getEnv("OFFER_EXCHANGE_RATES", ""),
+		},
+		Jobs: JobConfig{
+			TrashRetention: parseDuration(getEnv("JOB_TRASH_RETENTION", "720h"), 30*24*time.Hour),
+		},
+		Attachments: AttachmentConfig{
+			MaxFileBytes: getEnvInt64("ATTACHMENT_MAX_FILE_MB", 10) << 20,
+			QuotaBytes:   getEnvInt64("ATTACHMENT_QUOTA_MB", 100) << 20,
+		},
+		JobSources: JobSourceConfig{
+			Enabled:               getEnv("JOB_SOURCES", "serpapi,remotive"),
+			Timeout:               parseDuration(getEnv("JOB_SOURCE_TIMEOUT", "8s"), 8*time.Second),
+			CacheTTL:              parseDuration(getEnv("JOB_SEARCH_CACHE_TTL", "6h"), 6*time.Hour),
+			SerpMonthlyQuota:      getEnvInt64("SERP_API_MONTHLY_QUOTA", 0),
+			QuotaThresholdPercent: getEnvInt64("SERP_API_QUOTA_THRESHOLD_PERCENT", 90),
+			RefreshLimit:          getEnvInt64("JOB_SEARCH_REFRESH_LIMIT", 10),
+			RefreshWindow:         parseDuration(getEnv("JOB_SEARCH_REFRESH_WINDOW", "1h"), time.Hour),
+			GreenhouseBoards:      getEnv("GREENHOUSE_BOARDS", ""),
+			LeverCompanies:        getEnv("LEVER_COMPANIES", ""),
+			AdzunaAppID:           getEnv("ADZUNA_APP_ID", ""),
+			AdzunaAppKey:          getEnv("ADZUNA_APP_KEY", ""),
+			AdzunaCountry:         getEnv("ADZUNA_COUNTRY", "gb"),
+		},
+		Prefetch: PrefetchConfig{
+			Interval:     parseDuration(getEnv("JOB_PREFETCH_INTERVAL", "30m"), 30*time.Minute),
+			ActiveWindow: parseDuration(getEnv("JOB_PREFETCH_ACTIVE_WINDOW", "72h"), 72*time.Hour),
+			MaxUsers:     getEnvInt64("JOB_PREFETCH_MAX_USERS", 200),
+			Workers:      getEnvInt64("JOB_PREFETCH_WORKERS", 4),
+			Stagger:      parseDuration(getEnv("JOB_PREFETCH_STAGGER", "2s"), 2*time.Second),
+		},
+		Sessions: SessionConfig{
+			SweepInterval: parseDuration(getEnv("FOCUS_SESSION_SWEEP_INTERVAL", "5m"), 5*time.Minute),
+			PauseLimit:    parseDuration(getEnv("FOCUS_SESSION_PAUSE_LIMIT", "12h"), 12*time.Hour),
+		},
+		Admin: AdminConfig{
+			Emails: getEnv("ADMIN_EMAILS", ""),
+		},
 		
 
 	}
@@ -165,6 +282,14 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt64(key string, defaultValue int64) int64 {
+	v, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil || v <= 0 {
+		return defaultValue
+	}
+	return v
+}
+
 func parseDuration(value string, defaultDuration time.Duration) time.Duration {
 	d, err := time.ParseDuration(value)
 	if err != nil {
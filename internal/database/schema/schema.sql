@@ -8,6 +8,7 @@ CREATE TABLE IF NOT EXISTS users (
     password_hash VARCHAR(255), -- Made nullable
     linkedin_id TEXT, -- Added new column
     is_active BOOLEAN DEFAULT TRUE,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC', -- IANA name; the user's days are counted in it
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
@@ -52,6 +53,24 @@ CREATE TABLE IF NOT EXISTS user_profile (
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
+-- Pipeline stages (per-user Kanban columns mapped onto base job statuses)
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    id          SERIAL PRIMARY KEY,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name        VARCHAR(60) NOT NULL,
+    base_status VARCHAR(50) NOT NULL, -- saved | applied | interview | offer | rejected
+    position    INT NOT NULL DEFAULT 0,
+    color       VARCHAR(20),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_user_position ON pipeline_stages(user_id, position);
+
+CREATE TRIGGER update_pipeline_stages_updated_at BEFORE UPDATE ON pipeline_stages
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 -- Jobs table
 CREATE TABLE IF NOT EXISTS jobs (
     id SERIAL PRIMARY KEY,
@@ -65,9 +84,27 @@ CREATE TABLE IF NOT EXISTS jobs (
     date_applied TIMESTAMP,
     status VARCHAR(50) NOT NULL DEFAULT 'applied',
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    priority SMALLINT NOT NULL DEFAULT 0, -- 0 none | 1 low | 2 medium | 3 high
+    stage_id INT REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    board_position INT NOT NULL DEFAULT 0,
+    archived_at TIMESTAMP,
+    deleted_at TIMESTAMP, -- trashed; purged after the retention window
+    ghosted_at TIMESTAMP  -- set by an automation rule; cleared on the next status change
 );
 
+CREATE INDEX IF NOT EXISTS idx_jobs_user_stage_position ON jobs(user_id, stage_id, board_position);
+CREATE INDEX IF NOT EXISTS idx_jobs_user_deleted_at ON jobs(user_id, deleted_at);
+
+CREATE TABLE IF NOT EXISTS job_tags (
+    job_id  INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag     VARCHAR(50) NOT NULL,
+    PRIMARY KEY (job_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_tags_user_tag ON job_tags(user_id, tag);
+
 -- ============================================================
 -- Home Screen Features
 -- Focus Sessions, Streaks, Badges, Progress Stats
@@ -80,6 +117,20 @@ CREATE TABLE IF NOT EXISTS focus_sessions (
     duration_seconds INT NOT NULL DEFAULT 0,
     elapsed_seconds  INT NOT NULL DEFAULT 0,
     status           VARCHAR(20) NOT NULL DEFAULT 'active', -- active | paused | completed | abandoned
+    reported_seconds INT NOT NULL DEFAULT 0,
+    flagged          BOOLEAN NOT NULL DEFAULT FALSE,
+    job_id           INT REFERENCES jobs(id) ON DELETE SET NULL,
+    goal_id          INT REFERENCES user_goals(id) ON DELETE SET NULL,
+    intention        VARCHAR(200) NOT NULL DEFAULT '',
+    reflection       TEXT NOT NULL DEFAULT '',
+    focus_score      SMALLINT CHECK (focus_score BETWEEN 1 AND 5),
+    mode             VARCHAR(20) NOT NULL DEFAULT 'single', -- single | pomodoro
+    work_seconds        INT,
+    short_break_seconds INT,
+    long_break_seconds  INT,
+    cycles              INT,
+    long_break_every    INT,
+    break_seconds       INT NOT NULL DEFAULT 0,
     started_at       TIMESTAMP NOT NULL DEFAULT NOW(),
     ended_at         TIMESTAMP,
     created_at       TIMESTAMP NOT NULL DEFAULT NOW(),
@@ -89,10 +140,41 @@ CREATE TABLE IF NOT EXISTS focus_sessions (
 CREATE INDEX IF NOT EXISTS idx_focus_sessions_user_id    ON focus_sessions(user_id);
 CREATE INDEX IF NOT EXISTS idx_focus_sessions_status     ON focus_sessions(status);
 CREATE INDEX IF NOT EXISTS idx_focus_sessions_started_at ON focus_sessions(started_at);
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_job_id     ON focus_sessions(job_id) WHERE job_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_goal_id    ON focus_sessions(goal_id) WHERE goal_id IS NOT NULL;
 
 CREATE TRIGGER update_focus_sessions_updated_at BEFORE UPDATE ON focus_sessions
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+-- Stretches of a focus session during which its clock ran, timed by the server.
+CREATE TABLE IF NOT EXISTS focus_session_segments (
+    id         SERIAL PRIMARY KEY,
+    session_id INT NOT NULL REFERENCES focus_sessions(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_segments_session ON focus_session_segments(session_id, started_at);
+
+-- Pomodoro plans a user saved to reuse.
+CREATE TABLE IF NOT EXISTS focus_session_presets (
+    id                  SERIAL PRIMARY KEY,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                VARCHAR(60) NOT NULL,
+    work_seconds        INT NOT NULL,
+    short_break_seconds INT NOT NULL,
+    long_break_seconds  INT NOT NULL DEFAULT 0,
+    cycles              INT NOT NULL,
+    long_break_every    INT NOT NULL DEFAULT 0,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_presets_user_id ON focus_session_presets(user_id);
+
+CREATE TRIGGER update_focus_session_presets_updated_at BEFORE UPDATE ON focus_session_presets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 -- Streaks table
 CREATE TABLE IF NOT EXISTS streaks (
     id                SERIAL PRIMARY KEY,
@@ -147,7 +229,7 @@ CREATE INDEX IF NOT EXISTS idx_daily_progress_date    ON daily_progress(date);
 CREATE TABLE IF NOT EXISTS notifications (
     id         SERIAL PRIMARY KEY,
     user_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    type       VARCHAR(50) NOT NULL,  -- session_completed | streak_milestone | badge_earned | daily_reminder | streak_warning
+    type       VARCHAR(50) NOT NULL,  -- session_completed | session_expired | streak_milestone | badge_earned | daily_reminder | streak_warning | contact_follow_up | offer_deadline | job_nudge | goal_reached | goal_at_risk | saved_search_match | saved_search_digest
     title      VARCHAR(200) NOT NULL,
     message    TEXT NOT NULL,
     is_read    BOOLEAN NOT NULL DEFAULT FALSE,
@@ -172,6 +254,7 @@ CREATE TABLE IF NOT EXISTS notification_preferences (
     follow_up_reminder    BOOLEAN NOT NULL DEFAULT TRUE,
     application_check_in  BOOLEAN NOT NULL DEFAULT TRUE,
     interview_reminder    BOOLEAN NOT NULL DEFAULT TRUE,
+    offer_deadline        BOOLEAN NOT NULL DEFAULT TRUE,
     updated_at            TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
@@ -197,22 +280,371 @@ ON CONFLICT (name) DO NOTHING;
 -- ============================================================
 
 CREATE TABLE IF NOT EXISTS serp_job_cache (
-    id               SERIAL PRIMARY KEY,
-    user_id          INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    external_id      TEXT NOT NULL,
-    title            TEXT NOT NULL,
-    company_name     TEXT,
-    location         TEXT,
-    description      TEXT,
-    link             TEXT,
-    platform         VARCHAR(100),
-    posted_at        VARCHAR(100),
-    salary           VARCHAR(100),
-    saved_to_tracker BOOLEAN NOT NULL DEFAULT FALSE,
-    tracker_job_id   INT REFERENCES jobs(id) ON DELETE SET NULL,
-    fetched_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    id                SERIAL PRIMARY KEY,
+    user_id           INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    external_id       TEXT NOT NULL,
+    title             TEXT NOT NULL,
+    company_name      TEXT,
+    location          TEXT,
+    description       TEXT,
+    link              TEXT,
+    platform          VARCHAR(100),
+    posted_at         VARCHAR(100),
+    salary            VARCHAR(100),
+    saved_to_tracker  BOOLEAN NOT NULL DEFAULT FALSE,
+    tracker_job_id    INT REFERENCES jobs(id) ON DELETE SET NULL,
+    fetched_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    search_key        TEXT NOT NULL DEFAULT '',
+    position          INT NOT NULL DEFAULT 0,
+    company_key       TEXT NOT NULL DEFAULT '',
+    salary_min        NUMERIC(14, 2),
+    salary_max        NUMERIC(14, 2),
+    salary_currency   VARCHAR(3),
+    salary_period     VARCHAR(10),
+    salary_annual_min NUMERIC(14, 2),
+    salary_annual_max NUMERIC(14, 2),
+    salary_sort_value DOUBLE PRECISION,
+    posted_time       TIMESTAMP,
+    remote            BOOLEAN NOT NULL DEFAULT FALSE,
     UNIQUE (user_id, external_id)
 );
 
 CREATE INDEX IF NOT EXISTS idx_serp_job_cache_user_id    ON serp_job_cache(user_id);
 CREATE INDEX IF NOT EXISTS idx_serp_job_cache_fetched_at ON serp_job_cache(fetched_at);
+CREATE INDEX IF NOT EXISTS idx_serp_job_cache_search     ON serp_job_cache(user_id, search_key, position);
+
+-- The user's current recommendation search and where upstream paging left off.
+CREATE TABLE IF NOT EXISTS serp_search_state (
+    user_id         INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    search_key      TEXT NOT NULL,
+    next_page_token TEXT,
+    location        TEXT NOT NULL DEFAULT '',
+    date_posted     VARCHAR(10) NOT NULL DEFAULT '',
+    employment_type VARCHAR(20) NOT NULL DEFAULT '',
+    remote_only     BOOLEAN NOT NULL DEFAULT FALSE,
+    locations       TEXT[] NOT NULL DEFAULT '{}',
+    country         VARCHAR(2) NOT NULL DEFAULT '',
+    language        VARCHAR(8) NOT NULL DEFAULT '',
+    fetched_at      TIMESTAMP NOT NULL DEFAULT NOW(),
+    viewed_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_serp_search_state_viewed ON serp_search_state(viewed_at, fetched_at);
+
+-- Where the user wants recommended jobs searched.
+CREATE TABLE IF NOT EXISTS search_preferences (
+    user_id            INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    country            VARCHAR(2) NOT NULL DEFAULT '',
+    language           VARCHAR(8) NOT NULL DEFAULT '',
+    locations          TEXT[] NOT NULL DEFAULT '{}',
+    open_to_remote     BOOLEAN NOT NULL DEFAULT FALSE,
+    open_to_relocation BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_search_preferences_updated_at BEFORE UPDATE ON search_preferences
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================
+-- Contacts (recruiter CRM)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS contacts (
+    id                  SERIAL PRIMARY KEY,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                VARCHAR(150) NOT NULL,
+    company             VARCHAR(150),
+    role                VARCHAR(150),
+    email               VARCHAR(255),
+    linkedin_url        TEXT,
+    notes               TEXT,
+    next_touch_at       DATE,
+    next_touch_note     VARCHAR(255),
+    next_touch_notified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contacts_user_id    ON contacts(user_id);
+CREATE INDEX IF NOT EXISTS idx_contacts_next_touch ON contacts(next_touch_at) WHERE next_touch_notified = FALSE;
+
+CREATE TRIGGER update_contacts_updated_at
+BEFORE UPDATE ON contacts
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS contact_jobs (
+    contact_id INT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    job_id     INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (contact_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_jobs_job_id ON contact_jobs(job_id);
+
+CREATE TABLE IF NOT EXISTS contact_interactions (
+    id          SERIAL PRIMARY KEY,
+    contact_id  INT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type        VARCHAR(30) NOT NULL, -- email | call | coffee_chat | meeting | message | other
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    notes       TEXT,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_interactions_contact ON contact_interactions(contact_id, occurred_at DESC);
+
+-- ============================================================
+-- Job offers
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS job_offers (
+    job_id              INT PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    base_salary         NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    currency            CHAR(3) NOT NULL,
+    pay_period          VARCHAR(10) NOT NULL DEFAULT 'year', -- year | month | week | day | hour
+    bonus               NUMERIC(14, 2) NOT NULL DEFAULT 0,   -- expected yearly bonus, in currency
+    equity              VARCHAR(500),                        -- free-form grant description
+    equity_annual_value NUMERIC(14, 2) NOT NULL DEFAULT 0,   -- estimated yearly vesting value, in currency
+    benefits            TEXT,
+    start_date          DATE,
+    response_deadline   DATE,
+    remote_policy       VARCHAR(20),                         -- onsite | hybrid | remote
+    deadline_notified   BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_offers_user_id  ON job_offers(user_id);
+CREATE INDEX IF NOT EXISTS idx_job_offers_deadline ON job_offers(response_deadline) WHERE deadline_notified = FALSE;
+
+CREATE TRIGGER update_job_offers_updated_at
+BEFORE UPDATE ON job_offers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================================
+-- Job status history (funnel analytics)
+-- ============================================================
+
+CREATE TABLE IF NOT EXISTS job_status_events (
+    id          SERIAL PRIMARY KEY,
+    job_id      INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status   VARCHAR(50) NOT NULL,
+    changed_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_status_events_job_id  ON job_status_events(job_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_job_status_events_user_id ON job_status_events(user_id, changed_at);
+
+-- Recorded by trigger so every write path (tracker, board moves, recommended
+-- jobs) is captured.
+CREATE OR REPLACE FUNCTION record_job_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO job_status_events (job_id, user_id, from_status, to_status)
+        VALUES (NEW.id, NEW.user_id, NULL, NEW.status);
+    ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO job_status_events (job_id, user_id, from_status, to_status)
+        VALUES (NEW.id, NEW.user_id, OLD.status, NEW.status);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_job_status_change
+AFTER INSERT OR UPDATE OF status ON jobs
+FOR EACH ROW EXECUTE FUNCTION record_job_status_change();
+
+-- ============================================================
+-- Job attachments and description snapshots
+-- ============================================================
+
+-- Files kept alongside a tracked job: offer letters, take-home assignments,
+-- the exact CV that was sent. Stored in the database like users.cv.
+CREATE TABLE IF NOT EXISTS job_attachments (
+    id           SERIAL PRIMARY KEY,
+    job_id       INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    kind         VARCHAR(20) NOT NULL DEFAULT 'other', -- offer_letter | assignment | cv | other
+    size_bytes   BIGINT NOT NULL,
+    data         BYTEA NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_attachments_job_id  ON job_attachments(job_id);
+CREATE INDEX IF NOT EXISTS idx_job_attachments_user_id ON job_attachments(user_id);
+
+-- The posting as it looked when the job was saved from recommendations.
+-- Written once and never updated, so it survives the posting being taken down.
+CREATE TABLE IF NOT EXISTS job_description_snapshots (
+    job_id       INT PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title        TEXT NOT NULL,
+    company_name TEXT,
+    location     TEXT,
+    platform     VARCHAR(100),
+    link         TEXT,
+    description  TEXT NOT NULL,
+    salary       VARCHAR(100),
+    posted_at    VARCHAR(100),
+    captured_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- ============================================================
+-- Automation rules and job timeline events
+-- ============================================================
+
+-- Per-user rules the scheduler runs against stale jobs: "in <status> for more
+-- than <after_days> days" triggers any of mark ghosted, a one-off nudge and
+-- archive. With both nudge and archive, archiving waits grace_days after the
+-- nudge.
+CREATE TABLE IF NOT EXISTS automation_rules (
+    id           SERIAL PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    enabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    status       VARCHAR(50) NOT NULL, -- saved | applied | interview | offer | rejected
+    after_days   INT NOT NULL,
+    mark_ghosted BOOLEAN NOT NULL DEFAULT FALSE,
+    nudge        BOOLEAN NOT NULL DEFAULT FALSE,
+    archive      BOOLEAN NOT NULL DEFAULT FALSE,
+    grace_days   INT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_rules_user_id ON automation_rules(user_id);
+CREATE INDEX IF NOT EXISTS idx_automation_rules_enabled ON automation_rules(enabled) WHERE enabled = TRUE;
+
+CREATE TRIGGER update_automation_rules_updated_at
+BEFORE UPDATE ON automation_rules
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Changes made to a job by something other than the user, shown on the job's
+-- timeline next to its status history.
+CREATE TABLE IF NOT EXISTS job_events (
+    id         SERIAL PRIMARY KEY,
+    job_id     INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule_id    INT REFERENCES automation_rules(id) ON DELETE SET NULL,
+    event_type VARCHAR(30) NOT NULL, -- ghosted | nudged | archived
+    message    TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_events_job_id  ON job_events(job_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_job_events_rule_id ON job_events(rule_id, job_id);
+
+-- ============================================================
+-- User goals
+-- ============================================================
+
+-- Structured goals such as "apply to 10 jobs per week". Progress is computed
+-- from activity (jobs, daily_progress, contact interactions); "manual" goals,
+-- e.g. mock interviews, count the check-ins the user logs. The *_notified_for
+-- columns hold the start of the last period a notification was sent for, so
+-- each goal is announced at most once per period.
+CREATE TABLE IF NOT EXISTS user_goals (
+    id                   SERIAL PRIMARY KEY,
+    user_id              INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title                VARCHAR(100) NOT NULL,
+    metric               VARCHAR(30) NOT NULL, -- applications | interviews | focus_minutes | focus_sessions | networking | manual
+    target               INT NOT NULL,
+    period               VARCHAR(10) NOT NULL, -- weekly | monthly
+    reached_notified_for DATE,
+    at_risk_notified_for DATE,
+    created_at           TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_goals_user_id ON user_goals(user_id);
+
+CREATE TRIGGER update_user_goals_updated_at
+BEFORE UPDATE ON user_goals
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS goal_check_ins (
+    id        SERIAL PRIMARY KEY,
+    goal_id   INT NOT NULL REFERENCES user_goals(id) ON DELETE CASCADE,
+    amount    INT NOT NULL DEFAULT 1,
+    note      TEXT,
+    logged_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_check_ins_goal_id ON goal_check_ins(goal_id, logged_at);
+
+-- ============================================================
+-- Saved searches and new-match alerts
+-- ============================================================
+
+-- Named job searches that the scheduler re-runs. Every posting a search has
+-- returned is kept in saved_search_matches, so a run can tell new matches
+-- from ones already seen. alerted_at is set once the user has been told about
+-- a match; digest searches leave it NULL until the daily digest goes out.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id                 SERIAL PRIMARY KEY,
+    user_id            INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name               VARCHAR(100) NOT NULL,
+    query              VARCHAR(255) NOT NULL,
+    location           VARCHAR(255),
+    remote_only        BOOLEAN NOT NULL DEFAULT FALSE,
+    min_salary         INT,
+    excluded_companies TEXT[] NOT NULL DEFAULT '{}',
+    frequency          VARCHAR(10) NOT NULL DEFAULT 'daily', -- daily | weekly
+    alert              VARCHAR(10) NOT NULL DEFAULT 'instant', -- instant | digest | off
+    last_run_at        TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+
+CREATE TRIGGER update_saved_searches_updated_at
+BEFORE UPDATE ON saved_searches
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS saved_search_matches (
+    id              SERIAL PRIMARY KEY,
+    saved_search_id INT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    external_id     TEXT NOT NULL,
+    title           TEXT NOT NULL,
+    company_name    TEXT,
+    location        TEXT,
+    link            TEXT,
+    platform        VARCHAR(100),
+    salary          VARCHAR(100),
+    posted_at       VARCHAR(100),
+    first_seen_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    alerted_at      TIMESTAMP,
+    UNIQUE (saved_search_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_search_matches_pending
+    ON saved_search_matches(saved_search_id) WHERE alerted_at IS NULL;
+
+-- ============================================================
+-- Job Dismissals
+-- ============================================================
+
+-- Recommended jobs the user is not interested in. Reason 'company' hides every
+-- posting from the company; company_key is its name normalised for matching.
+CREATE TABLE IF NOT EXISTS job_dismissals (
+    id           SERIAL PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    external_id  TEXT NOT NULL,
+    title        TEXT NOT NULL,
+    company_name TEXT,
+    company_key  TEXT NOT NULL DEFAULT '',
+    location     TEXT,
+    reason       VARCHAR(20) NOT NULL DEFAULT 'not_relevant'
+                 CHECK (reason IN ('wrong_seniority', 'wrong_location', 'company', 'not_relevant')),
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_dismissals_company ON job_dismissals(user_id, company_key) WHERE reason = 'company';
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+const (
+	JobSourceManual      = "manual"
+	JobSourceRecommended = "recommended"
+)
+
+// ApplicationFunnelRow is one job's path through the funnel, as read from the
+// status history. Nil times mean the job never reached that point.
+type ApplicationFunnelRow struct {
+	JobID           int32
+	Platform        string
+	Source          string // manual | recommended
+	AppliedAt       *time.Time
+	InterviewAt     *time.Time // first time the job reached interview (or offer)
+	OfferAt         *time.Time
+	RejectedAt      *time.Time
+	FirstResponseAt *time.Time // first interview, offer or rejection after applying
+}
+
+// ApplicationAnalytics summarises how the job search is going over the last
+// Weeks weeks.
+type ApplicationAnalytics struct {
+	From                      string                  `json:"from"` // YYYY-MM-DD, a Monday
+	To                        string                  `json:"to"`   // YYYY-MM-DD
+	Weeks                     int                     `json:"weeks"`
+	TotalApplications         int64                   `json:"total_applications"`
+	ApplicationsPerWeek       float64                 `json:"applications_per_week"`
+	Funnel                    FunnelConversion        `json:"funnel"`
+	ResponseRate              float64                 `json:"response_rate"`
+	MedianDaysToFirstResponse *float64                `json:"median_days_to_first_response"`
+	ByPlatform                []FunnelBreakdown       `json:"by_platform"`
+	BySource                  []FunnelBreakdown       `json:"by_source"`
+	ChartPoints               []ApplicationChartPoint `json:"chart_points"`
+}
+
+// FunnelConversion counts the applications sent in the period and how far
+// they got. Rates are fractions between 0 and 1.
+type FunnelConversion struct {
+	Applied            int64   `json:"applied"`
+	Interviews         int64   `json:"interviews"`
+	Offers             int64   `json:"offers"`
+	Rejections         int64   `json:"rejections"`
+	AppliedToInterview float64 `json:"applied_to_interview"`
+	InterviewToOffer   float64 `json:"interview_to_offer"`
+	AppliedToOffer     float64 `json:"applied_to_offer"`
+}
+
+type FunnelBreakdown struct {
+	Key string `json:"key"`
+	FunnelConversion
+}
+
+// ApplicationChartPoint is one weekly bucket. Applications are counted by
+// application date; interviews, offers and rejections by the week they happened.
+type ApplicationChartPoint struct {
+	Label        string `json:"label"`
+	BucketKey    string `json:"bucket_key"`
+	Applications int64  `json:"applications"`
+	Interviews   int64  `json:"interviews"`
+	Offers       int64  `json:"offers"`
+	Rejections   int64  `json:"rejections"`
+}
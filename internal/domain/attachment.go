@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+const (
+	AttachmentKindOfferLetter = "offer_letter"
+	AttachmentKindAssignment  = "assignment"
+	AttachmentKindCV          = "cv"
+	AttachmentKindOther       = "other"
+)
+
+var AttachmentKinds = []string{
+	AttachmentKindOfferLetter,
+	AttachmentKindAssignment,
+	AttachmentKindCV,
+	AttachmentKindOther,
+}
+
+// JobAttachment is a file kept with a tracked job. Data is only loaded when
+// the file is downloaded.
+type JobAttachment struct {
+	ID          int32     `json:"id"`
+	JobID       int32     `json:"job_id"`
+	UserID      int32     `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Kind        string    `json:"kind"`
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+	Data        []byte    `json:"-"`
+}
+
+// StorageUsage is how much of their attachment quota a user has used.
+type StorageUsage struct {
+	UsedBytes    int64 `json:"used_bytes"`
+	QuotaBytes   int64 `json:"quota_bytes"`
+	MaxFileBytes int64 `json:"max_file_bytes"`
+}
+
+// JobDescriptionSnapshot is the posting as it was when a recommended job was
+// saved to the tracker. It is never updated.
+type JobDescriptionSnapshot struct {
+	JobID       int32     `json:"job_id"`
+	UserID      int32     `json:"user_id"`
+	Title       string    `json:"title"`
+	CompanyName string    `json:"company_name"`
+	Location    string    `json:"location"`
+	Platform    string    `json:"platform"`
+	Link        string    `json:"link"`
+	Description string    `json:"description"`
+	Salary      string    `json:"salary,omitempty"`
+	PostedAt    string    `json:"posted_at,omitempty"`
+	CapturedAt  time.Time `json:"captured_at"`
+}
@@ -0,0 +1,120 @@
+package domain
+
+import "time"
+
+// Actions an automation rule can take on a matching job.
+const (
+	RuleActionMarkGhosted = "mark_ghosted"
+	RuleActionNudge       = "nudge"
+	RuleActionArchive     = "archive"
+)
+
+// Job timeline event types. Status changes come from the status history; the
+// rest are recorded when a rule acts on the job.
+const (
+	JobEventStatusChange = "status_change"
+	JobEventGhosted      = "ghosted"
+	JobEventNudged       = "nudged"
+	JobEventArchived     = "archived"
+)
+
+// AutomationRule fires for jobs that have been in Status for more than
+// AfterDays days. A job is acted on once per stay in a status: restoring a job
+// the rule archived does not get it archived again until its status changes.
+type AutomationRule struct {
+	ID          int32     `json:"id"`
+	UserID      int32     `json:"user_id"`
+	Name        string    `json:"name"`
+	Enabled     bool      `json:"enabled"`
+	Status      string    `json:"status"`
+	AfterDays   int32     `json:"after_days"`
+	MarkGhosted bool      `json:"mark_ghosted"`
+	Nudge       bool      `json:"nudge"`
+	Archive     bool      `json:"archive"`
+	GraceDays   int32     `json:"grace_days"` // with Nudge and Archive: days between the nudge and archiving
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// AutomationRuleTemplates are suggested rules a user can start from. They are
+// not created automatically; rules only run once the user enables them.
+var AutomationRuleTemplates = []AutomationRule{
+	{Name: "Ghosted after 30 days", Status: JobStatusApplied, AfterDays: 30, MarkGhosted: true, Archive: true},
+	{Name: "Clear out stale saved jobs", Status: JobStatusSaved, AfterDays: 14, Nudge: true, Archive: true, GraceDays: 7},
+}
+
+// RuleActionEvent maps a rule action to the timeline event it records.
+var RuleActionEvent = map[string]string{
+	RuleActionMarkGhosted: JobEventGhosted,
+	RuleActionNudge:       JobEventNudged,
+	RuleActionArchive:     JobEventArchived,
+}
+
+type AutomationRuleRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Enabled     *bool  `json:"enabled"`
+	Status      string `json:"status" validate:"required,oneof=saved applied interview offer rejected"`
+	AfterDays   int32  `json:"after_days" validate:"required,min=1,max=365"`
+	MarkGhosted bool   `json:"mark_ghosted"`
+	Nudge       bool   `json:"nudge"`
+	Archive     bool   `json:"archive"`
+	GraceDays   int32  `json:"grace_days" validate:"min=0,max=90"`
+}
+
+func (r *AutomationRuleRequest) ToDomain(userID int32) AutomationRule {
+	rule := AutomationRule{
+		UserID:      userID,
+		Name:        r.Name,
+		Status:      r.Status,
+		AfterDays:   r.AfterDays,
+		MarkGhosted: r.MarkGhosted,
+		Nudge:       r.Nudge,
+		Archive:     r.Archive,
+		GraceDays:   r.GraceDays,
+	}
+	if r.Enabled != nil {
+		rule.Enabled = *r.Enabled
+	}
+	return rule
+}
+
+// RuleCandidate is a job in the rule's status together with what the rule
+// has already done to it.
+type RuleCandidate struct {
+	JobID       int32
+	UserID      int32
+	Title       string
+	CompanyName string
+	Status      string
+	StatusSince time.Time
+	Ghosted     bool
+	NudgedAt    *time.Time // last nudge from this rule
+	ArchivedAt  *time.Time // last archive by this rule
+}
+
+// RuleMatch is a job a rule would act on now, and how.
+type RuleMatch struct {
+	JobID        int32     `json:"job_id"`
+	Title        string    `json:"title"`
+	CompanyName  string    `json:"company_name"`
+	Status       string    `json:"status"`
+	StatusSince  time.Time `json:"status_since"`
+	DaysInStatus int32     `json:"days_in_status"`
+	Actions      []string  `json:"actions"`
+}
+
+type RulePreview struct {
+	Rule    AutomationRule `json:"rule"`
+	Matches []RuleMatch    `json:"matches"`
+}
+
+// JobTimelineEvent is one entry in a job's history.
+type JobTimelineEvent struct {
+	Type       string    `json:"type"`
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status,omitempty"`
+	RuleID     *int32    `json:"rule_id,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Automatic  bool      `json:"automatic"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+const (
+	InteractionTypeEmail      = "email"
+	InteractionTypeCall       = "call"
+	InteractionTypeCoffeeChat = "coffee_chat"
+	InteractionTypeMeeting    = "meeting"
+	InteractionTypeMessage    = "message"
+	InteractionTypeOther      = "other"
+)
+
+// Contact is a recruiter, referrer or anyone else the user is talking to
+// during the search. A contact can be linked to many tracked jobs.
+type Contact struct {
+	ID                int32      `json:"id"`
+	UserID            int32      `json:"user_id"`
+	Name              string     `json:"name"`
+	Company           string     `json:"company"`
+	Role              string     `json:"role"`
+	Email             string     `json:"email"`
+	LinkedInURL       string     `json:"linkedin_url"`
+	Notes             string     `json:"notes"`
+	NextTouchAt       string     `json:"next_touch_at,omitempty"` // YYYY-MM-DD
+	NextTouchNote     string     `json:"next_touch_note,omitempty"`
+	JobIDs            []int32    `json:"job_ids"`
+	LastInteractionAt *time.Time `json:"last_interaction_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// ContactDetail is a contact together with its interaction log, newest first.
+type ContactDetail struct {
+	Contact
+	Interactions []ContactInteraction `json:"interactions"`
+}
+
+type ContactRequest struct {
+	Name          string `json:"name" validate:"required,min=1,max=150"`
+	Company       string `json:"company" validate:"max=150"`
+	Role          string `json:"role" validate:"max=150"`
+	Email         string `json:"email" validate:"omitempty,email,max=255"`
+	LinkedInURL   string `json:"linkedin_url" validate:"omitempty,url,max=500"`
+	Notes         string `json:"notes" validate:"max=5000"`
+	NextTouchAt   string `json:"next_touch_at" validate:"omitempty,datetime=2006-01-02"`
+	NextTouchNote string `json:"next_touch_note" validate:"max=255"`
+	// Optional on update: nil keeps the current links, an empty list removes them.
+	JobIDs []int32 `json:"job_ids,omitempty" validate:"omitempty,max=50"`
+}
+
+func (r ContactRequest) ToDomain(userID int32) Contact {
+	return Contact{
+		UserID:        userID,
+		Name:          r.Name,
+		Company:       r.Company,
+		Role:          r.Role,
+		Email:         r.Email,
+		LinkedInURL:   r.LinkedInURL,
+		Notes:         r.Notes,
+		NextTouchAt:   r.NextTouchAt,
+		NextTouchNote: r.NextTouchNote,
+		JobIDs:        r.JobIDs,
+	}
+}
+
+// ContactFilter narrows the contact list. Query matches name, company or role.
+type ContactFilter struct {
+	JobID *int32
+	Query string
+}
+
+type ContactInteraction struct {
+	ID         int32     `json:"id"`
+	ContactID  int32     `json:"contact_id"`
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ContactInteractionRequest struct {
+	Type string `json:"type" validate:"required,oneof=email call coffee_chat meeting message other"`
+	// Defaults to now when omitted.
+	OccurredAt *time.Time `json:"occurred_at,omitempty"`
+	Notes      string     `json:"notes" validate:"max=5000"`
+}
+
+// ContactFollowUp is a contact whose next-touch date has arrived.
+type ContactFollowUp struct {
+	ContactID int32
+	UserID    int32
+	Name      string
+	Company   string
+	Note      string
+}
+
+// JobDetail is a tracked job together with the people linked to it, its
+// attachments and, when they exist, its offer and description snapshot.
+type JobDetail struct {
+	Job
+	Contacts    []Contact               `json:"contacts"`
+	Attachments []JobAttachment         `json:"attachments"`
+	Offer       *JobOffer               `json:"offer,omitempty"`
+	Snapshot    *JobDescriptionSnapshot `json:"description_snapshot,omitempty"`
+}
@@ -0,0 +1,51 @@
+package domain
+
+// DuplicateMatch is an existing job that looks like the same role as the one
+// being added. Reason is one of the jobmatch reasons, e.g. "same_link".
+type DuplicateMatch struct {
+	JobID       int32  `json:"job_id"`
+	Title       string `json:"title"`
+	CompanyName string `json:"company_name"`
+	Location    string `json:"location"`
+	Status      string `json:"status"`
+	Link        string `json:"link"`
+	Archived    bool   `json:"archived"`
+	Reason      string `json:"reason"`
+}
+
+// CreatedJob is returned when a job is added by hand. The job is always
+// created; Duplicates lets the client offer a merge.
+type CreatedJob struct {
+	ID         int32            `json:"id"`
+	Duplicates []DuplicateMatch `json:"duplicates,omitempty"`
+}
+
+// SavedJob is a recommended job saved to the tracker, with any jobs it
+// appears to duplicate.
+type SavedJob struct {
+	Job
+	Duplicates []DuplicateMatch `json:"duplicates,omitempty"`
+}
+
+// MergeJobsRequest folds DuplicateID into the job in the URL.
+type MergeJobsRequest struct {
+	DuplicateID int32 `json:"duplicate_id" validate:"required,gt=0"`
+}
+
+// statusRank orders statuses by how far along the job is. A rejection ends
+// the process so it outranks everything but an offer.
+var statusRank = map[string]int{
+	JobStatusSaved:     0,
+	JobStatusApplied:   1,
+	JobStatusInterview: 2,
+	JobStatusRejected:  3,
+	JobStatusOffer:     4,
+}
+
+// MoreAdvancedStatus returns whichever of a and b is further along.
+func MoreAdvancedStatus(a, b string) string {
+	if statusRank[b] > statusRank[a] {
+		return b
+	}
+	return a
+}
@@ -34,6 +34,33 @@ var (
 	ErrJobAlreadyApplied         = errors.New("job already applied")
 	ErrNoApplyLink               = errors.New("this listing has no apply link")
 	ErrCVNotFound                = errors.New("cv not found")
+	ErrJobImportFailed           = errors.New("could not find a job posting on this page")
+	ErrJobImportFetchFailed      = errors.New("failed to fetch job posting page")
+	ErrStageNotFound             = errors.New("pipeline stage not found")
+	ErrStageNameTaken            = errors.New("a pipeline stage with this name already exists")
+	ErrLastStageForStatus        = errors.New("cannot remove the last stage for this status")
+	ErrInvalidStageOrder         = errors.New("stage order must list every stage exactly once")
+	ErrContactNotFound           = errors.New("contact not found")
+	ErrInteractionNotFound       = errors.New("contact interaction not found")
+	ErrOfferNotFound             = errors.New("no offer recorded for this job")
+	ErrUnsupportedCurrency       = errors.New("no exchange rate configured for this currency")
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrSnapshotNotFound          = errors.New("no description snapshot for this job")
+	ErrUnsupportedFileType       = errors.New("file type not allowed")
+	ErrStorageQuotaExceeded      = errors.New("attachment storage quota exceeded")
+	ErrRuleNotFound              = errors.New("automation rule not found")
+	ErrRuleHasNoAction           = errors.New("a rule needs at least one action")
+	ErrGoalNotFound              = errors.New("goal not found")
+	ErrSessionPresetNotFound     = errors.New("session preset not found")
+	ErrUnknownTimezone           = errors.New("unknown time zone")
+	ErrInvalidSessionPlan        = errors.New("a session needs a duration, or a pomodoro plan that fits in a day; a long break needs a length")
+	ErrGoalNotManual             = errors.New("progress can only be logged for manual goals")
+	ErrSavedSearchNotFound       = errors.New("saved search not found")
+	ErrInvalidCursor             = errors.New("invalid or expired cursor, reload the first page")
+	ErrForbidden                 = errors.New("forbidden")
+	ErrDismissalNotFound         = errors.New("dismissal not found")
+	ErrSearchRateLimited         = errors.New("too many new job searches, please try again later")
+	ErrSearchQuotaExhausted      = errors.New("job search is paused until the monthly search budget resets")
 )
 
 // AppError represents an application error with HTTP status code
@@ -95,6 +122,46 @@ func GetHTTPStatus(err error) int {
 		return http.StatusNotFound
 	case errors.Is(err, ErrJobAlreadyTracked), errors.Is(err, ErrJobAlreadyApplied):
 		return http.StatusConflict
+	case errors.Is(err, ErrStageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrStageNameTaken), errors.Is(err, ErrLastStageForStatus):
+		return http.StatusConflict
+	case errors.Is(err, ErrInvalidStageOrder):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrContactNotFound), errors.Is(err, ErrInteractionNotFound), errors.Is(err, ErrOfferNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrUnsupportedCurrency):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrAttachmentNotFound), errors.Is(err, ErrSnapshotNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrFileSizeExceedsLimit), errors.Is(err, ErrStorageQuotaExceeded):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, ErrUnsupportedFileType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrRuleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrRuleHasNoAction):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrGoalNotFound), errors.Is(err, ErrSessionPresetNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrGoalNotManual), errors.Is(err, ErrInvalidSessionPlan), errors.Is(err, ErrUnknownTimezone):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrSavedSearchNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrInvalidCursor):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDismissalNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrSearchRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrSearchQuotaExhausted):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrJobImportFailed):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, ErrJobImportFetchFailed):
+		return http.StatusBadGateway
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,84 @@
+package domain
+
+import "time"
+
+// What a goal measures. All but GoalMetricManual are computed from activity.
+const (
+	GoalMetricApplications  = "applications"   // jobs applied to
+	GoalMetricInterviews    = "interviews"     // jobs that reached the interview stage
+	GoalMetricFocusMinutes  = "focus_minutes"  // minutes of focus sessions
+	GoalMetricFocusSessions = "focus_sessions" // completed focus sessions
+	GoalMetricNetworking    = "networking"     // logged contact interactions
+	GoalMetricManual        = "manual"         // check-ins logged by the user, e.g. mock interviews
+)
+
+const (
+	GoalPeriodWeekly  = "weekly"
+	GoalPeriodMonthly = "monthly"
+)
+
+// Goal progress states. A goal is at risk when it is behind the pace needed
+// to reach the target by the end of the period.
+const (
+	GoalStatusReached = "reached"
+	GoalStatusOnTrack = "on_track"
+	GoalStatusAtRisk  = "at_risk"
+)
+
+type Goal struct {
+	ID        int32     `json:"id"`
+	UserID    int32     `json:"user_id"`
+	Title     string    `json:"title"`
+	Metric    string    `json:"metric"`
+	Target    int32     `json:"target"`
+	Period    string    `json:"period"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ReachedNotifiedFor *time.Time `json:"-"`
+	AtRiskNotifiedFor  *time.Time `json:"-"`
+}
+
+type GoalRequest struct {
+	Title  string `json:"title" validate:"required,max=100"`
+	Metric string `json:"metric" validate:"required,oneof=applications interviews focus_minutes focus_sessions networking manual"`
+	Target int32  `json:"target" validate:"required,min=1,max=100000"`
+	Period string `json:"period" validate:"required,oneof=weekly monthly"`
+}
+
+func (r *GoalRequest) ToDomain(userID int32) Goal {
+	return Goal{
+		UserID: userID,
+		Title:  r.Title,
+		Metric: r.Metric,
+		Target: r.Target,
+		Period: r.Period,
+	}
+}
+
+// GoalCheckInRequest logs progress on a manual goal.
+type GoalCheckInRequest struct {
+	Amount int32  `json:"amount" validate:"omitempty,min=1,max=1000"` // defaults to 1
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// GoalActivity is everything goals measure, totalled over one period.
+type GoalActivity struct {
+	Applications  int32
+	Interviews    int32
+	FocusSeconds  int32
+	FocusSessions int32
+	Networking    int32
+	CheckIns      map[int32]int32 // manual goal ID -> amount logged
+}
+
+// GoalProgress is a goal with its progress in the current period.
+type GoalProgress struct {
+	Goal
+	Current     int32     `json:"current"`
+	Percent     int32     `json:"percent"` // capped at 100
+	Status      string    `json:"status"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"` // exclusive
+	DaysLeft    int32     `json:"days_left"`
+}
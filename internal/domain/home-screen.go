@@ -15,25 +15,213 @@ const (
 	SessionStatusAbandoned SessionStatus = "abandoned"
 )
 
+// FocusSession is a lock-in session. Its clock is kept by the server: while
+// the session runs, ElapsedSeconds plus the time since RunningSince is the
+// focus time so far. ReportedSeconds is what the client's own timer last
+// said; Flagged marks a session where the two disagreed. JobID, GoalID and
+// Intention say what the session was for; Reflection and FocusScore, from 1
+// to 5, are the user's own take once it ended.
+//
+// A pomodoro session runs its Pomodoro plan, whose length is its
+// DurationSeconds. Its clock runs through work and breaks alike, but only
+// work counts as ElapsedSeconds; the breaks are BreakSeconds. Phase is where
+// the plan stands while the session is in progress.
 type FocusSession struct {
-	ID              int32         `json:"id"`
-	UserID          int32         `json:"user_id"`
-	DurationSeconds int32         `json:"duration_seconds"` // planned
-	ElapsedSeconds  int32         `json:"elapsed_seconds"`  // completed so far
-	Status          SessionStatus `json:"status"`
-	StartedAt       time.Time     `json:"started_at"`
-	EndedAt         *time.Time    `json:"ended_at,omitempty"`
-	CreatedAt       time.Time     `json:"created_at"`
-	UpdatedAt       time.Time     `json:"updated_at"`
+	ID              int32          `json:"id"`
+	UserID          int32          `json:"user_id"`
+	DurationSeconds int32          `json:"duration_seconds"` // planned
+	ElapsedSeconds  int32          `json:"elapsed_seconds"`  // completed so far
+	ReportedSeconds int32          `json:"reported_seconds"`
+	Flagged         bool           `json:"flagged"`
+	JobID           *int32         `json:"job_id,omitempty"`
+	GoalID          *int32         `json:"goal_id,omitempty"`
+	Intention       string         `json:"intention,omitempty"`
+	Reflection      string         `json:"reflection,omitempty"`
+	FocusScore      *int32         `json:"focus_score,omitempty"`
+	Mode            string         `json:"mode"`
+	Pomodoro        *PomodoroPlan  `json:"pomodoro,omitempty"`
+	BreakSeconds    int32          `json:"break_seconds,omitempty"`
+	Phase           *PomodoroState `json:"phase,omitempty"`
+	Status          SessionStatus  `json:"status"`
+	RunningSince    *time.Time     `json:"running_since,omitempty"`
+	StartedAt       time.Time      `json:"started_at"`
+	EndedAt         *time.Time     `json:"ended_at,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
+// SessionSegment is a stretch of a session during which its clock ran. EndedAt
+// is nil while it is still running.
+type SessionSegment struct {
+	StartedAt time.Time
+	EndedAt   *time.Time
+}
+
+// SessionStop stops a session's clock at At, pausing or ending the session
+// with the focus time the server settled on. Reflection and FocusScore are
+// only kept when it ends.
+type SessionStop struct {
+	Status          SessionStatus
+	ElapsedSeconds  int32
+	BreakSeconds    int32
+	ReportedSeconds int32
+	Flagged         bool
+	Reflection      string
+	FocusScore      *int32
+	At              time.Time
+}
+
+// StartSessionRequest may say what the session is for: a tracked job, a goal,
+// a free-text intention such as "tailor CV for Flutterwave", or several.
+// A single session needs DurationSeconds; a pomodoro session needs a
+// Pomodoro plan or one of the user's presets, and lasts as long as the plan.
 type StartSessionRequest struct {
-	DurationSeconds int32 `json:"duration_seconds" validate:"required,min=60,max=86400"`
+	Mode            string        `json:"mode" validate:"omitempty,oneof=single pomodoro"`
+	DurationSeconds int32         `json:"duration_seconds" validate:"omitempty,min=60,max=86400"`
+	Pomodoro        *PomodoroPlan `json:"pomodoro" validate:"omitempty"`
+	PresetID        *int32        `json:"preset_id" validate:"omitempty,min=1"`
+	JobID           *int32        `json:"job_id" validate:"omitempty,min=1"`
+	GoalID          *int32        `json:"goal_id" validate:"omitempty,min=1"`
+	Intention       string        `json:"intention" validate:"max=200"`
 }
 
+// UpdateSessionRequest carries the client's own count of the session's focus
+// time. The server keeps its own; this can only lower it. Reflection and
+// FocusScore are read when the session ends.
 type UpdateSessionRequest struct {
-	ElapsedSeconds int32         `json:"elapsed_seconds" validate:"required,min=0"`
+	ElapsedSeconds int32         `json:"elapsed_seconds" validate:"min=0"`
 	Status         SessionStatus `json:"status" validate:"required,oneof=active paused completed abandoned"`
+	Reflection     string        `json:"reflection" validate:"max=2000"`
+	FocusScore     *int32        `json:"focus_score" validate:"omitempty,min=1,max=5"`
+}
+
+// ─────────────────────────────────────────
+// Pomodoro
+// ─────────────────────────────────────────
+
+const (
+	SessionModeSingle   = "single"
+	SessionModePomodoro = "pomodoro"
+)
+
+type SessionPhase string
+
+const (
+	SessionPhaseWork       SessionPhase = "work"
+	SessionPhaseShortBreak SessionPhase = "short_break"
+	SessionPhaseLongBreak  SessionPhase = "long_break"
+	SessionPhaseDone       SessionPhase = "done"
+)
+
+// PomodoroPlan is Cycles work intervals with a break after each but the
+// last. Every LongBreakEvery-th break is a long one; zero means none are.
+type PomodoroPlan struct {
+	WorkSeconds       int32 `json:"work_seconds" validate:"required,min=60,max=14400"`
+	ShortBreakSeconds int32 `json:"short_break_seconds" validate:"required,min=60,max=3600"`
+	LongBreakSeconds  int32 `json:"long_break_seconds" validate:"min=0,max=7200"`
+	Cycles            int32 `json:"cycles" validate:"required,min=1,max=16"`
+	LongBreakEvery    int32 `json:"long_break_every" validate:"min=0,max=16"`
+}
+
+// Valid reports whether the plan is complete: a long break needs a length.
+func (p PomodoroPlan) Valid() bool {
+	return p.WorkSeconds > 0 && p.ShortBreakSeconds > 0 && p.Cycles > 0 &&
+		(p.LongBreakEvery == 0 || p.LongBreakSeconds > 0)
+}
+
+// breakAfter returns the break that follows work cycle n, counting from 1.
+func (p PomodoroPlan) breakAfter(n int32) (SessionPhase, int32) {
+	if p.LongBreakEvery > 0 && n%p.LongBreakEvery == 0 {
+		return SessionPhaseLongBreak, p.LongBreakSeconds
+	}
+	return SessionPhaseShortBreak, p.ShortBreakSeconds
+}
+
+// TotalSeconds is how long the plan runs, breaks included.
+func (p PomodoroPlan) TotalSeconds() int32 {
+	total := p.Cycles * p.WorkSeconds
+	for n := int32(1); n < p.Cycles; n++ {
+		_, seconds := p.breakAfter(n)
+		total += seconds
+	}
+	return total
+}
+
+// At returns where the plan stands once its clock has run for clock seconds.
+func (p PomodoroPlan) At(clock int32) PomodoroState {
+	state := PomodoroState{Cycles: p.Cycles}
+	for n := int32(1); n <= p.Cycles; n++ {
+		state.Cycle = n
+		if clock < p.WorkSeconds {
+			state.Phase = SessionPhaseWork
+			state.PhaseRemainingSeconds = p.WorkSeconds - clock
+			state.WorkSeconds += clock
+			return state
+		}
+		clock -= p.WorkSeconds
+		state.WorkSeconds += p.WorkSeconds
+		if n == p.Cycles {
+			break
+		}
+		phase, seconds := p.breakAfter(n)
+		if clock < seconds {
+			state.Phase = phase
+			state.PhaseRemainingSeconds = seconds - clock
+			return state
+		}
+		clock -= seconds
+	}
+	state.Phase = SessionPhaseDone
+	return state
+}
+
+// PomodoroState is where a pomodoro session's plan stands: the current phase,
+// the work cycle it belongs to and the seconds left in it. PhaseEndsAt is
+// set while the clock runs. WorkSeconds is the work done so far.
+type PomodoroState struct {
+	Phase                 SessionPhase `json:"phase"`
+	Cycle                 int32        `json:"cycle"`
+	Cycles                int32        `json:"cycles"`
+	PhaseRemainingSeconds int32        `json:"phase_remaining_seconds"`
+	PhaseEndsAt           *time.Time   `json:"phase_ends_at,omitempty"`
+	WorkSeconds           int32        `json:"work_seconds"`
+}
+
+// SessionPreset is a pomodoro plan the user saved under a name.
+type SessionPreset struct {
+	ID     int32  `json:"id"`
+	UserID int32  `json:"user_id"`
+	Name   string `json:"name"`
+	PomodoroPlan
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SessionPresetRequest creates or replaces a preset.
+type SessionPresetRequest struct {
+	Name string `json:"name" validate:"required,max=60"`
+	PomodoroPlan
+}
+
+// SessionFilter narrows the session history. Zero values mean "no filter";
+// Query matches the intention or reflection.
+type SessionFilter struct {
+	JobID         *int32
+	GoalID        *int32
+	Status        SessionStatus
+	Query         string
+	MinFocusScore *int32
+}
+
+// JobFocusTime is the focus time completed sessions spent on a tracked job.
+type JobFocusTime struct {
+	JobID             int32     `json:"job_id"`
+	JobTitle          string    `json:"job_title"`
+	CompanyName       string    `json:"company_name,omitempty"`
+	TotalFocusSeconds int64     `json:"total_focus_seconds"`
+	Sessions          int64     `json:"sessions"`
+	AverageFocusScore *float64  `json:"average_focus_score,omitempty"`
+	LastSessionAt     time.Time `json:"last_session_at"`
 }
 
 // ─────────────────────────────────────────
@@ -105,4 +293,5 @@ type HomeScreenData struct {
 	WeeklyProgress ProgressSummary `json:"weekly_progress"`
 	RecentBadges   []UserBadge     `json:"recent_badges"`
 	TotalBadges    int32           `json:"total_badges"`
+	Goals          []GoalProgress  `json:"goals"`
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,21 @@ const (
 	JobStatusRejected  = "rejected"
 )
 
+// JobStatuses lists the base statuses in pipeline order.
+var JobStatuses = []string{JobStatusSaved, JobStatusApplied, JobStatusInterview, JobStatusOffer, JobStatusRejected}
+
+const (
+	JobPriorityNone   int32 = 0
+	JobPriorityLow    int32 = 1
+	JobPriorityMedium int32 = 2
+	JobPriorityHigh   int32 = 3
+)
+
+const (
+	maxTagsPerJob = 20
+	maxTagLength  = 50
+)
+
 type Job struct {
 	ID          int32     `json:"id"`
 	UserId      int32     `json:"user_id"`
@@ -24,6 +40,21 @@ type Job struct {
 	Notes       string    `json:"notes"`
 	DateApplied string    `json:"date_applied"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	Priority      int32    `json:"priority"` // 0 none | 1 low | 2 medium | 3 high
+	StageID       *int32   `json:"stage_id,omitempty"`
+	BoardPosition int32    `json:"board_position"`
+	Tags          []string `json:"tags"`
+
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"` // set while the job is in the trash
+	GhostedAt  *time.Time `json:"ghosted_at,omitempty"` // no reply; set by an automation rule
+}
+
+// TrashedJob is a job in the trash and the time it will be purged for good.
+type TrashedJob struct {
+	Job
+	PurgeAt time.Time `json:"purge_at"`
 }
 
 type JobRequest struct {
@@ -35,6 +66,26 @@ type JobRequest struct {
 	Notes       string `json:"notes"`
 	Status      string `json:"status"`
 	DateApplied string `json:"date_applied"` // DD-MM-YYYY
+
+	// Optional on update: nil leaves the current value untouched.
+	Priority *int32   `json:"priority,omitempty" validate:"omitempty,min=0,max=3"`
+	StageID  *int32   `json:"stage_id,omitempty"`
+	Tags     []string `json:"tags,omitempty" validate:"omitempty,max=20,dive,max=50"`
+}
+
+// JobFilter narrows the job list. Zero values mean "no filter";
+// a job must carry every tag in Tags to match.
+type JobFilter struct {
+	Tags     []string
+	Priority *int32
+	StageID  *int32
+	Status   string
+	Archived bool // list archived jobs instead of active ones
+}
+
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int32  `json:"count"`
 }
 
 type DirectApplyRequest struct {
@@ -47,7 +98,7 @@ type DirectApplyResult struct {
 }
 
 func (j JobRequest) ToDomain(userId int32) Job {
-	return Job{
+	job := Job{
 		UserId:      userId,
 		Title:       j.Title,
 		CompanyName: j.CompanyName,
@@ -57,5 +108,35 @@ func (j JobRequest) ToDomain(userId int32) Job {
 		Status:      j.Status,
 		Notes:       j.Notes,
 		DateApplied: j.DateApplied,
+		StageID:     j.StageID,
+	}
+	if j.Priority != nil {
+		job.Priority = *j.Priority
+	}
+	if j.Tags != nil {
+		job.Tags = NormalizeTags(j.Tags)
+	}
+	return job
+}
+
+// NormalizeTags lower-cases, trims and de-duplicates tags, dropping empty ones.
+// The result is never nil so callers can tell "clear all tags" from "not sent".
+func NormalizeTags(tags []string) []string {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.Join(strings.Fields(t), " "))
+		if r := []rune(t); len(r) > maxTagLength {
+			t = string(r[:maxTagLength])
+		}
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+		if len(out) == maxTagsPerJob {
+			break
+		}
 	}
+	return out
 }
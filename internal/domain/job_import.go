@@ -0,0 +1,46 @@
+package domain
+
+// ImportJobRequest is the body for POST /jobs/from-url.
+// Either URL or HTML must be set. HTML is used by the browser-extension clipper,
+// which already has the rendered page; URL is then only used as the job link.
+type ImportJobRequest struct {
+	URL  string `json:"url" validate:"omitempty,url,max=2048"`
+	HTML string `json:"html" validate:"omitempty,max=2097152"`
+}
+
+// ImportedJob holds the fields extracted from a job posting page.
+type ImportedJob struct {
+	Title       string `json:"title"`
+	CompanyName string `json:"company_name"`
+	Location    string `json:"location"`
+	Salary      string `json:"salary,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link"`
+	Platform    string `json:"platform"`
+	Source      string `json:"source"` // json-ld | greenhouse | lever | workable | opengraph
+}
+
+// JobImportResult is returned to the client so it can review the prefilled
+// job before creating it with POST /jobs.
+type JobImportResult struct {
+	Job         JobRequest `json:"job"`
+	Salary      string     `json:"salary,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Source      string     `json:"source"`
+}
+
+func (j ImportedJob) ToResult() JobImportResult {
+	return JobImportResult{
+		Job: JobRequest{
+			Title:       j.Title,
+			CompanyName: j.CompanyName,
+			Location:    j.Location,
+			Platform:    j.Platform,
+			Link:        j.Link,
+			Status:      JobStatusSaved,
+		},
+		Salary:      j.Salary,
+		Description: j.Description,
+		Source:      j.Source,
+	}
+}
@@ -5,11 +5,19 @@ import "time"
 type NotificationType string
 
 const (
-	NotificationTypeSessionCompleted NotificationType = "session_completed"
-	NotificationTypeStreakMilestone  NotificationType = "streak_milestone"
-	NotificationTypeBadgeEarned      NotificationType = "badge_earned"
-	NotificationTypeDailyReminder    NotificationType = "daily_reminder"
-	NotificationTypeStreakWarning    NotificationType = "streak_warning"
+	NotificationTypeSessionCompleted  NotificationType = "session_completed"
+	NotificationTypeSessionExpired    NotificationType = "session_expired"
+	NotificationTypeStreakMilestone   NotificationType = "streak_milestone"
+	NotificationTypeBadgeEarned       NotificationType = "badge_earned"
+	NotificationTypeDailyReminder     NotificationType = "daily_reminder"
+	NotificationTypeStreakWarning     NotificationType = "streak_warning"
+	NotificationTypeContactFollowUp   NotificationType = "contact_follow_up"
+	NotificationTypeOfferDeadline     NotificationType = "offer_deadline"
+	NotificationTypeJobNudge          NotificationType = "job_nudge"
+	NotificationTypeGoalReached       NotificationType = "goal_reached"
+	NotificationTypeGoalAtRisk        NotificationType = "goal_at_risk"
+	NotificationTypeSavedSearchMatch  NotificationType = "saved_search_match"
+	NotificationTypeSavedSearchDigest NotificationType = "saved_search_digest"
 )
 
 type Notification struct {
@@ -16,6 +16,7 @@ type NotificationPreferences struct {
 	FollowUpReminder   bool      `json:"follow_up_reminder"`
 	ApplicationCheckIn bool      `json:"application_check_in"`
 	InterviewReminder  bool      `json:"interview_reminder"`
+	OfferDeadline      bool      `json:"offer_deadline"`
 	UpdatedAt          time.Time `json:"updated_at"`
 }
 
@@ -32,6 +33,7 @@ type UpdateNotificationPreferencesRequest struct {
 	FollowUpReminder   bool `json:"follow_up_reminder"`
 	ApplicationCheckIn bool `json:"application_check_in"`
 	InterviewReminder  bool `json:"interview_reminder"`
+	OfferDeadline      bool `json:"offer_deadline"`
 }
 
 func DefaultNotificationPreferences(userID int32) NotificationPreferences {
@@ -49,6 +51,7 @@ func DefaultNotificationPreferences(userID int32) NotificationPreferences {
 		FollowUpReminder:   true,
 		ApplicationCheckIn: true,
 		InterviewReminder:  true,
+		OfferDeadline:      true,
 	}
 }
 
@@ -58,7 +61,7 @@ func (p NotificationPreferences) AllowsInApp(notifType NotificationType) bool {
 	}
 
 	switch notifType {
-	case NotificationTypeSessionCompleted:
+	case NotificationTypeSessionCompleted, NotificationTypeSessionExpired:
 		return p.SessionCompleted
 	case NotificationTypeStreakMilestone:
 		return p.StreakMilestone
@@ -68,6 +71,14 @@ func (p NotificationPreferences) AllowsInApp(notifType NotificationType) bool {
 		return p.DailyReminder
 	case NotificationTypeStreakWarning:
 		return p.StreakWarning
+	case NotificationTypeContactFollowUp:
+		return p.FollowUpReminder
+	case NotificationTypeJobNudge:
+		return p.ApplicationCheckIn
+	case NotificationTypeGoalReached, NotificationTypeGoalAtRisk:
+		return p.MotivationalNudges
+	case NotificationTypeOfferDeadline:
+		return p.OfferDeadline
 	default:
 		return true
 	}
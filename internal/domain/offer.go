@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+const (
+	PayPeriodYear  = "year"
+	PayPeriodMonth = "month"
+	PayPeriodWeek  = "week"
+	PayPeriodDay   = "day"
+	PayPeriodHour  = "hour"
+)
+
+const (
+	RemotePolicyOnsite = "onsite"
+	RemotePolicyHybrid = "hybrid"
+	RemotePolicyRemote = "remote"
+)
+
+// periodsPerYear converts a pay period to a yearly figure, assuming a
+// 52-week year of five 8-hour days.
+var periodsPerYear = map[string]float64{
+	PayPeriodYear:  1,
+	PayPeriodMonth: 12,
+	PayPeriodWeek:  52,
+	PayPeriodDay:   260,
+	PayPeriodHour:  2080,
+}
+
+// JobOffer holds the terms of an offer for a tracked job. Bonus and
+// EquityAnnualValue are yearly amounts in Currency.
+type JobOffer struct {
+	JobID             int32     `json:"job_id"`
+	UserID            int32     `json:"user_id"`
+	BaseSalary        float64   `json:"base_salary"`
+	Currency          string    `json:"currency"`
+	PayPeriod         string    `json:"pay_period"`
+	Bonus             float64   `json:"bonus"`
+	Equity            string    `json:"equity"`
+	EquityAnnualValue float64   `json:"equity_annual_value"`
+	Benefits          string    `json:"benefits"`
+	StartDate         string    `json:"start_date,omitempty"`        // YYYY-MM-DD
+	ResponseDeadline  string    `json:"response_deadline,omitempty"` // YYYY-MM-DD
+	RemotePolicy      string    `json:"remote_policy,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// AnnualBase is the base salary scaled to a year.
+func (o JobOffer) AnnualBase() float64 {
+	multiplier, ok := periodsPerYear[o.PayPeriod]
+	if !ok {
+		multiplier = 1
+	}
+	return o.BaseSalary * multiplier
+}
+
+type JobOfferRequest struct {
+	BaseSalary        float64 `json:"base_salary" validate:"gte=0"`
+	Currency          string  `json:"currency" validate:"required,len=3,alpha"`
+	PayPeriod         string  `json:"pay_period" validate:"omitempty,oneof=year month week day hour"`
+	Bonus             float64 `json:"bonus" validate:"gte=0"`
+	Equity            string  `json:"equity" validate:"max=500"`
+	EquityAnnualValue float64 `json:"equity_annual_value" validate:"gte=0"`
+	Benefits          string  `json:"benefits" validate:"max=5000"`
+	StartDate         string  `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	ResponseDeadline  string  `json:"response_deadline" validate:"omitempty,datetime=2006-01-02"`
+	RemotePolicy      string  `json:"remote_policy" validate:"omitempty,oneof=onsite hybrid remote"`
+}
+
+func (r JobOfferRequest) ToDomain(userID, jobID int32) JobOffer {
+	offer := JobOffer{
+		JobID:             jobID,
+		UserID:            userID,
+		BaseSalary:        r.BaseSalary,
+		Currency:          r.Currency,
+		PayPeriod:         r.PayPeriod,
+		Bonus:             r.Bonus,
+		Equity:            r.Equity,
+		EquityAnnualValue: r.EquityAnnualValue,
+		Benefits:          r.Benefits,
+		StartDate:         r.StartDate,
+		ResponseDeadline:  r.ResponseDeadline,
+		RemotePolicy:      r.RemotePolicy,
+	}
+	if offer.PayPeriod == "" {
+		offer.PayPeriod = PayPeriodYear
+	}
+	return offer
+}
+
+// ComparedOffer is one offer with its yearly figures converted to the
+// comparison currency. When Converted is false the offer's currency has no
+// exchange rate and the annual figures are left at zero.
+type ComparedOffer struct {
+	JobID        int32    `json:"job_id"`
+	Title        string   `json:"title"`
+	CompanyName  string   `json:"company_name"`
+	Status       string   `json:"status"`
+	Offer        JobOffer `json:"offer"`
+	Converted    bool     `json:"converted"`
+	AnnualBase   float64  `json:"annual_base"`
+	AnnualBonus  float64  `json:"annual_bonus"`
+	AnnualEquity float64  `json:"annual_equity"`
+	TotalAnnual  float64  `json:"total_annual"`
+	// DaysToDeadline is negative once the deadline has passed.
+	DaysToDeadline *int `json:"days_to_deadline,omitempty"`
+}
+
+type OfferComparison struct {
+	Currency string          `json:"currency"`
+	Offers   []ComparedOffer `json:"offers"`
+}
+
+// OfferDeadline is an open offer whose response deadline is close.
+type OfferDeadline struct {
+	JobID       int32
+	UserID      int32
+	Title       string
+	CompanyName string
+	Deadline    time.Time
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// PipelineStage is a user-defined Kanban column. Every stage maps onto one of
+// the base job statuses so filters, badges and analytics keep working.
+type PipelineStage struct {
+	ID         int32     `json:"id"`
+	UserID     int32     `json:"user_id"`
+	Name       string    `json:"name"`
+	BaseStatus string    `json:"base_status"`
+	Position   int32     `json:"position"`
+	Color      string    `json:"color,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type PipelineStageRequest struct {
+	Name       string `json:"name" validate:"required,min=1,max=60"`
+	BaseStatus string `json:"base_status" validate:"required,oneof=saved applied interview offer rejected"`
+	Color      string `json:"color,omitempty" validate:"omitempty,max=20"`
+}
+
+type ReorderStagesRequest struct {
+	StageIDs []int32 `json:"stage_ids" validate:"required,min=1"`
+}
+
+// MoveJobRequest drops a job into a stage at a zero-based position.
+type MoveJobRequest struct {
+	StageID  int32 `json:"stage_id" validate:"required"`
+	Position int32 `json:"position" validate:"min=0"`
+}
+
+type BoardColumn struct {
+	Stage PipelineStage `json:"stage"`
+	Jobs  []Job         `json:"jobs"`
+}
+
+type JobBoard struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+// DefaultPipelineStages is the pipeline every user starts with: one stage per base status.
+var DefaultPipelineStages = []PipelineStage{
+	{Name: "Saved", BaseStatus: JobStatusSaved, Position: 0},
+	{Name: "Applied", BaseStatus: JobStatusApplied, Position: 1},
+	{Name: "Interview", BaseStatus: JobStatusInterview, Position: 2},
+	{Name: "Offer", BaseStatus: JobStatusOffer, Position: 3},
+	{Name: "Rejected", BaseStatus: JobStatusRejected, Position: 4},
+}
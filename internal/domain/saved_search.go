@@ -0,0 +1,96 @@
+package domain
+
+import "time"
+
+// How often the scheduler re-runs a saved search.
+const (
+	SearchFrequencyDaily  = "daily"
+	SearchFrequencyWeekly = "weekly"
+)
+
+// How the user hears about new matches. Digest searches are rolled into one
+// notification a day; searches with alerts off are only run on demand.
+const (
+	SearchAlertInstant = "instant"
+	SearchAlertDigest  = "digest"
+	SearchAlertOff     = "off"
+)
+
+type SavedSearch struct {
+	ID                int32      `json:"id"`
+	UserID            int32      `json:"user_id"`
+	Name              string     `json:"name"`
+	Query             string     `json:"query"`
+	Location          string     `json:"location"`
+	RemoteOnly        bool       `json:"remote_only"`
+	MinSalary         *int32     `json:"min_salary,omitempty"`
+	ExcludedCompanies []string   `json:"excluded_companies"`
+	Frequency         string     `json:"frequency"`
+	Alert             string     `json:"alert"`
+	LastRunAt         *time.Time `json:"last_run_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type SavedSearchRequest struct {
+	Name              string   `json:"name" validate:"required,max=100"`
+	Query             string   `json:"query" validate:"required,max=255"`
+	Location          string   `json:"location" validate:"max=255"`
+	RemoteOnly        bool     `json:"remote_only"`
+	MinSalary         *int32   `json:"min_salary" validate:"omitempty,min=0"`
+	ExcludedCompanies []string `json:"excluded_companies" validate:"max=50,dive,required,max=150"`
+	Frequency         string   `json:"frequency" validate:"required,oneof=daily weekly"`
+	Alert             string   `json:"alert" validate:"required,oneof=instant digest off"`
+}
+
+func (r *SavedSearchRequest) ToDomain(userID int32) SavedSearch {
+	excluded := r.ExcludedCompanies
+	if excluded == nil {
+		excluded = []string{}
+	}
+	return SavedSearch{
+		UserID:            userID,
+		Name:              r.Name,
+		Query:             r.Query,
+		Location:          r.Location,
+		RemoteOnly:        r.RemoteOnly,
+		MinSalary:         r.MinSalary,
+		ExcludedCompanies: excluded,
+		Frequency:         r.Frequency,
+		Alert:             r.Alert,
+	}
+}
+
+// SavedSearchMatch is a posting a saved search has returned.
+type SavedSearchMatch struct {
+	ID            int32      `json:"id"`
+	SavedSearchID int32      `json:"saved_search_id"`
+	ExternalID    string     `json:"external_id"`
+	Title         string     `json:"title"`
+	CompanyName   string     `json:"company_name"`
+	Location      string     `json:"location"`
+	Link          string     `json:"link"`
+	Platform      string     `json:"platform"`
+	Salary        string     `json:"salary,omitempty"`
+	PostedAt      string     `json:"posted_at"`
+	FirstSeenAt   time.Time  `json:"first_seen_at"`
+	AlertedAt     *time.Time `json:"alerted_at,omitempty"`
+}
+
+// SavedSearchRun is the outcome of running a search: what it returned this
+// time and which of those it had not seen before.
+type SavedSearchRun struct {
+	Search     SavedSearch        `json:"search"`
+	NewMatches []SavedSearchMatch `json:"new_matches"`
+	TotalFound int                `json:"total_found"`
+}
+
+// SavedSearchDigest counts the matches of one digest search that have not
+// been announced yet, up to and including MaxMatchID.
+type SavedSearchDigest struct {
+	UserID        int32
+	SavedSearchID int32
+	SearchName    string
+	NewMatches    int32
+	MaxMatchID    int32
+}
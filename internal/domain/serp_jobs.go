@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // SerpJob represents a job fetched from SerpApi
 type SerpJob struct {
@@ -13,7 +16,24 @@ type SerpJob struct {
 	Platform    string    `json:"platform"`
 	PostedAt    string    `json:"posted_at"`
 	Salary      string    `json:"salary,omitempty"`
+	Remote      bool      `json:"remote,omitempty"`
 	FetchedAt   time.Time `json:"fetched_at"`
+
+	// Read from Salary and PostedAt before the job is cached.
+	SalaryRange     *SalaryRange `json:"salary_range,omitempty"`
+	PostedTime      *time.Time   `json:"posted_time,omitempty"`
+	SalarySortValue *float64     `json:"-"`
+}
+
+// SalaryRange is a salary read from a posting's text. The annual figures
+// assume full-time hours and are zero when the pay period cannot be told.
+type SalaryRange struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Currency  string  `json:"currency,omitempty"`
+	Period    string  `json:"period,omitempty"`
+	AnnualMin float64 `json:"annual_min,omitempty"`
+	AnnualMax float64 `json:"annual_max,omitempty"`
 }
 
 // SerpJobCache is a fetched job stored in the DB
@@ -29,20 +49,191 @@ type SerpJobCache struct {
 	Platform       string    `json:"platform"`
 	PostedAt       string    `json:"posted_at"`
 	Salary         string    `json:"salary"`
+	Remote         bool      `json:"remote"`
 	SavedToTracker bool      `json:"saved_to_tracker"`
 	TrackerJobID   *int32    `json:"tracker_job_id,omitempty"`
 	FetchedAt      time.Time `json:"fetched_at"`
+
+	SalaryRange *SalaryRange `json:"salary_range,omitempty"`
+	// PostedTime is when the job was posted, counted back from FetchedAt
+	// for text such as "3 days ago".
+	PostedTime *time.Time `json:"posted_time,omitempty"`
+
+	// Position orders the results of a search; cursors point into it.
+	Position int32 `json:"-"`
+	// SortValue is what the requested sort ordered the job by.
+	SortValue *float64 `json:"-"`
 }
 
-// JobSearchResult is the response returned to the client
+// JobSearchResult is the response returned to the client. NextCursor is
+// empty on the last page.
 type JobSearchResult struct {
 	Jobs       []SerpJobCache `json:"jobs"`
 	TotalCount int            `json:"total_count"`
 	FromCache  bool           `json:"from_cache"`
 	FetchedAt  time.Time      `json:"fetched_at"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// Date-posted windows for recommendations.
+const (
+	DatePostedToday     = "today"
+	DatePostedThreeDays = "3days"
+	DatePostedWeek      = "week"
+	DatePostedMonth     = "month"
+)
+
+// Recommendation sort orders. Relevance is the order the job boards return;
+// newest and pay order the jobs fetched for the search so far.
+const (
+	RecommendationSortRelevance = "relevance"
+	RecommendationSortNewest    = "newest"
+	RecommendationSortPay       = "pay"
+)
+
+// Employment types for recommendations.
+const (
+	EmploymentFullTime   = "full_time"
+	EmploymentPartTime   = "part_time"
+	EmploymentContract   = "contract"
+	EmploymentInternship = "internship"
+)
+
+// RecommendationQuery holds the query parameters of GET /jobs/recommended.
+// Places, country, language, date posted, employment type and remote only
+// change what is fetched from the job boards; has salary and platform filter
+// the cached results. Location and Locations replace the places in the
+// user's search preferences.
+type RecommendationQuery struct {
+	Location       string   `query:"location" validate:"max=255"`
+	Locations      []string `query:"locations" validate:"max=5,dive,min=1,max=100"`
+	Country        string   `query:"country" validate:"omitempty,len=2,alpha"`
+	Language       string   `query:"language" validate:"omitempty,min=2,max=8"`
+	DatePosted     string   `query:"date_posted" validate:"omitempty,oneof=today 3days week month"`
+	EmploymentType string   `query:"employment_type" validate:"omitempty,oneof=full_time part_time contract internship"`
+	RemoteOnly     bool     `query:"remote_only"`
+	HasSalary      bool     `query:"has_salary"`
+	Platform       string   `query:"platform" validate:"max=100"`
+	Sort           string   `query:"sort" validate:"omitempty,oneof=relevance newest pay"`
+	Cursor         string   `query:"cursor"`
+	Limit          int32    `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// SerpSearchState remembers the user's current recommendation search: which
+// one it is, the filters it was made with, when its first page was fetched,
+// where upstream paging left off and when the user last looked at it.
+type SerpSearchState struct {
+	UserID        int32
+	SearchKey     string
+	Params        SerpSearchParams
+	NextPageToken string
+	FetchedAt     time.Time
+	ViewedAt      time.Time
+}
+
+// SerpSearchParams are the filters of a recommendation search that are sent
+// upstream; with the user's profile they make up the search.
+type SerpSearchParams struct {
+	Location       string
+	Locations      []string
+	Country        string
+	Language       string
+	DatePosted     string
+	EmploymentType string
+	RemoteOnly     bool
+}
+
+// SearchPreferences say where recommended jobs are searched for. Country is
+// a lower-case ISO 3166 code such as "ng" and Language an ISO 639 code such
+// as "en". Locations are cities or regions, searched together; a user open
+// to relocation is also shown jobs anywhere in Country, and one open to
+// remote work remote roles open to it.
+type SearchPreferences struct {
+	Country          string     `json:"country"`
+	Language         string     `json:"language"`
+	Locations        []string   `json:"locations"`
+	OpenToRemote     bool       `json:"open_to_remote"`
+	OpenToRelocation bool       `json:"open_to_relocation"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateSearchPreferencesRequest is the body of PUT
+// /jobs/recommended/preferences.
+type UpdateSearchPreferencesRequest struct {
+	Country          string   `json:"country" validate:"omitempty,len=2,alpha"`
+	Language         string   `json:"language" validate:"omitempty,min=2,max=8"`
+	Locations        []string `json:"locations" validate:"max=5,dive,min=1,max=100"`
+	OpenToRemote     bool     `json:"open_to_remote"`
+	OpenToRelocation bool     `json:"open_to_relocation"`
+}
+
+// SerpCacheFilter selects the cached results of one search.
+type SerpCacheFilter struct {
+	SearchKey string
+	HasSalary bool
+	Platform  string
+	Sort      string
+}
+
+// SerpCacheCursor is where a page of search results starts: after the job at
+// Position, whose sort value was Value.
+type SerpCacheCursor struct {
+	Position int32
+	Value    *float64
 }
 
 // SaveJobRequest is used to save a fetched job to the tracker
 type SaveJobRequest struct {
 	CacheID int32 `json:"cache_id" validate:"required"`
 }
+
+// SearchQuotaUsage is how much of a paid job source's monthly plan has been
+// used. Fetching stops once Used reaches Budget, a share of Plan.
+type SearchQuotaUsage struct {
+	Source      string    `json:"source"`
+	Month       string    `json:"month"`
+	Used        int64     `json:"used"`
+	Plan        int64     `json:"plan"`
+	Budget      int64     `json:"budget"`
+	Remaining   int64     `json:"remaining"`
+	PercentUsed float64   `json:"percent_used"`
+	Exhausted   bool      `json:"exhausted"`
+	ResetsAt    time.Time `json:"resets_at"`
+}
+
+// SearchStats are the job search cache and recommendation prefetch counters
+// since the process started. Cache counts, per source, "<source>.hits",
+// ".misses" and ".joined"; Prefetch has running totals and a summary of the
+// last run.
+type SearchStats struct {
+	CacheHitRatio float64         `json:"cache_hit_ratio"`
+	Cache         json.RawMessage `json:"cache" swaggertype:"object"`
+	Prefetch      json.RawMessage `json:"prefetch" swaggertype:"object"`
+}
+
+// Reasons a recommended job can be dismissed for. DismissReasonCompany hides
+// every posting from the company; the others hide only the one posting.
+const (
+	DismissReasonWrongSeniority = "wrong_seniority"
+	DismissReasonWrongLocation  = "wrong_location"
+	DismissReasonCompany        = "company"
+	DismissReasonNotRelevant    = "not_relevant"
+)
+
+// DismissJobRequest is the body of POST /jobs/recommended/:id/dismiss.
+type DismissJobRequest struct {
+	Reason string `json:"reason" validate:"omitempty,oneof=wrong_seniority wrong_location company not_relevant"`
+}
+
+// JobDismissal is a recommended job the user is not interested in.
+type JobDismissal struct {
+	ID          int32     `json:"id"`
+	UserID      int32     `json:"user_id"`
+	ExternalID  string    `json:"external_id"`
+	Title       string    `json:"title"`
+	CompanyName string    `json:"company_name,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
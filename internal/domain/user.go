@@ -24,7 +24,7 @@ type UserProfile struct {
 	FullName          string    `json:"full_name"`
 	CurrentJob        string    `json:"current_job"`
 	ExperienceLevel   string    `json:"experience_level"`
-	Goals             []string  `json:"goals"`
+	Goals             []string  `json:"goals"` // free-text aspirations from onboarding; measurable targets are Goal records
 	JobSearchLocation string    `json:"job_search_location,omitempty"`
 	HasCV             bool      `json:"has_cv"`
 	UpdatedAt         time.Time `json:"updated_at"`
@@ -37,6 +37,34 @@ type UserProfileRequest struct {
 	Goals           []string `json:"goals,omitempty" validate:"omitempty"`
 }
 
+// TitleSuggestionQuery is what the user has typed into the job title field
+// so far.
+type TitleSuggestionQuery struct {
+	Q     string `query:"q" validate:"max=100"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=20"`
+}
+
+// TitleSuggestions offers standard job titles for the typed text, the
+// seniority it started with, if any, and every experience level.
+type TitleSuggestions struct {
+	Titles []TitleSuggestion       `json:"titles"`
+	Level  string                  `json:"level,omitempty"`
+	Levels []ExperienceLevelOption `json:"levels"`
+}
+
+// TitleSuggestion is a standard title and the titles searched alongside it.
+type TitleSuggestion struct {
+	Title   string   `json:"title"`
+	Related []string `json:"related"`
+}
+
+// ExperienceLevelOption is a standard experience level; Value is what is
+// stored as a profile's experience level.
+type ExperienceLevelOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
 // RegisterRequest represents the request to register a new user
 type RegisterRequest struct {
 	Email    string `json:"email" validate:"required,email"`
@@ -78,6 +106,12 @@ type UpdateUserRequest struct {
 	PhoneNumber *string `json:"phone_number,omitempty" validate:"omitempty,min=10,max=20"`
 }
 
+// UserTimezone is the IANA time zone, such as "Africa/Nairobi", that the
+// user's streak days, daily progress and reminders follow.
+type UserTimezone struct {
+	Timezone string `json:"timezone" validate:"required,max=64,timezone"`
+}
+
 type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
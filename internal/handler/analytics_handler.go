@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AnalyticsHandler struct {
+	analyticsService service.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsService: analyticsService}
+}
+
+// GetApplicationAnalytics godoc
+// @Summary      Job search funnel analytics
+// @Description  Weekly applications sent, applied → interview → offer conversion, response rate and median days to first response, broken down by platform and by source (manual or recommended). chart_points holds one bucket per week, starting on Monday.
+// @Tags         analytics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        weeks query int false "Number of weeks to report on, current week included (1-52, default 12)"
+// @Success      200 {object} response.Response{data=domain.ApplicationAnalytics}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /analytics/applications [get]
+func (h *AnalyticsHandler) GetApplicationAnalytics(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	weeks := 0
+	if raw := c.QueryParam("weeks"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > 52 {
+			return response.ValidationError(c, "weeks must be between 1 and 52")
+		}
+		weeks = v
+	}
+
+	analytics, err := h.analyticsService.GetApplicationAnalytics(c.Request().Context(), userID, weeks)
+	if err != nil {
+		c.Logger().Errorf("failed to build application analytics: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "application analytics retrieved", analytics)
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AttachmentHandler struct {
+	attachmentService service.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+// UploadAttachment godoc
+// @Summary      Attach a file to a job
+// @Description  Upload an offer letter, take-home assignment, the CV that was sent or any other file. Allowed types: PDF, DOC, DOCX, TXT, MD, PNG and JPEG. Files count towards the user's storage quota.
+// @Tags         attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path     int    true  "Job ID"
+// @Param        file formData file   true  "File to attach"
+// @Param        kind formData string false "offer_letter, assignment, cv or other (default)"
+// @Success      201 {object} response.Response{data=domain.JobAttachment}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Failure      413 {object} response.Response
+// @Failure      415 {object} response.Response
+// @Router       /jobs/{id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	kind := c.FormValue("kind")
+	if kind != "" && !isAttachmentKind(kind) {
+		return response.ValidationError(c, "kind must be one of offer_letter, assignment, cv, other")
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return response.ValidationError(c, "file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Logger().Errorf("failed to open file: %v", err)
+		return response.Error(c, domain.ErrInvalidInput)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.Logger().Errorf("failed to read file: %v", err)
+		return response.Error(c, domain.ErrInvalidInput)
+	}
+
+	attachment, err := h.attachmentService.Upload(c.Request().Context(), userID, jobID, fileHeader.Filename, kind, data)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "attachment uploaded", attachment)
+}
+
+// ListAttachments godoc
+// @Summary      List a job's attachments
+// @Tags         attachments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Success      200 {object} response.Response{data=[]domain.JobAttachment}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	attachments, err := h.attachmentService.List(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "attachments retrieved", attachments)
+}
+
+// DownloadAttachment godoc
+// @Summary      Download an attachment
+// @Tags         attachments
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id            path int true "Job ID"
+// @Param        attachment_id path int true "Attachment ID"
+// @Success      200 {file} file "Attachment contents"
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/attachments/{attachment_id} [get]
+func (h *AttachmentHandler) DownloadAttachment(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+	attachmentID, err := parseIDParam(c, "attachment_id")
+	if err != nil {
+		return response.ValidationError(c, "invalid attachment ID")
+	}
+
+	attachment, err := h.attachmentService.Download(c.Request().Context(), userID, jobID, attachmentID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+	return c.Blob(http.StatusOK, attachment.ContentType, attachment.Data)
+}
+
+// DeleteAttachment godoc
+// @Summary      Delete an attachment
+// @Tags         attachments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id            path int true "Job ID"
+// @Param        attachment_id path int true "Attachment ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+	attachmentID, err := parseIDParam(c, "attachment_id")
+	if err != nil {
+		return response.ValidationError(c, "invalid attachment ID")
+	}
+
+	if err := h.attachmentService.Delete(c.Request().Context(), userID, jobID, attachmentID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "attachment deleted", nil)
+}
+
+// GetStorageUsage godoc
+// @Summary      Attachment storage usage
+// @Description  Bytes used by the user's attachments, the quota and the per-file size limit. Attachments on trashed jobs count until the job is purged.
+// @Tags         attachments
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=domain.StorageUsage}
+// @Failure      401 {object} response.Response
+// @Router       /jobs/attachments/usage [get]
+func (h *AttachmentHandler) GetStorageUsage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	usage, err := h.attachmentService.GetUsage(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "storage usage retrieved", usage)
+}
+
+// GetSnapshot godoc
+// @Summary      Get a job's description snapshot
+// @Description  The posting as it was when the job was saved from recommendations, kept after the listing is taken down
+// @Tags         attachments
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Success      200 {object} response.Response{data=domain.JobDescriptionSnapshot}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/snapshot [get]
+func (h *AttachmentHandler) GetSnapshot(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	snapshot, err := h.attachmentService.GetSnapshot(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "description snapshot retrieved", snapshot)
+}
+
+func isAttachmentKind(kind string) bool {
+	for _, k := range domain.AttachmentKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AutomationHandler struct {
+	automationService service.AutomationService
+	validator         echo.Validator
+}
+
+func NewAutomationHandler(automationService service.AutomationService, validator echo.Validator) *AutomationHandler {
+	return &AutomationHandler{
+		automationService: automationService,
+		validator:         validator,
+	}
+}
+
+// ListRules godoc
+// @Summary      List automation rules
+// @Tags         automation
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.AutomationRule}
+// @Failure      401 {object} response.Response
+// @Router       /automation/rules [get]
+func (h *AutomationHandler) ListRules(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	rules, err := h.automationService.ListRules(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "rules retrieved", rules)
+}
+
+// ListTemplates godoc
+// @Summary      Suggested automation rules
+// @Description  Ready-made rules to start from, such as "ghosted after 30 days in applied". They are not active until created and enabled.
+// @Tags         automation
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.AutomationRule}
+// @Failure      401 {object} response.Response
+// @Router       /automation/rules/templates [get]
+func (h *AutomationHandler) ListTemplates(c echo.Context) error {
+	if _, ok := c.Get("user_id").(int32); !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	return response.Success(c, http.StatusOK, "templates retrieved", h.automationService.ListTemplates())
+}
+
+// CreateRule godoc
+// @Summary      Create an automation rule
+// @Description  A rule fires for jobs that have been in a status for more than after_days days and can mark them ghosted, send a one-off nudge and archive them. With both nudge and archive, archiving waits grace_days after the nudge. Rules are created disabled unless enabled is true.
+// @Tags         automation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.AutomationRuleRequest true "Rule details"
+// @Success      201 {object} response.Response{data=domain.AutomationRule}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /automation/rules [post]
+func (h *AutomationHandler) CreateRule(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.AutomationRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	rule, err := h.automationService.CreateRule(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "rule created", rule)
+}
+
+// UpdateRule godoc
+// @Summary      Update an automation rule
+// @Description  Replaces the rule's settings. Omit enabled to leave it as it is.
+// @Tags         automation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                          true "Rule ID"
+// @Param        request body domain.AutomationRuleRequest true "Rule details"
+// @Success      200 {object} response.Response{data=domain.AutomationRule}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /automation/rules/{id} [put]
+func (h *AutomationHandler) UpdateRule(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	ruleID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid rule ID")
+	}
+
+	var req domain.AutomationRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	rule, err := h.automationService.UpdateRule(c.Request().Context(), userID, ruleID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "rule updated", rule)
+}
+
+// DeleteRule godoc
+// @Summary      Delete an automation rule
+// @Description  Events the rule recorded stay on the job timelines.
+// @Tags         automation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Rule ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /automation/rules/{id} [delete]
+func (h *AutomationHandler) DeleteRule(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	ruleID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid rule ID")
+	}
+
+	if err := h.automationService.DeleteRule(c.Request().Context(), userID, ruleID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "rule deleted", nil)
+}
+
+// PreviewRule godoc
+// @Summary      Preview an automation rule
+// @Description  Lists the jobs the rule would act on if it ran now, and what it would do to each. Works for disabled rules; nothing is changed.
+// @Tags         automation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Rule ID"
+// @Success      200 {object} response.Response{data=domain.RulePreview}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /automation/rules/{id}/preview [get]
+func (h *AutomationHandler) PreviewRule(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	ruleID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid rule ID")
+	}
+
+	preview, err := h.automationService.PreviewRule(c.Request().Context(), userID, ruleID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "rule preview retrieved", preview)
+}
+
+// PreviewDraftRule godoc
+// @Summary      Preview an unsaved automation rule
+// @Description  Dry run of a rule before creating it.
+// @Tags         automation
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.AutomationRuleRequest true "Rule details"
+// @Success      200 {object} response.Response{data=domain.RulePreview}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /automation/rules/preview [post]
+func (h *AutomationHandler) PreviewDraftRule(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.AutomationRuleRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	preview, err := h.automationService.PreviewDraft(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "rule preview retrieved", preview)
+}
+
+// GetJobTimeline godoc
+// @Summary      Job timeline
+// @Description  The job's status changes and the changes automation rules made to it (ghosted, nudged, archived), oldest first. Automatic entries are flagged and say which rule made them.
+// @Tags         automation
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Success      200 {object} response.Response{data=[]domain.JobTimelineEvent}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/timeline [get]
+func (h *AutomationHandler) GetJobTimeline(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	timeline, err := h.automationService.GetTimeline(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "timeline retrieved", timeline)
+}
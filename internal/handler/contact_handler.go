@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ContactHandler struct {
+	contactService service.ContactService
+	validator      echo.Validator
+}
+
+func NewContactHandler(contactService service.ContactService, validator echo.Validator) *ContactHandler {
+	return &ContactHandler{
+		contactService: contactService,
+		validator:      validator,
+	}
+}
+
+// ListContacts godoc
+// @Summary      List contacts
+// @Description  Returns the user's contacts ordered by name, optionally filtered by linked job or a search on name, company and role
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        job_id query int    false "Only contacts linked to this job"
+// @Param        q      query string false "Search name, company or role"
+// @Success      200 {object} response.Response{data=[]domain.Contact}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /contacts [get]
+func (h *ContactHandler) ListContacts(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	filter := domain.ContactFilter{Query: c.QueryParam("q")}
+	if j := c.QueryParam("job_id"); j != "" {
+		v, err := strconv.ParseInt(j, 10, 32)
+		if err != nil {
+			return response.ValidationError(c, "invalid job_id")
+		}
+		jobID := int32(v)
+		filter.JobID = &jobID
+	}
+
+	contacts, err := h.contactService.List(c.Request().Context(), userID, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "contacts retrieved", contacts)
+}
+
+// GetContact godoc
+// @Summary      Get a contact
+// @Description  Returns a contact with its linked jobs and interaction log, newest first
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Contact ID"
+// @Success      200 {object} response.Response{data=domain.ContactDetail}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id} [get]
+func (h *ContactHandler) GetContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+
+	contact, err := h.contactService.Get(c.Request().Context(), userID, contactID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "contact retrieved", contact)
+}
+
+// CreateContact godoc
+// @Summary      Create a contact
+// @Description  Adds a recruiter or other contact. job_ids links the contact to tracked jobs; next_touch_at (YYYY-MM-DD) schedules a follow-up reminder.
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.ContactRequest true "Contact details"
+// @Success      201 {object} response.Response{data=domain.Contact}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /contacts [post]
+func (h *ContactHandler) CreateContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.ContactRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	contact, err := h.contactService.Create(c.Request().Context(), userID, &req)
+	if err != nil {
+		c.Logger().Errorf("failed to create contact: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "contact created", contact)
+}
+
+// UpdateContact godoc
+// @Summary      Update a contact
+// @Description  Replaces the contact's details. Omit job_ids to keep the current job links. Setting a new next_touch_at re-arms the reminder.
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Contact ID"
+// @Param        request body domain.ContactRequest true "Contact details"
+// @Success      200 {object} response.Response{data=domain.Contact}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id} [put]
+func (h *ContactHandler) UpdateContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+
+	var req domain.ContactRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	contact, err := h.contactService.Update(c.Request().Context(), userID, contactID, &req)
+	if err != nil {
+		c.Logger().Errorf("failed to update contact: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "contact updated", contact)
+}
+
+// DeleteContact godoc
+// @Summary      Delete a contact
+// @Description  Deletes the contact, its job links and its interaction log
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Contact ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id} [delete]
+func (h *ContactHandler) DeleteContact(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+
+	if err := h.contactService.Delete(c.Request().Context(), userID, contactID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "contact deleted", nil)
+}
+
+// LinkJob godoc
+// @Summary      Link a contact to a job
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path int true "Contact ID"
+// @Param        job_id path int true "Job ID"
+// @Success      200 {object} response.Response{data=domain.Contact}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id}/jobs/{job_id} [post]
+func (h *ContactHandler) LinkJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+	jobID, err := parseIDParam(c, "job_id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	contact, err := h.contactService.LinkJob(c.Request().Context(), userID, contactID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job linked to contact", contact)
+}
+
+// UnlinkJob godoc
+// @Summary      Unlink a contact from a job
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path int true "Contact ID"
+// @Param        job_id path int true "Job ID"
+// @Success      200 {object} response.Response{data=domain.Contact}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id}/jobs/{job_id} [delete]
+func (h *ContactHandler) UnlinkJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+	jobID, err := parseIDParam(c, "job_id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	contact, err := h.contactService.UnlinkJob(c.Request().Context(), userID, contactID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job unlinked from contact", contact)
+}
+
+// AddInteraction godoc
+// @Summary      Log an interaction
+// @Description  Records an email, call, coffee chat, meeting or message with the contact. occurred_at defaults to now.
+// @Tags         contacts
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Contact ID"
+// @Param        request body domain.ContactInteractionRequest true "Interaction details"
+// @Success      201 {object} response.Response{data=domain.ContactInteraction}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id}/interactions [post]
+func (h *ContactHandler) AddInteraction(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+
+	var req domain.ContactInteractionRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	interaction, err := h.contactService.AddInteraction(c.Request().Context(), userID, contactID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "interaction logged", interaction)
+}
+
+// DeleteInteraction godoc
+// @Summary      Delete an interaction
+// @Tags         contacts
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id             path int true "Contact ID"
+// @Param        interaction_id path int true "Interaction ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /contacts/{id}/interactions/{interaction_id} [delete]
+func (h *ContactHandler) DeleteInteraction(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	contactID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid contact id")
+	}
+	interactionID, err := parseIDParam(c, "interaction_id")
+	if err != nil {
+		return response.ValidationError(c, "invalid interaction id")
+	}
+
+	if err := h.contactService.DeleteInteraction(c.Request().Context(), userID, contactID, interactionID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "interaction deleted", nil)
+}
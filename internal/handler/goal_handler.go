@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type GoalHandler struct {
+	goalService service.GoalService
+	validator   echo.Validator
+}
+
+func NewGoalHandler(goalService service.GoalService, validator echo.Validator) *GoalHandler {
+	return &GoalHandler{
+		goalService: goalService,
+		validator:   validator,
+	}
+}
+
+// ListGoals godoc
+// @Summary      List goals with progress
+// @Description  Returns the user's goals with progress in the current week or month. Progress is computed from tracked jobs, focus sessions and contact interactions; manual goals count logged check-ins.
+// @Tags         goals
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.GoalProgress}
+// @Failure      401 {object} response.Response
+// @Router       /goals [get]
+func (h *GoalHandler) ListGoals(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	goals, err := h.goalService.ListGoals(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "goals retrieved", goals)
+}
+
+// CreateGoal godoc
+// @Summary      Create a goal
+// @Description  For example {"title": "Apply to 10 jobs", "metric": "applications", "target": 10, "period": "weekly"}. Metrics: applications, interviews, focus_minutes, focus_sessions, networking and manual.
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.GoalRequest true "Goal details"
+// @Success      201 {object} response.Response{data=domain.GoalProgress}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /goals [post]
+func (h *GoalHandler) CreateGoal(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.GoalRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	goal, err := h.goalService.CreateGoal(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "goal created", goal)
+}
+
+// UpdateGoal godoc
+// @Summary      Update a goal
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                true "Goal ID"
+// @Param        request body domain.GoalRequest true "Goal details"
+// @Success      200 {object} response.Response{data=domain.GoalProgress}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /goals/{id} [put]
+func (h *GoalHandler) UpdateGoal(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	goalID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid goal ID")
+	}
+
+	var req domain.GoalRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	goal, err := h.goalService.UpdateGoal(c.Request().Context(), userID, goalID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "goal updated", goal)
+}
+
+// DeleteGoal godoc
+// @Summary      Delete a goal
+// @Tags         goals
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Goal ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /goals/{id} [delete]
+func (h *GoalHandler) DeleteGoal(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	goalID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid goal ID")
+	}
+
+	if err := h.goalService.DeleteGoal(c.Request().Context(), userID, goalID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "goal deleted", nil)
+}
+
+// CheckIn godoc
+// @Summary      Log progress on a manual goal
+// @Description  Records activity the app cannot see, such as a mock interview. Amount defaults to 1.
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                       true "Goal ID"
+// @Param        request body domain.GoalCheckInRequest true "Check-in"
+// @Success      201 {object} response.Response{data=domain.GoalProgress}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /goals/{id}/check-ins [post]
+func (h *GoalHandler) CheckIn(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	goalID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid goal ID")
+	}
+
+	var req domain.GoalCheckInRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	goal, err := h.goalService.CheckIn(c.Request().Context(), userID, goalID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "progress logged", goal)
+}
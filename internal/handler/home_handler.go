@@ -4,6 +4,7 @@ import (
 	"aiki/internal/domain"
 	"aiki/internal/pkg/response"
 	"aiki/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -28,7 +29,7 @@ func NewHomeHandler(homeService service.HomeService, validator echo.Validator) *
 
 // GetHomeScreen godoc
 // @Summary Get home screen data
-// @Description Returns aggregated home screen data: streak, active session, weekly progress, recent badges
+// @Description Returns aggregated home screen data: streak, active session, weekly progress, recent badges, goal progress
 // @Tags home
 // @Produce json
 // @Security BearerAuth
@@ -55,7 +56,7 @@ func (h *HomeHandler) GetHomeScreen(c echo.Context) error {
 
 // StartSession godoc
 // @Summary Start a focus session
-// @Description Starts a new lock-in focus session for the authenticated user
+// @Description Starts a new lock-in focus session for the authenticated user: a single block of duration_seconds, or a pomodoro of work and break intervals given as a plan or a saved preset_id
 // @Tags sessions
 // @Accept json
 // @Produce json
@@ -90,7 +91,7 @@ func (h *HomeHandler) StartSession(c echo.Context) error {
 
 // GetActiveSession godoc
 // @Summary Get active session
-// @Description Returns the current active or paused session, if any
+// @Description Returns the current active or paused session, if any. A pomodoro session includes its current phase, cycle and phase deadline
 // @Tags sessions
 // @Produce json
 // @Security BearerAuth
@@ -113,7 +114,7 @@ func (h *HomeHandler) GetActiveSession(c echo.Context) error {
 
 // PauseSession godoc
 // @Summary Pause a focus session
-// @Description Pauses an active focus session
+// @Description Pauses an active focus session. The server times the session; elapsed_seconds is only a hint that can lower its count
 // @Tags sessions
 // @Accept json
 // @Produce json
@@ -180,13 +181,13 @@ func (h *HomeHandler) ResumeSession(c echo.Context) error {
 
 // EndSession godoc
 // @Summary End a focus session
-// @Description Ends a session as completed or abandoned
+// @Description Ends a session as completed or abandoned, with an optional reflection and focus score (1-5). The server times the session; elapsed_seconds is only a hint that can lower its count, and a session whose count strays too far is flagged and earns no badges
 // @Tags sessions
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Session ID"
-// @Param request body domain.UpdateSessionRequest true "Elapsed seconds and status"
+// @Param request body domain.UpdateSessionRequest true "Elapsed seconds, status, reflection and focus score"
 // @Success 200 {object} response.Response{data=domain.FocusSession}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
@@ -211,8 +212,7 @@ func (h *HomeHandler) EndSession(c echo.Context) error {
 		return response.ValidationError(c, err.Error())
 	}
 
-	completed := req.Status == domain.SessionStatusCompleted
-	session, err := h.homeService.EndSession(c.Request().Context(), userID, sessionID, req.ElapsedSeconds, completed)
+	session, err := h.homeService.EndSession(c.Request().Context(), userID, sessionID, &req)
 	if err != nil {
 		return response.Error(c, err)
 	}
@@ -225,9 +225,15 @@ func (h *HomeHandler) EndSession(c echo.Context) error {
 // @Tags sessions
 // @Produce json
 // @Security BearerAuth
-// @Param limit  query int false "Limit (default 20)"
-// @Param offset query int false "Offset (default 0)"
+// @Param limit           query int    false "Limit (default 20)"
+// @Param offset          query int    false "Offset (default 0)"
+// @Param job_id          query int    false "Only sessions linked to this job"
+// @Param goal_id         query int    false "Only sessions linked to this goal"
+// @Param status          query string false "active | paused | completed | abandoned"
+// @Param q               query string false "Search the intention and reflection"
+// @Param min_focus_score query int    false "Only sessions rated at least this (1-5)"
 // @Success 200 {object} response.Response{data=[]domain.FocusSession}
+// @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Router /sessions [get]
 func (h *HomeHandler) GetSessionHistory(c echo.Context) error {
@@ -249,7 +255,12 @@ func (h *HomeHandler) GetSessionHistory(c echo.Context) error {
 		}
 	}
 
-	sessions, err := h.homeService.GetSessionHistory(c.Request().Context(), userID, limit, offset)
+	filter, err := parseSessionFilter(c)
+	if err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	sessions, err := h.homeService.GetSessionHistory(c.Request().Context(), userID, filter, limit, offset)
 	if err != nil {
 		return response.Error(c, err)
 	}
@@ -257,6 +268,200 @@ func (h *HomeHandler) GetSessionHistory(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "session history retrieved", sessions)
 }
 
+// parseSessionFilter reads the session history filters: ?job_id=, ?goal_id=,
+// ?status=, ?q= and ?min_focus_score=.
+func parseSessionFilter(c echo.Context) (domain.SessionFilter, error) {
+	filter := domain.SessionFilter{Query: c.QueryParam("q")}
+
+	if j := c.QueryParam("job_id"); j != "" {
+		v, err := strconv.ParseInt(j, 10, 32)
+		if err != nil {
+			return filter, errors.New("invalid job_id")
+		}
+		jobID := int32(v)
+		filter.JobID = &jobID
+	}
+
+	if g := c.QueryParam("goal_id"); g != "" {
+		v, err := strconv.ParseInt(g, 10, 32)
+		if err != nil {
+			return filter, errors.New("invalid goal_id")
+		}
+		goalID := int32(v)
+		filter.GoalID = &goalID
+	}
+
+	if status := domain.SessionStatus(c.QueryParam("status")); status != "" {
+		switch status {
+		case domain.SessionStatusActive, domain.SessionStatusPaused, domain.SessionStatusCompleted, domain.SessionStatusAbandoned:
+			filter.Status = status
+		default:
+			return filter, errors.New("invalid status")
+		}
+	}
+
+	if m := c.QueryParam("min_focus_score"); m != "" {
+		v, err := strconv.Atoi(m)
+		if err != nil || v < 1 || v > 5 {
+			return filter, errors.New("min_focus_score must be between 1 and 5")
+		}
+		score := int32(v)
+		filter.MinFocusScore = &score
+	}
+
+	return filter, nil
+}
+
+// GetJobFocusTime godoc
+// @Summary Get focus time per job
+// @Description Returns the focus time of completed sessions linked to each tracked job, most worked on first
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]domain.JobFocusTime}
+// @Failure 401 {object} response.Response
+// @Router /sessions/jobs [get]
+func (h *HomeHandler) GetJobFocusTime(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	totals, err := h.homeService.GetJobFocusTime(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job focus time retrieved", totals)
+}
+
+// ─────────────────────────────────────────
+// Pomodoro presets
+// ─────────────────────────────────────────
+
+// ListSessionPresets godoc
+// @Summary List pomodoro presets
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]domain.SessionPreset}
+// @Failure 401 {object} response.Response
+// @Router /sessions/presets [get]
+func (h *HomeHandler) ListSessionPresets(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	presets, err := h.homeService.ListSessionPresets(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "session presets retrieved", presets)
+}
+
+// CreateSessionPreset godoc
+// @Summary Save a pomodoro preset
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.SessionPresetRequest true "Preset"
+// @Success 201 {object} response.Response{data=domain.SessionPreset}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Router /sessions/presets [post]
+func (h *HomeHandler) CreateSessionPreset(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.SessionPresetRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	preset, err := h.homeService.CreateSessionPreset(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "session preset saved", preset)
+}
+
+// UpdateSessionPreset godoc
+// @Summary Update a pomodoro preset
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Preset ID"
+// @Param request body domain.SessionPresetRequest true "Preset"
+// @Success 200 {object} response.Response{data=domain.SessionPreset}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /sessions/presets/{id} [put]
+func (h *HomeHandler) UpdateSessionPreset(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	presetID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid preset id")
+	}
+
+	var req domain.SessionPresetRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	preset, err := h.homeService.UpdateSessionPreset(c.Request().Context(), userID, presetID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "session preset updated", preset)
+}
+
+// DeleteSessionPreset godoc
+// @Summary Delete a pomodoro preset
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Preset ID"
+// @Success 200 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /sessions/presets/{id} [delete]
+func (h *HomeHandler) DeleteSessionPreset(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	presetID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid preset id")
+	}
+
+	if err := h.homeService.DeleteSessionPreset(c.Request().Context(), userID, presetID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "session preset deleted", nil)
+}
+
 // ─────────────────────────────────────────
 // Streaks
 // ─────────────────────────────────────────
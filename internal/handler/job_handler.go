@@ -4,8 +4,10 @@ import (
 	"aiki/internal/domain"
 	"aiki/internal/pkg/response"
 	"aiki/internal/service"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -24,13 +26,13 @@ func NewJobHandler(jobService service.JobService, validator echo.Validator) *Job
 
 // CreateJob godoc
 // @Summary Create a new job
-// @Description Create a new job application for the authenticated user
+// @Description Create a new job application for the authenticated user. Existing jobs that look like the same role (same apply link, or same company, title and location) are returned in duplicates; the job is created regardless and can be merged with POST /jobs/{id}/merge.
 // @Tags jobs
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body domain.JobRequest true "Job details"
-// @Success 201 {object} response.Response{data=map[string]int32}
+// @Success 201 {object} response.Response{data=domain.CreatedJob}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Router /jobs [post]
@@ -51,24 +53,28 @@ func (h *JobHandler) CreateJob(c echo.Context) error {
 
 	job := req.ToDomain(userID)
 
-	jobID, err := h.jobService.Create(c.Request().Context(), &job)
+	jobID, duplicates, err := h.jobService.Create(c.Request().Context(), &job)
 	if err != nil {
 		c.Logger().Errorf("failed to create job: %v", err)
 		return response.Error(c, err)
 	}
 
-	return response.Success(c, http.StatusCreated, "job created successfully", map[string]int32{"id": jobID})
+	message := "job created successfully"
+	if len(duplicates) > 0 {
+		message = "job created; it looks like a job you already track"
+	}
+	return response.Success(c, http.StatusCreated, message, domain.CreatedJob{ID: jobID, Duplicates: duplicates})
 }
 
 // GetJob godoc
 // @Summary Get a job by ID
-// @Description Get a specific job application by ID
+// @Description Get a specific job application by ID, including the contacts linked to it
 // @Tags jobs
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Job ID"
-// @Success 200 {object} response.Response{data=domain.Job}
+// @Success 200 {object} response.Response{data=domain.JobDetail}
 // @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Failure 404 {object} response.Response
@@ -84,7 +90,7 @@ func (h *JobHandler) GetJob(c echo.Context) error {
 		return response.ValidationError(c, "invalid job ID")
 	}
 
-	job, err := h.jobService.GetByID(c.Request().Context(), int32(jobID))
+	job, err := h.jobService.GetDetail(c.Request().Context(), int32(jobID))
 	if err != nil {
 		return response.Error(c, err)
 	}
@@ -99,12 +105,18 @@ func (h *JobHandler) GetJob(c echo.Context) error {
 
 // GetAllJobs godoc
 // @Summary Get all jobs
-// @Description Get all job applications for the authenticated user
+// @Description Get all job applications for the authenticated user, optionally filtered by tag, priority, stage or status. Archived jobs are only listed with archived=true; trashed jobs are listed under /jobs/trash.
 // @Tags jobs
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param tag query []string false "Only jobs carrying all of these tags (repeat the parameter or comma-separate)" collectionFormat(multi)
+// @Param priority query int false "Priority (0 none, 1 low, 2 medium, 3 high)"
+// @Param stage_id query int false "Pipeline stage ID"
+// @Param status query string false "Base status"
+// @Param archived query bool false "List archived jobs instead of active ones"
 // @Success 200 {object} response.Response{data=[]domain.Job}
+// @Failure 400 {object} response.Response
 // @Failure 401 {object} response.Response
 // @Router /jobs [get]
 func (h *JobHandler) GetAllJobs(c echo.Context) error {
@@ -113,7 +125,17 @@ func (h *JobHandler) GetAllJobs(c echo.Context) error {
 		return response.Error(c, domain.ErrUnauthorized)
 	}
 
-	jobs, err := h.jobService.GetAllByUserID(c.Request().Context(), userID)
+	filter, err := parseJobFilter(c)
+	if err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+	if a := c.QueryParam("archived"); a != "" {
+		if filter.Archived, err = strconv.ParseBool(a); err != nil {
+			return response.ValidationError(c, "archived must be true or false")
+		}
+	}
+
+	jobs, err := h.jobService.GetAllByUserID(c.Request().Context(), userID, filter)
 	if err != nil {
 		c.Logger().Errorf("failed to get jobs: %v", err)
 		return response.Error(c, err)
@@ -122,6 +144,30 @@ func (h *JobHandler) GetAllJobs(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "jobs retrieved successfully", jobs)
 }
 
+// GetTags godoc
+// @Summary Get job tags
+// @Description List the tags the authenticated user has used, most used first, for autocomplete and filter chips
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]domain.TagCount}
+// @Failure 401 {object} response.Response
+// @Router /jobs/tags [get]
+func (h *JobHandler) GetTags(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	tags, err := h.jobService.GetTags(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Errorf("failed to get job tags: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "tags retrieved successfully", tags)
+}
+
 // UpdateJob godoc
 // @Summary Update a job
 // @Description Update an existing job application
@@ -167,6 +213,9 @@ func (h *JobHandler) UpdateJob(c echo.Context) error {
 	}
 
 	job := req.ToDomain(userID)
+	if req.Priority == nil {
+		job.Priority = existingJob.Priority
+	}
 
 	err = h.jobService.Update(c.Request().Context(), int32(jobID), &job)
 	if err != nil {
@@ -185,7 +234,7 @@ func (h *JobHandler) UpdateJob(c echo.Context) error {
 
 // DeleteJob godoc
 // @Summary Delete a job
-// @Description Delete a job application
+// @Description Move a job application to the trash. It can be restored with POST /jobs/{id}/restore until it is purged after the retention window.
 // @Tags jobs
 // @Accept json
 // @Produce json
@@ -223,5 +272,184 @@ func (h *JobHandler) DeleteJob(c echo.Context) error {
 		return response.Error(c, err)
 	}
 
-	return response.Success(c, http.StatusOK, "job deleted successfully", nil)
+	return response.Success(c, http.StatusOK, "job moved to trash", nil)
+}
+
+// ArchiveJob godoc
+// @Summary Archive a job
+// @Description Hide a job from the default listings, the board and analytics without deleting it
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} response.Response{data=domain.Job}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /jobs/{id}/archive [post]
+func (h *JobHandler) ArchiveJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	job, err := h.jobService.Archive(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job archived", job)
+}
+
+// RestoreJob godoc
+// @Summary Restore a job
+// @Description Undo the last delete or archive. A trashed job comes back as it was before it was deleted; an archived job becomes active again.
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID"
+// @Success 200 {object} response.Response{data=domain.Job}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /jobs/{id}/restore [post]
+func (h *JobHandler) RestoreJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	job, err := h.jobService.Restore(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job restored", job)
+}
+
+// GetTrash godoc
+// @Summary List trashed jobs
+// @Description Jobs in the trash, most recently deleted first, with the time each will be permanently deleted
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} response.Response{data=[]domain.TrashedJob}
+// @Failure 401 {object} response.Response
+// @Router /jobs/trash [get]
+func (h *JobHandler) GetTrash(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobs, err := h.jobService.ListTrash(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Errorf("failed to list trashed jobs: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "trashed jobs retrieved successfully", jobs)
+}
+
+// MergeJob godoc
+// @Summary Merge a duplicate job
+// @Description Fold duplicate_id into this job and delete it. Notes are combined, the most advanced status, highest priority and earliest application date are kept, and tags, contacts, attachments, status history and recommendation links move to this job. The duplicate's offer and description snapshot are kept only if this job has none.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Job ID to keep"
+// @Param request body domain.MergeJobsRequest true "Job to merge in"
+// @Success 200 {object} response.Response{data=domain.Job}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 404 {object} response.Response
+// @Router /jobs/{id}/merge [post]
+func (h *JobHandler) MergeJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	var req domain.MergeJobsRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+	if req.DuplicateID == jobID {
+		return response.ValidationError(c, "a job cannot be merged into itself")
+	}
+
+	job, err := h.jobService.Merge(c.Request().Context(), userID, jobID, req.DuplicateID)
+	if err != nil {
+		c.Logger().Errorf("failed to merge jobs: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "jobs merged", job)
+}
+
+// parseJobFilter reads the shared job list filters: ?tag= (repeatable or
+// comma-separated), ?priority=, ?stage_id= and ?status=.
+func parseJobFilter(c echo.Context) (domain.JobFilter, error) {
+	var filter domain.JobFilter
+
+	for _, raw := range c.QueryParams()["tag"] {
+		for _, tag := range strings.Split(raw, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				filter.Tags = append(filter.Tags, tag)
+			}
+		}
+	}
+
+	if p := c.QueryParam("priority"); p != "" {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < int(domain.JobPriorityNone) || v > int(domain.JobPriorityHigh) {
+			return filter, errors.New("priority must be between 0 and 3")
+		}
+		priority := int32(v)
+		filter.Priority = &priority
+	}
+
+	if s := c.QueryParam("stage_id"); s != "" {
+		v, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return filter, errors.New("invalid stage_id")
+		}
+		stageID := int32(v)
+		filter.StageID = &stageID
+	}
+
+	if status := c.QueryParam("status"); status != "" {
+		valid := false
+		for _, st := range domain.JobStatuses {
+			if status == st {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return filter, errors.New("invalid status")
+		}
+		filter.Status = status
+	}
+
+	return filter, nil
 }
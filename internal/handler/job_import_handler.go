@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxImportBodyBytes caps the request body; pasted HTML can be large but not unbounded.
+const maxImportBodyBytes = 3 << 20
+
+type JobImportHandler struct {
+	importService service.JobImportService
+	validator     echo.Validator
+}
+
+func NewJobImportHandler(importService service.JobImportService, validator echo.Validator) *JobImportHandler {
+	return &JobImportHandler{
+		importService: importService,
+		validator:     validator,
+	}
+}
+
+// ImportJob godoc
+// @Summary Import a job from a posting URL or raw HTML
+// @Description Fetches the posting page (or parses the supplied HTML from the browser clipper) and returns a prefilled job. Reads schema.org JobPosting JSON-LD, Greenhouse, Lever and Workable layouts, and OpenGraph tags. Nothing is saved; send the returned job to POST /jobs to track it.
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.ImportJobRequest true "Posting URL and/or raw HTML"
+// @Success 200 {object} response.Response{data=domain.JobImportResult}
+// @Failure 400 {object} response.Response
+// @Failure 401 {object} response.Response
+// @Failure 422 {object} response.Response "No job posting found on the page"
+// @Failure 502 {object} response.Response "Posting page could not be fetched"
+// @Router /jobs/from-url [post]
+func (h *JobImportHandler) ImportJob(c echo.Context) error {
+	if _, ok := c.Get("user_id").(int32); !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxImportBodyBytes)
+
+	var req domain.ImportJobRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+	if strings.TrimSpace(req.URL) == "" && strings.TrimSpace(req.HTML) == "" {
+		return response.ValidationError(c, "either url or html is required")
+	}
+
+	result, err := h.importService.Import(c.Request().Context(), &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job details extracted", result)
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OfferHandler struct {
+	offerService service.OfferService
+	validator    echo.Validator
+}
+
+func NewOfferHandler(offerService service.OfferService, validator echo.Validator) *OfferHandler {
+	return &OfferHandler{
+		offerService: offerService,
+		validator:    validator,
+	}
+}
+
+// GetOffer godoc
+// @Summary      Get a job's offer
+// @Tags         offers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Success      200 {object} response.Response{data=domain.JobOffer}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/offer [get]
+func (h *OfferHandler) GetOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	offer, err := h.offerService.Get(c.Request().Context(), userID, jobID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "offer retrieved", offer)
+}
+
+// SaveOffer godoc
+// @Summary      Record a job's offer
+// @Description  Creates or replaces the offer details for a job: base salary and pay period, currency, yearly bonus, equity, benefits, start date, response deadline and remote policy. A reminder is sent shortly before the response deadline.
+// @Tags         offers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Param        request body domain.JobOfferRequest true "Offer details"
+// @Success      200 {object} response.Response{data=domain.JobOffer}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/offer [put]
+func (h *OfferHandler) SaveOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	var req domain.JobOfferRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	offer, err := h.offerService.Save(c.Request().Context(), userID, jobID, &req)
+	if err != nil {
+		c.Logger().Errorf("failed to save offer: %v", err)
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "offer saved", offer)
+}
+
+// DeleteOffer godoc
+// @Summary      Delete a job's offer
+// @Tags         offers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/offer [delete]
+func (h *OfferHandler) DeleteOffer(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	if err := h.offerService.Delete(c.Request().Context(), userID, jobID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "offer deleted", nil)
+}
+
+// CompareOffers godoc
+// @Summary      Compare offers side by side
+// @Description  Normalises offers to yearly base, bonus, equity and total in one currency and orders them by total. Omit job_id to compare every recorded offer. Offers in a currency with no configured exchange rate are returned last with converted=false.
+// @Tags         offers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        job_id   query []int false "Job IDs to compare (repeat the parameter or comma-separate)" collectionFormat(multi)
+// @Param        currency query string false "ISO 4217 currency to compare in (defaults to the server setting)"
+// @Success      200 {object} response.Response{data=domain.OfferComparison}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /jobs/offers/compare [get]
+func (h *OfferHandler) CompareOffers(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var jobIDs []int32
+	for _, raw := range c.QueryParams()["job_id"] {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			v, err := strconv.ParseInt(part, 10, 32)
+			if err != nil {
+				return response.ValidationError(c, "invalid job_id")
+			}
+			jobIDs = append(jobIDs, int32(v))
+		}
+	}
+
+	comparison, err := h.offerService.Compare(c.Request().Context(), userID, jobIDs, c.QueryParam("currency"))
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "offers compared", comparison)
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PipelineHandler struct {
+	pipelineService service.PipelineService
+	validator       echo.Validator
+}
+
+func NewPipelineHandler(pipelineService service.PipelineService, validator echo.Validator) *PipelineHandler {
+	return &PipelineHandler{
+		pipelineService: pipelineService,
+		validator:       validator,
+	}
+}
+
+// ListStages godoc
+// @Summary      List pipeline stages
+// @Description  Returns the user's Kanban columns in board order. New users get the default stages (Saved, Applied, Interview, Offer, Rejected).
+// @Tags         pipeline
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.PipelineStage}
+// @Failure      401 {object} response.Response
+// @Router       /pipeline/stages [get]
+func (h *PipelineHandler) ListStages(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	stages, err := h.pipelineService.ListStages(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "stages retrieved", stages)
+}
+
+// CreateStage godoc
+// @Summary      Create a pipeline stage
+// @Description  Adds a custom column at the end of the board. Every stage maps onto a base status (saved, applied, interview, offer, rejected).
+// @Tags         pipeline
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.PipelineStageRequest true "Stage details"
+// @Success      201 {object} response.Response{data=domain.PipelineStage}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      409 {object} response.Response
+// @Router       /pipeline/stages [post]
+func (h *PipelineHandler) CreateStage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.PipelineStageRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	stage, err := h.pipelineService.CreateStage(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "stage created", stage)
+}
+
+// UpdateStage godoc
+// @Summary      Update a pipeline stage
+// @Description  Renames or re-maps a stage. Jobs in the stage take on its new base status.
+// @Tags         pipeline
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Stage ID"
+// @Param        request body domain.PipelineStageRequest true "Stage details"
+// @Success      200 {object} response.Response{data=domain.PipelineStage}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Failure      409 {object} response.Response
+// @Router       /pipeline/stages/{id} [put]
+func (h *PipelineHandler) UpdateStage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	stageID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid stage id")
+	}
+
+	var req domain.PipelineStageRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	stage, err := h.pipelineService.UpdateStage(c.Request().Context(), userID, stageID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "stage updated", stage)
+}
+
+// DeleteStage godoc
+// @Summary      Delete a pipeline stage
+// @Description  Removes a stage and moves its jobs to another stage with the same base status. The last stage for a status cannot be deleted.
+// @Tags         pipeline
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Stage ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Failure      409 {object} response.Response
+// @Router       /pipeline/stages/{id} [delete]
+func (h *PipelineHandler) DeleteStage(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	stageID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid stage id")
+	}
+
+	if err := h.pipelineService.DeleteStage(c.Request().Context(), userID, stageID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "stage deleted", nil)
+}
+
+// ReorderStages godoc
+// @Summary      Reorder pipeline stages
+// @Description  Sets the column order. stage_ids must list every stage exactly once.
+// @Tags         pipeline
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.ReorderStagesRequest true "Stage IDs in the new order"
+// @Success      200 {object} response.Response{data=[]domain.PipelineStage}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /pipeline/stages/order [put]
+func (h *PipelineHandler) ReorderStages(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.ReorderStagesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	stages, err := h.pipelineService.ReorderStages(c.Request().Context(), userID, req.StageIDs)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "stages reordered", stages)
+}
+
+// GetBoard godoc
+// @Summary      Get the Kanban board
+// @Description  Returns the user's jobs grouped into pipeline columns, ordered within each column. Accepts the same filters as GET /jobs.
+// @Tags         pipeline
+// @Produce      json
+// @Security     BearerAuth
+// @Param        tag      query []string false "Only jobs carrying all of these tags" collectionFormat(multi)
+// @Param        priority query int      false "Priority (0 none, 1 low, 2 medium, 3 high)"
+// @Success      200 {object} response.Response{data=domain.JobBoard}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /jobs/board [get]
+func (h *PipelineHandler) GetBoard(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	filter, err := parseJobFilter(c)
+	if err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	board, err := h.pipelineService.GetBoard(c.Request().Context(), userID, filter)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "board retrieved", board)
+}
+
+// MoveJob godoc
+// @Summary      Move a job on the board
+// @Description  Drops a job into a stage at a zero-based position. The job's status follows the stage's base status.
+// @Tags         pipeline
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Job ID"
+// @Param        request body domain.MoveJobRequest true "Destination stage and position"
+// @Success      200 {object} response.Response{data=domain.Job}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/{id}/move [patch]
+func (h *PipelineHandler) MoveJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	jobID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job ID")
+	}
+
+	var req domain.MoveJobRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	job, err := h.pipelineService.MoveJob(c.Request().Context(), userID, jobID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job moved", job)
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+	"aiki/internal/service"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SavedSearchHandler struct {
+	searchService service.SavedSearchService
+	validator     echo.Validator
+}
+
+func NewSavedSearchHandler(searchService service.SavedSearchService, validator echo.Validator) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		searchService: searchService,
+		validator:     validator,
+	}
+}
+
+// ListSearches godoc
+// @Summary      List saved job searches
+// @Tags         saved-searches
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.SavedSearch}
+// @Failure      401 {object} response.Response
+// @Router       /saved-searches [get]
+func (h *SavedSearchHandler) ListSearches(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	searches, err := h.searchService.ListSearches(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "saved searches retrieved", searches)
+}
+
+// CreateSearch godoc
+// @Summary      Save a job search
+// @Description  For example {"name": "Remote Go roles", "query": "Backend Engineer", "remote_only": true, "min_salary": 90000, "excluded_companies": ["Acme"], "frequency": "daily", "alert": "instant"}. The scheduler re-runs the search daily or weekly; alert is instant (a notification per run with new matches), digest (one notification a day across digest searches) or off. The first run only records what is already out there.
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.SavedSearchRequest true "Search details"
+// @Success      201 {object} response.Response{data=domain.SavedSearch}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /saved-searches [post]
+func (h *SavedSearchHandler) CreateSearch(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.SavedSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	search, err := h.searchService.CreateSearch(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusCreated, "search saved", search)
+}
+
+// UpdateSearch godoc
+// @Summary      Update a saved job search
+// @Description  Changing the query, location or filters forgets the matches seen so far; the next run sets a new baseline.
+// @Tags         saved-searches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                       true "Saved search ID"
+// @Param        request body domain.SavedSearchRequest true "Search details"
+// @Success      200 {object} response.Response{data=domain.SavedSearch}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /saved-searches/{id} [put]
+func (h *SavedSearchHandler) UpdateSearch(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	searchID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid saved search ID")
+	}
+
+	var req domain.SavedSearchRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	search, err := h.searchService.UpdateSearch(c.Request().Context(), userID, searchID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "saved search updated", search)
+}
+
+// DeleteSearch godoc
+// @Summary      Delete a saved job search
+// @Tags         saved-searches
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Saved search ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /saved-searches/{id} [delete]
+func (h *SavedSearchHandler) DeleteSearch(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	searchID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid saved search ID")
+	}
+
+	if err := h.searchService.DeleteSearch(c.Request().Context(), userID, searchID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "saved search deleted", nil)
+}
+
+// ListMatches godoc
+// @Summary      List a saved search's matches
+// @Description  Returns the postings the search has found, newest first.
+// @Tags         saved-searches
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Saved search ID"
+// @Success      200 {object} response.Response{data=[]domain.SavedSearchMatch}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /saved-searches/{id}/matches [get]
+func (h *SavedSearchHandler) ListMatches(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	searchID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid saved search ID")
+	}
+
+	matches, err := h.searchService.ListMatches(c.Request().Context(), userID, searchID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "matches retrieved", matches)
+}
+
+// RunSearch godoc
+// @Summary      Run a saved search now
+// @Description  Searches the job boards immediately and returns the postings the search had not seen before. No notification is sent for them.
+// @Tags         saved-searches
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Saved search ID"
+// @Success      200 {object} response.Response{data=domain.SavedSearchRun}
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /saved-searches/{id}/run [post]
+func (h *SavedSearchHandler) RunSearch(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	searchID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid saved search ID")
+	}
+
+	run, err := h.searchService.RunSearch(c.Request().Context(), userID, searchID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "search run", run)
+}
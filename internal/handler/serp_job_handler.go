@@ -6,29 +6,43 @@ import (
 	"aiki/internal/service"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 type SerpJobHandler struct {
 	serpService service.SerpJobService
+	validator   echo.Validator
 }
 
-func NewSerpJobHandler(serpService service.SerpJobService) *SerpJobHandler {
-	return &SerpJobHandler{serpService: serpService}
+func NewSerpJobHandler(serpService service.SerpJobService, validator echo.Validator) *SerpJobHandler {
+	return &SerpJobHandler{
+		serpService: serpService,
+		validator:   validator,
+	}
 }
 
 // GetRecommendedJobs godoc
 // @Summary      Get recommended jobs
-// @Description  Fetches jobs from SerpApi based on user profile (job title + experience level). Optional query param `location` (e.g. Austin, TX or United States) is passed to SerpApi as the job location filter. Returns cached results if fetched within 24 hours for the same location.
+// @Description  Fetches jobs based on the user profile (job title + experience level). `location`, `locations`, `country`, `language`, `date_posted`, `employment_type` and `remote_only` are passed to the job boards; places and country not given are taken from the user's search preferences, and several places are searched together with the results merged. Users open to remote work also get remote roles open to their country, marked `remote`; the first page is served from cache if that search was fetched within 24 hours. `has_salary` and `platform` filter the cached results. `sort` is relevance (the default), newest or pay; newest and pay order only the jobs fetched so far, and jobs without a known posting date or salary come last. Each job carries `salary_range` and `posted_time` where they could be read. Results are paged: pass `next_cursor` from the previous page as `cursor` while `has_more` is true. A cursor from a different search is rejected.
 // @Tags         job-search
 // @Produce      json
 // @Security     BearerAuth
 // @Success      200 {object} response.Response{data=domain.JobSearchResult}
 // @Failure      400 {object} response.Response
 // @Failure      401 {object} response.Response
-// @Param        location query string false "Job location filter (e.g. Seattle, WA) — forwarded to SerpApi Google Jobs"
+// @Param        location        query string  false "Job location filter (e.g. Seattle, WA)"
+// @Param        locations       query []string false "Several places to search together, up to 5" collectionFormat(multi)
+// @Param        country         query string  false "ISO 3166 country code (e.g. ng)"
+// @Param        language        query string  false "ISO 639 language code (e.g. en)"
+// @Param        date_posted     query string  false "Posted within" Enums(today, 3days, week, month)
+// @Param        employment_type query string  false "Employment type" Enums(full_time, part_time, contract, internship)
+// @Param        remote_only     query bool    false "Only remote jobs"
+// @Param        has_salary      query bool    false "Only jobs that state a salary"
+// @Param        platform        query string  false "Only jobs listed on this platform (e.g. LinkedIn)"
+// @Param        sort            query string  false "Order" Enums(relevance, newest, pay)
+// @Param        cursor          query string  false "next_cursor from the previous page"
+// @Param        limit           query int     false "Page size, 1-50 (default 20)"
 // @Router       /jobs/recommended [get]
 func (h *SerpJobHandler) GetRecommendedJobs(c echo.Context) error {
 	userID, ok := c.Get("user_id").(int32)
@@ -36,8 +50,15 @@ func (h *SerpJobHandler) GetRecommendedJobs(c echo.Context) error {
 		return response.Error(c, domain.ErrUnauthorized)
 	}
 
-	location := strings.TrimSpace(c.QueryParam("location"))
-	result, err := h.serpService.GetJobsForUser(c.Request().Context(), userID, location)
+	var q domain.RecommendationQuery
+	if err := c.Bind(&q); err != nil {
+		return response.ValidationError(c, "invalid query parameters")
+	}
+	if err := h.validator.Validate(&q); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	result, err := h.serpService.GetJobsForUser(c.Request().Context(), userID, &q)
 	if err != nil {
 		return response.Error(c, err)
 	}
@@ -47,13 +68,13 @@ func (h *SerpJobHandler) GetRecommendedJobs(c echo.Context) error {
 
 // SaveJobToTracker godoc
 // @Summary      Save a recommended job to tracker
-// @Description  Saves a job from the recommended list into the user's job tracker
+// @Description  Saves a job from the recommended list into the user's job tracker. Tracked jobs that look like the same role are returned in duplicates so the client can offer a merge.
 // @Tags         job-search
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id path int true "Cache job ID"
-// @Success      201 {object} response.Response{data=domain.Job}
+// @Success      201 {object} response.Response{data=domain.SavedJob}
 // @Failure      400 {object} response.Response
 // @Failure      401 {object} response.Response
 // @Router       /jobs/recommended/{id}/save [post]
@@ -74,7 +95,11 @@ func (h *SerpJobHandler) SaveJobToTracker(c echo.Context) error {
 		return response.Error(c, err)
 	}
 
-	return response.Success(c, http.StatusCreated, "job saved to tracker", job)
+	message := "job saved to tracker"
+	if len(job.Duplicates) > 0 {
+		message = "job saved to tracker; it looks like a job you already track"
+	}
+	return response.Success(c, http.StatusCreated, message, job)
 }
 
 // ApplyRecommendedJob godoc
@@ -115,3 +140,186 @@ func (h *SerpJobHandler) ApplyRecommendedJob(c echo.Context) error {
 
 	return response.Success(c, http.StatusOK, "apply recorded", result)
 }
+
+// DismissJob godoc
+// @Summary      Dismiss a recommended job
+// @Description  Hides the job from recommendations, including after later refreshes. reason is wrong_seniority, wrong_location, company or not_relevant (the default); company hides every job from that company. Reasons also shape later searches: a seniority dismissed twice is left out of the search, and jobs like ones dismissed for location or relevance are ranked lower.
+// @Tags         job-search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path int                      true  "Cache job ID"
+// @Param        request body domain.DismissJobRequest false "Dismiss reason"
+// @Success      200 {object} response.Response{data=domain.JobDismissal}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/recommended/{id}/dismiss [post]
+func (h *SerpJobHandler) DismissJob(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	cacheID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid job id")
+	}
+
+	var req domain.DismissJobRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	dismissal, err := h.serpService.DismissJob(c.Request().Context(), userID, cacheID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "job dismissed", dismissal)
+}
+
+// ListDismissals godoc
+// @Summary      List dismissed recommended jobs
+// @Tags         job-search
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.JobDismissal}
+// @Failure      401 {object} response.Response
+// @Router       /jobs/recommended/dismissals [get]
+func (h *SerpJobHandler) ListDismissals(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	dismissals, err := h.serpService.ListDismissals(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "dismissals retrieved", dismissals)
+}
+
+// DeleteDismissal godoc
+// @Summary      Undo a dismissal
+// @Description  The job, or the company's jobs, can be recommended again.
+// @Tags         job-search
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path int true "Dismissal ID"
+// @Success      200 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Failure      404 {object} response.Response
+// @Router       /jobs/recommended/dismissals/{id} [delete]
+func (h *SerpJobHandler) DeleteDismissal(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	dismissalID, err := parseIDParam(c, "id")
+	if err != nil {
+		return response.ValidationError(c, "invalid dismissal ID")
+	}
+
+	if err := h.serpService.DeleteDismissal(c.Request().Context(), userID, dismissalID); err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "dismissal removed", nil)
+}
+
+// GetSearchPreferences godoc
+// @Summary      Get job search preferences
+// @Description  Where recommended jobs are searched for: country, language, places, and whether remote roles or jobs elsewhere in the country suit the user.
+// @Tags         job-search
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=domain.SearchPreferences}
+// @Failure      401 {object} response.Response
+// @Router       /jobs/recommended/preferences [get]
+func (h *SerpJobHandler) GetSearchPreferences(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	prefs, err := h.serpService.GetSearchPreferences(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "search preferences retrieved", prefs)
+}
+
+// UpdateSearchPreferences godoc
+// @Summary      Update job search preferences
+// @Description  Replaces the user's job search preferences. `country` is an ISO 3166 code such as "ng" and `language` an ISO 639 code such as "en"; up to 5 `locations` are searched together. `open_to_relocation` adds jobs anywhere in the country, `open_to_remote` remote roles open to it. The next recommendations are a new search.
+// @Tags         job-search
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.UpdateSearchPreferencesRequest true "Search preferences"
+// @Success      200 {object} response.Response{data=domain.SearchPreferences}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /jobs/recommended/preferences [put]
+func (h *SerpJobHandler) UpdateSearchPreferences(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.UpdateSearchPreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	prefs, err := h.serpService.UpdateSearchPreferences(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "search preferences updated", prefs)
+}
+
+// GetSearchQuota godoc
+// @Summary      Job search quota usage
+// @Description  Admin only. This month's calls to each paid job source against its plan. Fetching from a source stops once used reaches budget; searches then fall back to cached results.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=[]domain.SearchQuotaUsage}
+// @Failure      401 {object} response.Response
+// @Failure      403 {object} response.Response
+// @Router       /admin/search-quota [get]
+func (h *SerpJobHandler) GetSearchQuota(c echo.Context) error {
+	usage, err := h.serpService.GetSearchQuota(c.Request().Context())
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "search quota retrieved", usage)
+}
+
+// GetSearchStats godoc
+// @Summary      Job search cache and prefetch stats
+// @Description  Admin only. How often searches on this instance were answered from the shared cache, per source, and what the recommendation prefetch runs did, since the process started.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=domain.SearchStats}
+// @Failure      401 {object} response.Response
+// @Failure      403 {object} response.Response
+// @Router       /admin/search-stats [get]
+func (h *SerpJobHandler) GetSearchStats(c echo.Context) error {
+	stats := h.serpService.GetSearchStats(c.Request().Context())
+	return response.Success(c, http.StatusOK, "search stats retrieved", stats)
+}
@@ -84,7 +84,7 @@ func (h *UserHandler) UpdateMe(c echo.Context) error {
 
 // CreateProfile godoc
 // @Summary      Create user profile
-// @Description  Create a profile for the currently authenticated user
+// @Description  Create a profile for the currently authenticated user. The job title and experience level are stored in standard form, e.g. "Snr. Software Engr" becomes Software Engineer at senior level.
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -124,7 +124,7 @@ func (h *UserHandler) CreateProfile(c echo.Context) error {
 
 // UpdateProfile godoc
 // @Summary      Update user profile
-// @Description  Update the currently authenticated user's profile details
+// @Description  Update the currently authenticated user's profile details. The job title and experience level are stored in standard form.
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -183,6 +183,31 @@ func (h *UserHandler) GetProfile(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "user profile retrieved successfully", profile)
 }
 
+// SuggestTitles godoc
+// @Summary      Suggest job titles
+// @Description  Autocomplete for the onboarding job title field. Returns standard titles matching what has been typed, with the titles searched alongside each, the seniority the text started with (e.g. "snr back" gives senior) and every experience level. Profiles store the title and level in this standard form.
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q     query string false "Text typed so far"
+// @Param        limit query int    false "Most titles to return, 1-20 (default 8)"
+// @Success      200 {object} response.Response{data=domain.TitleSuggestions}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /users/profile/title-suggestions [get]
+func (h *UserHandler) SuggestTitles(c echo.Context) error {
+	var q domain.TitleSuggestionQuery
+	if err := c.Bind(&q); err != nil {
+		return response.ValidationError(c, "invalid query parameters")
+	}
+	if err := h.validator.Validate(&q); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	suggestions := h.userService.SuggestTitles(c.Request().Context(), &q)
+	return response.Success(c, http.StatusOK, "title suggestions retrieved", suggestions)
+}
+
 // UploadCV godoc
 // @Summary      Upload CV
 // @Description  Upload a CV file (PDF, max 5MB) for the currently authenticated user
@@ -230,6 +255,63 @@ func (h *UserHandler) UploadCV(c echo.Context) error {
 	return response.Success(c, http.StatusOK, "file uploaded successfully", nil)
 }
 
+// GetTimezone godoc
+// @Summary      Get user time zone
+// @Description  Returns the IANA time zone the user's streak days, daily progress and reminders follow
+// @Tags         users
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} response.Response{data=domain.UserTimezone}
+// @Failure      401 {object} response.Response
+// @Router       /users/me/timezone [get]
+func (h *UserHandler) GetTimezone(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	timezone, err := h.userService.GetTimezone(c.Request().Context(), userID)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "time zone retrieved", timezone)
+}
+
+// UpdateTimezone godoc
+// @Summary      Set user time zone
+// @Description  Sets the IANA time zone, e.g. Africa/Nairobi, that the user's streak days, daily progress and reminders follow. The streak carries over to the new zone.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.UserTimezone true "Time zone"
+// @Success      200 {object} response.Response{data=domain.UserTimezone}
+// @Failure      400 {object} response.Response
+// @Failure      401 {object} response.Response
+// @Router       /users/me/timezone [put]
+func (h *UserHandler) UpdateTimezone(c echo.Context) error {
+	userID, ok := c.Get("user_id").(int32)
+	if !ok {
+		return response.Error(c, domain.ErrUnauthorized)
+	}
+
+	var req domain.UserTimezone
+	if err := c.Bind(&req); err != nil {
+		return response.ValidationError(c, "invalid request body")
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		return response.ValidationError(c, err.Error())
+	}
+
+	timezone, err := h.userService.UpdateTimezone(c.Request().Context(), userID, &req)
+	if err != nil {
+		return response.Error(c, err)
+	}
+
+	return response.Success(c, http.StatusOK, "time zone updated", timezone)
+}
+
 // GetCV godoc
 // @Summary      Get user CV
 // @Description  Download the currently authenticated user's uploaded CV
@@ -82,6 +82,27 @@ func (m *MockUserService) UploadUserCV(ctx context.Context, userID int32, data [
 	return args.Error(0)
 }
 
+func (m *MockUserService) SuggestTitles(ctx context.Context, q *domain.TitleSuggestionQuery) *domain.TitleSuggestions {
+	args := m.Called(ctx, q)
+	return args.Get(0).(*domain.TitleSuggestions)
+}
+
+func (m *MockUserService) GetTimezone(ctx context.Context, id int32) (*domain.UserTimezone, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserTimezone), args.Error(1)
+}
+
+func (m *MockUserService) UpdateTimezone(ctx context.Context, id int32, req *domain.UserTimezone) (*domain.UserTimezone, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserTimezone), args.Error(1)
+}
+
 func TestUserHandler_GetMe(t *testing.T) {
 	e := setupEcho()
 	mockService := new(MockUserService)
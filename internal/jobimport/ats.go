@@ -0,0 +1,140 @@
+package jobimport
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// atsParser extracts fields from one applicant tracking system's page layout.
+type atsParser struct {
+	name    string
+	hosts   []string
+	matches func(doc *html.Node) bool
+	parse   func(doc *html.Node) fields
+}
+
+var atsParsers = []atsParser{
+	{
+		name:  "greenhouse",
+		hosts: []string{"greenhouse.io"},
+		matches: func(doc *html.Node) bool {
+			return findFirst(doc, byClass("app-title")) != nil || findFirst(doc, byClass("job__title")) != nil
+		},
+		parse: parseGreenhouse,
+	},
+	{
+		name:  "lever",
+		hosts: []string{"lever.co"},
+		matches: func(doc *html.Node) bool {
+			return findFirst(doc, byClass("posting-headline")) != nil
+		},
+		parse: parseLever,
+	},
+	{
+		name:  "workable",
+		hosts: []string{"workable.com"},
+		matches: func(doc *html.Node) bool {
+			return findFirst(doc, byAttr("data-ui", "job-title")) != nil
+		},
+		parse: parseWorkable,
+	},
+}
+
+// parseATS picks the ATS layout by host first, then by page markers so that
+// pasted HTML from the browser clipper is still recognised.
+func parseATS(doc *html.Node, host string) (fields, string, bool) {
+	for _, p := range atsParsers {
+		for _, h := range p.hosts {
+			if host == h || strings.HasSuffix(host, "."+h) {
+				return p.parse(doc), p.name, true
+			}
+		}
+	}
+	for _, p := range atsParsers {
+		if p.matches(doc) {
+			return p.parse(doc), p.name, true
+		}
+	}
+	return fields{}, "", false
+}
+
+// Greenhouse has two layouts: the classic boards.greenhouse.io page
+// (h1.app-title, span.company-name) and the newer job-boards page (div.job__title).
+func parseGreenhouse(doc *html.Node) fields {
+	var f fields
+	if title := findFirst(doc, byClass("app-title")); title != nil {
+		f.Title = textOf(title)
+		company := textOf(findFirst(doc, byClass("company-name")))
+		f.CompanyName = strings.TrimSpace(strings.TrimPrefix(company, "at "))
+		if header := findFirst(doc, byAttr("id", "header")); header != nil {
+			f.Location = textOf(findFirst(header, byClass("location")))
+		}
+		f.Description = plainText(findFirst(doc, byAttr("id", "content")))
+		return f
+	}
+
+	if block := findFirst(doc, byClass("job__title")); block != nil {
+		f.Title = textOf(findFirst(block, byTag("h1")))
+		f.Location = textOf(findFirst(block, byClass("job__location")))
+	}
+	f.Description = plainText(findFirst(doc, byClass("job__description")))
+	if pay := findFirst(doc, byClass("pay-range")); pay != nil {
+		f.Salary = textOf(pay)
+	}
+	return f
+}
+
+// Lever pages title the document "Company - Job title".
+func parseLever(doc *html.Node) fields {
+	var f fields
+	headline := findFirst(doc, byClass("posting-headline"))
+	if headline != nil {
+		f.Title = textOf(findFirst(headline, byTag("h2")))
+		if categories := findFirst(headline, byClass("posting-categories")); categories != nil {
+			f.Location = textOf(findFirst(categories, byClass("location")))
+			if workplace := textOf(findFirst(categories, byClass("workplaceTypes"))); workplace != "" {
+				f.Location = joinNonEmpty(" · ", f.Location, strings.TrimSuffix(workplace, " —"))
+			}
+		}
+	}
+	if title := textOf(findFirst(doc, byTag("title"))); title != "" {
+		if company, _, ok := strings.Cut(title, " - "); ok {
+			f.CompanyName = strings.TrimSpace(company)
+		}
+	}
+	f.Salary = textOf(findFirst(doc, byAttr("data-qa", "salary-range")))
+
+	sections := findAll(doc, func(n *html.Node) bool {
+		qa := attr(n, "data-qa")
+		return qa == "job-description" || qa == "closing-description" || (n.Data == "div" && hasClass(n, "section") && hasClass(n, "page-centered") && findFirst(n, byTag("ul")) != nil)
+	})
+	parts := make([]string, 0, len(sections))
+	for _, s := range sections {
+		if text := plainText(s); text != "" && !contains(parts, text) {
+			parts = append(parts, text)
+		}
+	}
+	f.Description = strings.Join(parts, "\n\n")
+	return f
+}
+
+func parseWorkable(doc *html.Node) fields {
+	return fields{
+		Title:       textOf(findFirst(doc, byAttr("data-ui", "job-title"))),
+		CompanyName: textOf(findFirst(doc, byAttr("data-ui", "company-name"))),
+		Location:    textOf(findFirst(doc, byAttr("data-ui", "job-location"))),
+		Salary:      textOf(findFirst(doc, byAttr("data-ui", "job-salary"))),
+		Description: plainText(findFirst(doc, byAttr("data-ui", "job-description"))),
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, sep)
+}
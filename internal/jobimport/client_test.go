@@ -0,0 +1,52 @@
+package jobimport
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{"8.8.8.8", true},
+		{"104.16.0.1", true},
+		{"2606:4700::1111", true},
+		{"::ffff:8.8.8.8", true},
+
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"10.1.2.3", false},
+		{"100.64.0.1", false},
+		{"100.127.255.254", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"172.16.0.1", false},
+		{"192.0.0.8", false},
+		{"192.0.2.1", false},
+		{"192.168.1.1", false},
+		{"198.18.0.1", false},
+		{"198.51.100.7", false},
+		{"203.0.113.9", false},
+		{"224.0.0.1", false},
+		{"239.255.255.250", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:100.64.0.1", false},
+		{"64:ff9b::a00:1", false},
+		{"2001:db8::1", false},
+		{"2002:a00:1::", false},
+		{"fd00::1", false},
+		{"fe80::1", false},
+		{"ff02::1", false},
+	}
+	for _, tt := range tests {
+		if got := isPublicIP(net.ParseIP(tt.ip)); got != tt.public {
+			t.Errorf("isPublicIP(%s) = %v, want %v", tt.ip, got, tt.public)
+		}
+	}
+}
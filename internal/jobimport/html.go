@@ -0,0 +1,160 @@
+package jobimport
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// findAll returns every element under n that matches pred, in document order.
+func findAll(n *html.Node, pred func(*html.Node) bool) []*html.Node {
+	var out []*html.Node
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && pred(node) {
+			out = append(out, node)
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return out
+}
+
+// findFirst returns the first element under n that matches pred, or nil.
+func findFirst(n *html.Node, pred func(*html.Node) bool) *html.Node {
+	if n.Type == html.ElementNode && pred(n) {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findFirst(c, pred); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if strings.EqualFold(a.Key, key) {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasClass(n *html.Node, class string) bool {
+	for _, c := range strings.Fields(attr(n, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func byTag(tag string) func(*html.Node) bool {
+	return func(n *html.Node) bool { return n.Data == tag }
+}
+
+func byClass(class string) func(*html.Node) bool {
+	return func(n *html.Node) bool { return hasClass(n, class) }
+}
+
+func byAttr(key, value string) func(*html.Node) bool {
+	return func(n *html.Node) bool { return attr(n, key) == value }
+}
+
+// textOf returns the visible text of n with whitespace collapsed. A nil node yields "".
+func textOf(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && (node.Data == "script" || node.Data == "style") {
+			return
+		}
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			b.WriteByte(' ')
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return collapseSpaces(b.String())
+}
+
+// blockTags end a line when rendering HTML descriptions as plain text.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"section": true, "tr": true,
+}
+
+// plainText renders n as plain text, keeping paragraph and list breaks so a
+// description stays readable once the markup is gone.
+func plainText(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			b.WriteString(node.Data)
+			return
+		case html.ElementNode:
+			if node.Data == "script" || node.Data == "style" {
+				return
+			}
+			if node.Data == "li" {
+				b.WriteString("\n• ")
+			}
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if node.Type == html.ElementNode && blockTags[node.Data] {
+			b.WriteByte('\n')
+		}
+	}
+	walk(n)
+
+	lines := strings.Split(b.String(), "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = collapseSpaces(line)
+		if line == "" || line == "•" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		out = append(out, line)
+		blank = false
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// htmlFragmentToText converts an HTML string (as found in JSON-LD descriptions) to plain text.
+func htmlFragmentToText(fragment string) string {
+	if !strings.Contains(fragment, "<") {
+		return strings.TrimSpace(html.UnescapeString(fragment))
+	}
+	doc, err := html.Parse(strings.NewReader(fragment))
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+	return plainText(doc)
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
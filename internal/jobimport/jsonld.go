@@ -0,0 +1,219 @@
+package jobimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// parseJSONLD looks for a schema.org JobPosting in the page's
+// <script type="application/ld+json"> blocks.
+func parseJSONLD(doc *html.Node) (fields, bool) {
+	scripts := findAll(doc, func(n *html.Node) bool {
+		return n.Data == "script" && strings.Contains(strings.ToLower(attr(n, "type")), "ld+json")
+	})
+
+	for _, s := range scripts {
+		if s.FirstChild == nil {
+			continue
+		}
+		var raw any
+		if err := json.Unmarshal([]byte(s.FirstChild.Data), &raw); err != nil {
+			continue
+		}
+		if posting := findJobPosting(raw); posting != nil {
+			return jobPostingFields(posting), true
+		}
+	}
+	return fields{}, false
+}
+
+// findJobPosting walks a decoded JSON-LD value (object, array or @graph) and
+// returns the first node typed JobPosting.
+func findJobPosting(v any) map[string]any {
+	switch t := v.(type) {
+	case []any:
+		for _, item := range t {
+			if p := findJobPosting(item); p != nil {
+				return p
+			}
+		}
+	case map[string]any:
+		if isType(t["@type"], "JobPosting") {
+			return t
+		}
+		if graph, ok := t["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	}
+	return nil
+}
+
+func isType(v any, want string) bool {
+	switch t := v.(type) {
+	case string:
+		return t == want
+	case []any:
+		for _, item := range t {
+			if s, ok := item.(string); ok && s == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jobPostingFields(p map[string]any) fields {
+	f := fields{
+		Title:       collapseSpaces(html.UnescapeString(str(p["title"]))),
+		CompanyName: orgName(p["hiringOrganization"]),
+		Location:    jobLocation(p["jobLocation"]),
+		Salary:      salary(p["baseSalary"]),
+		Description: htmlFragmentToText(str(p["description"])),
+	}
+	if strings.EqualFold(str(p["jobLocationType"]), "TELECOMMUTE") {
+		if f.Location == "" {
+			f.Location = "Remote"
+		} else {
+			f.Location = f.Location + " (Remote)"
+		}
+	}
+	return f
+}
+
+func orgName(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any:
+		return strings.TrimSpace(str(t["name"]))
+	case []any:
+		if len(t) > 0 {
+			return orgName(t[0])
+		}
+	}
+	return ""
+}
+
+func jobLocation(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case []any:
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			if loc := jobLocation(item); loc != "" {
+				parts = append(parts, loc)
+			}
+		}
+		return strings.Join(parts, "; ")
+	case map[string]any:
+		if addr, ok := t["address"]; ok {
+			return address(addr)
+		}
+		return strings.TrimSpace(str(t["name"]))
+	}
+	return ""
+}
+
+func address(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case map[string]any:
+		parts := make([]string, 0, 3)
+		for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			var part string
+			switch c := t[key].(type) {
+			case string:
+				part = c
+			case map[string]any:
+				part = str(c["name"])
+			}
+			part = strings.TrimSpace(part)
+			if part != "" && !contains(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+		return strings.Join(parts, ", ")
+	}
+	return ""
+}
+
+// salary formats a schema.org MonetaryAmount, e.g. "USD 120000–150000 a year".
+func salary(v any) string {
+	amount, ok := v.(map[string]any)
+	if !ok {
+		return ""
+	}
+	currency := str(amount["currency"])
+
+	var min, max, unit string
+	switch value := amount["value"].(type) {
+	case map[string]any:
+		min = number(value["minValue"])
+		max = number(value["maxValue"])
+		if min == "" && max == "" {
+			min = number(value["value"])
+		}
+		unit = str(value["unitText"])
+		if currency == "" {
+			currency = str(value["currency"])
+		}
+	default:
+		min = number(value)
+	}
+	if unit == "" {
+		unit = str(amount["unitText"])
+	}
+
+	var out string
+	switch {
+	case min != "" && max != "" && min != max:
+		out = fmt.Sprintf("%s–%s", min, max)
+	case min != "":
+		out = min
+	case max != "":
+		out = max
+	default:
+		return ""
+	}
+	if currency != "" {
+		out = currency + " " + out
+	}
+	switch unit = strings.ToLower(unit); unit {
+	case "":
+	case "hour":
+		out = out + " an hour"
+	default:
+		out = out + " a " + unit
+	}
+	return out
+}
+
+func number(v any) string {
+	switch t := v.(type) {
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case string:
+		return strings.TrimSpace(t)
+	}
+	return ""
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+// Package jobimport extracts job details from a posting page so users can add
+// a job to the tracker by pasting its URL instead of typing every field.
+package jobimport
+
+import (
+	"aiki/internal/domain"
+	"bytes"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const maxDescriptionLength = 20000
+
+// fields is the intermediate result of a single extraction strategy.
+type fields struct {
+	Title       string
+	CompanyName string
+	Location    string
+	Salary      string
+	Description string
+}
+
+// fillFrom copies any field that is still empty from other.
+func (f *fields) fillFrom(other fields) {
+	if f.Title == "" {
+		f.Title = other.Title
+	}
+	if f.CompanyName == "" {
+		f.CompanyName = other.CompanyName
+	}
+	if f.Location == "" {
+		f.Location = other.Location
+	}
+	if f.Salary == "" {
+		f.Salary = other.Salary
+	}
+	if f.Description == "" {
+		f.Description = other.Description
+	}
+}
+
+// Parse extracts a job posting from raw HTML. pageURL is optional; when set it
+// becomes the job link and is used to pick the ATS layout.
+//
+// Sources are tried in order of reliability: schema.org JobPosting JSON-LD,
+// then known ATS layouts, then OpenGraph/meta tags. Later sources only fill
+// fields the earlier ones left empty.
+func Parse(rawHTML []byte, pageURL string) (*domain.ImportedJob, error) {
+	doc, err := html.Parse(bytes.NewReader(rawHTML))
+	if err != nil {
+		return nil, domain.ErrJobImportFailed
+	}
+
+	host := hostOf(pageURL)
+
+	var result fields
+	var source string
+
+	if f, ok := parseJSONLD(doc); ok {
+		result = f
+		source = "json-ld"
+	}
+	if f, name, ok := parseATS(doc, host); ok {
+		result.fillFrom(f)
+		if source == "" {
+			source = name
+		}
+	}
+	if f, ok := parseOpenGraph(doc); ok {
+		result.fillFrom(f)
+		if source == "" {
+			source = "opengraph"
+		}
+	}
+
+	if result.Title == "" {
+		return nil, domain.ErrJobImportFailed
+	}
+
+	link := pageURL
+	if link == "" {
+		link = canonicalURL(doc)
+	}
+
+	return &domain.ImportedJob{
+		Title:       truncate(result.Title, 255),
+		CompanyName: truncate(result.CompanyName, 150),
+		Location:    truncate(result.Location, 255),
+		Salary:      truncate(result.Salary, 100),
+		Description: truncate(result.Description, maxDescriptionLength),
+		Link:        link,
+		Platform:    platformFor(hostOf(link)),
+		Source:      source,
+	}, nil
+}
+
+// parseOpenGraph reads og:* and standard meta tags, falling back to <title>.
+func parseOpenGraph(doc *html.Node) (fields, bool) {
+	meta := make(map[string]string)
+	for _, m := range findAll(doc, byTag("meta")) {
+		key := attr(m, "property")
+		if key == "" {
+			key = attr(m, "name")
+		}
+		key = strings.ToLower(key)
+		if key == "" {
+			continue
+		}
+		if _, seen := meta[key]; !seen {
+			meta[key] = strings.TrimSpace(attr(m, "content"))
+		}
+	}
+
+	f := fields{
+		Title:       meta["og:title"],
+		CompanyName: meta["og:site_name"],
+		Description: meta["og:description"],
+	}
+	if f.Title == "" {
+		f.Title = meta["twitter:title"]
+	}
+	if f.Title == "" {
+		f.Title = textOf(findFirst(doc, byTag("title")))
+	}
+	if f.Description == "" {
+		f.Description = meta["description"]
+	}
+	f.Title = collapseSpaces(f.Title)
+
+	return f, f.Title != "" || f.Description != ""
+}
+
+func canonicalURL(doc *html.Node) string {
+	link := findFirst(doc, func(n *html.Node) bool {
+		return n.Data == "link" && strings.EqualFold(attr(n, "rel"), "canonical")
+	})
+	if link != nil {
+		return attr(link, "href")
+	}
+	for _, m := range findAll(doc, byTag("meta")) {
+		if attr(m, "property") == "og:url" {
+			return attr(m, "content")
+		}
+	}
+	return ""
+}
+
+func hostOf(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// platformFor maps a host to the value stored in jobs.platform.
+func platformFor(host string) string {
+	known := map[string]string{
+		"greenhouse.io": "greenhouse",
+		"lever.co":      "lever",
+		"workable.com":  "workable",
+		"linkedin.com":  "linkedin",
+		"indeed.com":    "indeed",
+		"glassdoor.com": "glassdoor",
+	}
+	for domainName, platform := range known {
+		if host == domainName || strings.HasSuffix(host, "."+domainName) {
+			return platform
+		}
+	}
+	if host == "" {
+		return "website"
+	}
+	return host
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(r[:n]))
+}
@@ -0,0 +1,174 @@
+package jobimport
+
+import (
+	"aiki/internal/domain"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestParse_Fixtures(t *testing.T) {
+	cases := []struct {
+		fixture  string
+		url      string
+		want     domain.ImportedJob
+		descHas  []string
+		descNone []string
+	}{
+		{
+			fixture: "jsonld_jobposting.html",
+			url:     "https://paystack.com/careers/backend-engineer",
+			want: domain.ImportedJob{
+				Title:       "Backend Engineer (Go)",
+				CompanyName: "Paystack",
+				Location:    "Lagos, NG",
+				Salary:      "NGN 900000–1400000 a month",
+				Link:        "https://paystack.com/careers/backend-engineer",
+				Platform:    "paystack.com",
+				Source:      "json-ld",
+			},
+			descHas:  []string{"build payment APIs", "• Design services in Go"},
+			descNone: []string{"<strong>"},
+		},
+		{
+			fixture: "greenhouse_classic.html",
+			url:     "https://boards.greenhouse.io/flutterwave/jobs/123",
+			want: domain.ImportedJob{
+				Title:       "Senior Mobile Engineer",
+				CompanyName: "Flutterwave",
+				Location:    "Remote - Nigeria",
+				Link:        "https://boards.greenhouse.io/flutterwave/jobs/123",
+				Platform:    "greenhouse",
+				Source:      "greenhouse",
+			},
+			descHas:  []string{"What you will do", "• Ship features in Flutter"},
+			descNone: []string{"ignored"},
+		},
+		{
+			fixture: "lever.html",
+			url:     "https://jobs.lever.co/kuda/abc-123",
+			want: domain.ImportedJob{
+				Title:       "Product Designer",
+				CompanyName: "Kuda",
+				Location:    "London, United Kingdom · Hybrid",
+				Salary:      "£55,000 - £70,000 a year",
+				Link:        "https://jobs.lever.co/kuda/abc-123",
+				Platform:    "lever",
+				Source:      "lever",
+			},
+			descHas: []string{"delightful banking", "• A strong portfolio"},
+		},
+		{
+			// Pasted from the browser clipper: no URL, layout detected from markup.
+			fixture: "workable.html",
+			want: domain.ImportedJob{
+				Title:       "Data Analyst",
+				CompanyName: "Moniepoint",
+				Location:    "Lagos, Lagos, Nigeria",
+				Salary:      "₦400K–₦600K a month",
+				Platform:    "website",
+				Source:      "workable",
+			},
+			descHas: []string{"Turn data into decisions."},
+		},
+		{
+			fixture: "opengraph_only.html",
+			want: domain.ImportedJob{
+				Title:       "QA Engineer",
+				CompanyName: "Example Corp",
+				Link:        "https://careers.example.com/jobs/42",
+				Platform:    "careers.example.com",
+				Source:      "opengraph",
+			},
+			descHas: []string{"Help us ship quality software."},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			got, err := Parse(loadFixture(t, tc.fixture), tc.url)
+			if err != nil {
+				t.Fatalf("Parse returned error: %v", err)
+			}
+
+			desc := got.Description
+			got.Description = ""
+			if *got != tc.want {
+				t.Errorf("Parse() =\n  %+v\nwant\n  %+v", *got, tc.want)
+			}
+			for _, s := range tc.descHas {
+				if !strings.Contains(desc, s) {
+					t.Errorf("description missing %q:\n%s", s, desc)
+				}
+			}
+			for _, s := range tc.descNone {
+				if strings.Contains(desc, s) {
+					t.Errorf("description should not contain %q:\n%s", s, desc)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_NoPosting(t *testing.T) {
+	_, err := Parse(loadFixture(t, "no_posting.html"), "https://example.com")
+	if !errors.Is(err, domain.ErrJobImportFailed) {
+		t.Fatalf("expected ErrJobImportFailed, got %v", err)
+	}
+}
+
+func TestSalary(t *testing.T) {
+	cases := []struct {
+		name string
+		in   map[string]any
+		want string
+	}{
+		{
+			name: "single value",
+			in:   map[string]any{"currency": "USD", "value": map[string]any{"value": 45.5, "unitText": "HOUR"}},
+			want: "USD 45.5 an hour",
+		},
+		{
+			name: "plain number",
+			in:   map[string]any{"currency": "EUR", "value": 60000.0},
+			want: "EUR 60000",
+		},
+		{
+			name: "empty",
+			in:   map[string]any{"currency": "EUR"},
+			want: "",
+		},
+	}
+	for _, tc := range cases {
+		if got := salary(tc.in); got != tc.want {
+			t.Errorf("%s: salary() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFetch_RejectsUnsafeURLs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(loadFixture(t, "lever.html"))
+	}))
+	defer srv.Close()
+
+	c := NewClient()
+	for _, raw := range []string{"file:///etc/passwd", "ftp://example.com/job", "http://", srv.URL} {
+		if _, _, err := c.Fetch(t.Context(), raw); err == nil {
+			t.Errorf("Fetch(%q) should be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	adzunaBaseURL  = "https://api.adzuna.com/v1/api/jobs"
+	adzunaPageSize = 20
+)
+
+// Adzuna searches the Adzuna aggregator. It needs an app ID and key and
+// searches one country at a time: the query's, when Adzuna covers it, or
+// the configured one.
+type Adzuna struct {
+	baseURL    string
+	httpClient *http.Client
+	appID      string
+	appKey     string
+	country    string
+}
+
+// NewAdzuna searches the given country, a lower-case ISO code such as "gb".
+func NewAdzuna(appID, appKey, country string) *Adzuna {
+	return &Adzuna{
+		baseURL:    adzunaBaseURL,
+		httpClient: newHTTPClient(),
+		appID:      appID,
+		appKey:     appKey,
+		country:    strings.ToLower(country),
+	}
+}
+
+func (a *Adzuna) Name() string {
+	return "adzuna"
+}
+
+// adzunaCountries are the countries Adzuna has listings for.
+var adzunaCountries = map[string]bool{
+	"at": true, "au": true, "be": true, "br": true, "ca": true, "ch": true, "de": true,
+	"es": true, "fr": true, "gb": true, "in": true, "it": true, "mx": true, "nl": true,
+	"nz": true, "pl": true, "sg": true, "us": true, "za": true,
+}
+
+type adzunaResponse struct {
+	Count   int `json:"count"`
+	Results []struct {
+		ID          string  `json:"id"`
+		Title       string  `json:"title"`
+		Description string  `json:"description"`
+		RedirectURL string  `json:"redirect_url"`
+		Created     string  `json:"created"`
+		SalaryMin   float64 `json:"salary_min"`
+		SalaryMax   float64 `json:"salary_max"`
+		Company     struct {
+			DisplayName string `json:"display_name"`
+		} `json:"company"`
+		Location struct {
+			DisplayName string `json:"display_name"`
+		} `json:"location"`
+	} `json:"results"`
+}
+
+func (a *Adzuna) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	jobs, _, err := a.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+// adzunaMaxDaysOld maps the date filters onto Adzuna's max_days_old.
+var adzunaMaxDaysOld = map[string]string{
+	domain.DatePostedToday:     "1",
+	domain.DatePostedThreeDays: "3",
+	domain.DatePostedWeek:      "7",
+	domain.DatePostedMonth:     "30",
+}
+
+// adzunaContractParams maps the employment types onto Adzuna's flags. It has
+// none for internships.
+var adzunaContractParams = map[string]string{
+	domain.EmploymentFullTime: "full_time",
+	domain.EmploymentPartTime: "part_time",
+	domain.EmploymentContract: "contract",
+}
+
+// SearchPage pages by number; the token is the next page to fetch. A
+// country Adzuna does not cover has no results.
+func (a *Adzuna) SearchPage(ctx context.Context, q Query, token string) ([]domain.SerpJob, string, error) {
+	country := a.country
+	if q.Country != "" {
+		if !adzunaCountries[q.Country] {
+			return nil, "", nil
+		}
+		country = q.Country
+	}
+
+	page := 1
+	if token != "" {
+		n, err := strconv.Atoi(token)
+		if err != nil || n < 1 {
+			return nil, "", fmt.Errorf("invalid adzuna page token %q", token)
+		}
+		page = n
+	}
+
+	params := url.Values{}
+	params.Set("app_id", a.appID)
+	params.Set("app_key", a.appKey)
+	params.Set("what", q.Title)
+	params.Set("results_per_page", strconv.Itoa(adzunaPageSize))
+	if q.Location != "" {
+		params.Set("where", q.Location)
+	}
+	if days, ok := adzunaMaxDaysOld[q.DatePosted]; ok {
+		params.Set("max_days_old", days)
+	}
+	if flag, ok := adzunaContractParams[q.EmploymentType]; ok {
+		params.Set(flag, "1")
+	}
+	reqURL := fmt.Sprintf("%s/%s/search/%d?%s", a.baseURL, url.PathEscape(country), page, params.Encode())
+
+	body, err := getJSON(ctx, a.httpClient, reqURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("adzuna request failed: %w", err)
+	}
+
+	var result adzunaResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, "", fmt.Errorf("failed to decode adzuna response: %w", err)
+	}
+
+	now := time.Now()
+	jobs := make([]domain.SerpJob, 0, len(result.Results))
+	for _, j := range result.Results {
+		var salary string
+		switch {
+		case j.SalaryMin > 0 && j.SalaryMax > j.SalaryMin:
+			salary = fmt.Sprintf("%.0f–%.0f", j.SalaryMin, j.SalaryMax)
+		case j.SalaryMax > 0:
+			salary = fmt.Sprintf("%.0f", j.SalaryMax)
+		}
+		jobs = append(jobs, domain.SerpJob{
+			ExternalID: externalID(a.Name(), j.ID),
+			// Adzuna highlights search terms with <strong> in titles.
+			Title:       htmlToText(j.Title),
+			CompanyName: j.Company.DisplayName,
+			Location:    j.Location.DisplayName,
+			Description: htmlToText(j.Description),
+			Link:        j.RedirectURL,
+			Platform:    "Adzuna",
+			PostedAt:    datePart(j.Created),
+			Salary:      salary,
+			FetchedAt:   now,
+		})
+	}
+
+	var next string
+	if len(result.Results) == adzunaPageSize && page*adzunaPageSize < result.Count {
+		next = strconv.Itoa(page + 1)
+	}
+	return jobs, next, nil
+}
@@ -0,0 +1,252 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/jobmatch"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Aggregator searches several sources at once. It is itself a Source, so the
+// recommendation service does not need to know how many boards are enabled.
+type Aggregator struct {
+	sources []Source
+	timeout time.Duration
+}
+
+// NewAggregator fans out to sources, giving each at most timeout to answer.
+func NewAggregator(timeout time.Duration, sources ...Source) *Aggregator {
+	return &Aggregator{sources: sources, timeout: timeout}
+}
+
+func (a *Aggregator) Name() string {
+	return "aggregate"
+}
+
+// Names lists the enabled sources.
+func (a *Aggregator) Names() []string {
+	names := make([]string, len(a.sources))
+	for i, src := range a.sources {
+		names[i] = src.Name()
+	}
+	return names
+}
+
+// Search queries every source in parallel and returns their first pages.
+func (a *Aggregator) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	jobs, _, err := a.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+// SearchPage queries the sources in parallel. A source that fails or runs
+// out of time is logged and left out; SearchPage only fails when all of them
+// do. Results are interleaved across sources so no single board crowds out
+// the rest, filtered on what the sources could not filter themselves, then
+// deduplicated.
+//
+// A query with several Locations, or IncludeRemote, is searched once per
+// place and once for remote roles, and the results merged the same way.
+//
+// The token carries each source's own page token. Later pages only query
+// the sources that had more.
+func (a *Aggregator) SearchPage(ctx context.Context, q Query, token string) ([]domain.SerpJob, string, error) {
+	if len(a.sources) == 0 {
+		return nil, "", errors.New("no job sources enabled")
+	}
+
+	type search struct {
+		key string
+		src Source
+		q   Query
+	}
+	subs := expand(q)
+	var searches []search
+	for i, sub := range subs {
+		for _, src := range a.sources {
+			key := src.Name()
+			if len(subs) > 1 {
+				key = fmt.Sprintf("%s#%d", key, i)
+			}
+			searches = append(searches, search{key: key, src: src, q: sub})
+		}
+	}
+
+	tokens := map[string]string{}
+	if token != "" {
+		if err := decodePageToken(token, &tokens); err != nil {
+			return nil, "", err
+		}
+		var more []search
+		for _, s := range searches {
+			if _, ok := tokens[s.key]; ok {
+				more = append(more, s)
+			}
+		}
+		if len(more) == 0 {
+			return nil, "", nil
+		}
+		searches = more
+	}
+
+	type result struct {
+		jobs []domain.SerpJob
+		next string
+		err  error
+	}
+	results := make([]result, len(searches))
+
+	var wg sync.WaitGroup
+	for i, s := range searches {
+		wg.Add(1)
+		go func(i int, s search) {
+			defer wg.Done()
+			sctx, cancel := context.WithTimeout(ctx, a.timeout)
+			defer cancel()
+			jobs, next, err := SearchPage(sctx, s.src, s.q, tokens[s.key])
+			results[i] = result{jobs: jobs, next: next, err: err}
+		}(i, s)
+	}
+	wg.Wait()
+
+	var (
+		perSearch [][]domain.SerpJob
+		errs      []error
+	)
+	now := time.Now()
+	nextTokens := map[string]string{}
+	for i, r := range results {
+		s := searches[i]
+		if r.err != nil {
+			log.Printf("job source %s failed: %v", s.key, r.err)
+			errs = append(errs, fmt.Errorf("%s: %w", s.key, r.err))
+			continue
+		}
+		perSearch = append(perSearch, filterJobs(markRemote(r.jobs), s.q, now))
+		if r.next != "" {
+			nextTokens[s.key] = r.next
+		}
+	}
+	if len(errs) == len(searches) {
+		return nil, "", fmt.Errorf("%w: %w", errAllSourcesFailed, errors.Join(errs...))
+	}
+
+	var next string
+	if len(nextTokens) > 0 {
+		next = encodePageToken(nextTokens)
+	}
+	return Dedupe(interleave(perSearch)), next, nil
+}
+
+// expand splits q into the searches it stands for: one per entry of
+// Locations, or q itself, plus one for remote roles when IncludeRemote is
+// set.
+func expand(q Query) []Query {
+	base := q
+	base.Locations = nil
+	base.IncludeRemote = false
+
+	var subs []Query
+	if len(q.Locations) == 0 {
+		subs = append(subs, base)
+	}
+	for _, loc := range q.Locations {
+		sub := base
+		sub.Location = loc
+		subs = append(subs, sub)
+	}
+	if q.IncludeRemote && !q.RemoteOnly {
+		sub := base
+		sub.Location = ""
+		sub.RemoteOnly = true
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// markRemote flags the postings whose text says the role is remote. It
+// copies jobs, which the shared cache may be handing to other searches too.
+func markRemote(jobs []domain.SerpJob) []domain.SerpJob {
+	marked := make([]domain.SerpJob, len(jobs))
+	for i, job := range jobs {
+		job.Remote = job.Remote || DetectRemote(job)
+		marked[i] = job
+	}
+	return marked
+}
+
+func encodePageToken(tokens map[string]string) string {
+	b, _ := json.Marshal(tokens)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string, tokens *map[string]string) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(b, tokens)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid page token: %w", err)
+	}
+	return nil
+}
+
+// interleave takes one job from each source in turn.
+func interleave(lists [][]domain.SerpJob) []domain.SerpJob {
+	var merged []domain.SerpJob
+	for i := 0; ; i++ {
+		added := false
+		for _, list := range lists {
+			if i < len(list) {
+				merged = append(merged, list[i])
+				added = true
+			}
+		}
+		if !added {
+			return merged
+		}
+	}
+}
+
+// Dedupe drops postings that jobmatch considers the same role as an earlier
+// one. Details the kept posting lacks, such as the salary, are taken from the
+// duplicate.
+func Dedupe(jobs []domain.SerpJob) []domain.SerpJob {
+	kept := make([]domain.SerpJob, 0, len(jobs))
+	postings := make([]jobmatch.Posting, 0, len(jobs))
+
+outer:
+	for _, job := range jobs {
+		posting := jobmatch.Posting{Title: job.Title, CompanyName: job.CompanyName, Location: job.Location, Link: job.Link}
+		for i := range kept {
+			if jobmatch.Match(postings[i], posting) != "" {
+				fillMissing(&kept[i], job)
+				continue outer
+			}
+		}
+		kept = append(kept, job)
+		postings = append(postings, posting)
+	}
+	return kept
+}
+
+func fillMissing(job *domain.SerpJob, from domain.SerpJob) {
+	if job.Salary == "" {
+		job.Salary = from.Salary
+	}
+	if job.Description == "" {
+		job.Description = from.Description
+	}
+	if job.PostedAt == "" {
+		job.PostedAt = from.PostedAt
+	}
+	if job.Location == "" {
+		job.Location = from.Location
+	}
+	job.Remote = job.Remote || from.Remote
+}
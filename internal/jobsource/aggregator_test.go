@@ -0,0 +1,235 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	name  string
+	jobs  []domain.SerpJob
+	err   error
+	delay time.Duration
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return f.jobs, f.err
+}
+
+func TestAggregatorSearch(t *testing.T) {
+	a := &fakeSource{name: "a", jobs: []domain.SerpJob{
+		{ExternalID: "a:1", Title: "Backend Engineer", CompanyName: "Paystack", Location: "Lagos"},
+		{ExternalID: "a:2", Title: "Platform Engineer", CompanyName: "Paystack", Location: "Lagos"},
+	}}
+	b := &fakeSource{name: "b", jobs: []domain.SerpJob{
+		{ExternalID: "b:1", Title: "Data Engineer", CompanyName: "Kuda", Location: "Lagos"},
+		// Same role as a:1, with the salary a:1 lacks.
+		{ExternalID: "b:2", Title: "Backend Engineer", CompanyName: "Paystack Inc", Location: "Lagos", Salary: "₦1M"},
+	}}
+	failing := &fakeSource{name: "down", err: errors.New("503")}
+	slow := &fakeSource{name: "slow", delay: time.Second, jobs: []domain.SerpJob{{ExternalID: "slow:1", Title: "Late"}}}
+
+	agg := NewAggregator(50*time.Millisecond, a, b, failing, slow)
+	jobs, err := agg.Search(context.Background(), Query{Title: "Engineer"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	var ids []string
+	for _, j := range jobs {
+		ids = append(ids, j.ExternalID)
+	}
+	want := []string{"a:1", "b:1", "a:2"}
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+	if jobs[0].Salary != "₦1M" {
+		t.Errorf("salary not taken from duplicate: %q", jobs[0].Salary)
+	}
+}
+
+func TestAggregatorSearch_AllFail(t *testing.T) {
+	agg := NewAggregator(time.Second,
+		&fakeSource{name: "a", err: errors.New("boom")},
+		&fakeSource{name: "b", err: errors.New("bang")},
+	)
+	_, err := agg.Search(context.Background(), Query{Title: "Engineer"})
+	if !errors.Is(err, errAllSourcesFailed) {
+		t.Fatalf("expected errAllSourcesFailed, got %v", err)
+	}
+
+	if _, err := NewAggregator(time.Second).Search(context.Background(), Query{}); err == nil {
+		t.Fatal("expected an error with no sources")
+	}
+}
+
+func TestAggregatorSearch_EmptyResultIsNotFailure(t *testing.T) {
+	agg := NewAggregator(time.Second,
+		&fakeSource{name: "a"},
+		&fakeSource{name: "b", err: errors.New("boom")},
+	)
+	jobs, err := agg.Search(context.Background(), Query{Title: "Engineer"})
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("jobs=%v err=%v", jobs, err)
+	}
+}
+
+// fakePager serves pages[token], linking each page to the next.
+type fakePager struct {
+	name  string
+	pages map[string][]domain.SerpJob
+	next  map[string]string
+	seen  []string
+}
+
+func (f *fakePager) Name() string { return f.name }
+
+func (f *fakePager) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	jobs, _, err := f.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+func (f *fakePager) SearchPage(ctx context.Context, q Query, token string) ([]domain.SerpJob, string, error) {
+	f.seen = append(f.seen, token)
+	return f.pages[token], f.next[token], nil
+}
+
+func TestAggregatorSearchPage(t *testing.T) {
+	paged := &fakePager{
+		name: "paged",
+		pages: map[string][]domain.SerpJob{
+			"":   {{ExternalID: "p:1", Title: "Backend Engineer", CompanyName: "Paystack"}},
+			"p2": {{ExternalID: "p:2", Title: "Data Engineer", CompanyName: "Kuda"}},
+		},
+		next: map[string]string{"": "p2"},
+	}
+	plain := &fakeSource{name: "plain", jobs: []domain.SerpJob{{ExternalID: "x:1", Title: "SRE", CompanyName: "Flutterwave"}}}
+	agg := NewAggregator(time.Second, paged, plain)
+
+	jobs, next, err := agg.SearchPage(context.Background(), Query{Title: "Engineer"}, "")
+	if err != nil || len(jobs) != 2 || next == "" {
+		t.Fatalf("first page: jobs=%v next=%q err=%v", jobs, next, err)
+	}
+
+	// Only the paging source has a second page to fetch.
+	jobs, next, err = agg.SearchPage(context.Background(), Query{Title: "Engineer"}, next)
+	if err != nil || len(jobs) != 1 || jobs[0].ExternalID != "p:2" || next != "" {
+		t.Fatalf("second page: jobs=%v next=%q err=%v", jobs, next, err)
+	}
+	if len(paged.seen) != 2 || paged.seen[1] != "p2" {
+		t.Errorf("tokens passed to source = %v", paged.seen)
+	}
+
+	if _, _, err := agg.SearchPage(context.Background(), Query{}, "not base64!"); err == nil {
+		t.Error("expected an error for a malformed token")
+	}
+}
+
+func TestAggregatorSearchPage_Filters(t *testing.T) {
+	now := time.Now().UTC().Format("2006-01-02")
+	src := &fakeSource{name: "a", jobs: []domain.SerpJob{
+		{ExternalID: "a:1", Title: "Backend Engineer", CompanyName: "Paystack", Location: "Remote", PostedAt: now},
+		{ExternalID: "a:2", Title: "Data Engineer", CompanyName: "Kuda", Location: "Lagos", PostedAt: now},
+		{ExternalID: "a:3", Title: "SRE", CompanyName: "Monzo", Location: "Remote", PostedAt: "2020-01-01"},
+	}}
+	jobs, _, err := NewAggregator(time.Second, src).SearchPage(context.Background(),
+		Query{RemoteOnly: true, DatePosted: domain.DatePostedWeek}, "")
+	if err != nil || len(jobs) != 1 || jobs[0].ExternalID != "a:1" {
+		t.Fatalf("jobs=%v err=%v", jobs, err)
+	}
+}
+
+// placeSource answers each location with its own jobs and records the
+// queries it was sent.
+type placeSource struct {
+	mu      sync.Mutex
+	jobs    map[string][]domain.SerpJob
+	queries []Query
+}
+
+func (p *placeSource) Name() string { return "places" }
+
+func (p *placeSource) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queries = append(p.queries, q)
+	return p.jobs[q.Location], nil
+}
+
+func TestAggregatorSearchPage_Places(t *testing.T) {
+	src := &placeSource{jobs: map[string][]domain.SerpJob{
+		"Lagos": {{ExternalID: "1", Title: "Backend Engineer", CompanyName: "Paystack", Location: "Lagos"}},
+		"Abuja": {{ExternalID: "2", Title: "Backend Engineer", CompanyName: "Kuda", Location: "Abuja"}},
+		"": {
+			{ExternalID: "3", Title: "Backend Engineer", CompanyName: "Moniepoint", Location: "Ibadan"},
+			{ExternalID: "4", Title: "Backend Engineer", CompanyName: "Andela", Location: "Nigeria", Description: "This is a fully remote role."},
+		},
+	}}
+	q := Query{Title: "Backend Engineer", Country: "ng", Locations: []string{"Lagos", "Abuja"}, IncludeRemote: true}
+
+	jobs, _, err := NewAggregator(time.Second, src).SearchPage(context.Background(), q, "")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	ids := map[string]bool{}
+	for _, j := range jobs {
+		ids[j.ExternalID] = true
+	}
+	// Ibadan is neither a wanted place nor remote.
+	if len(jobs) != 3 || !ids["1"] || !ids["2"] || !ids["4"] {
+		t.Fatalf("jobs = %+v", jobs)
+	}
+	for _, j := range jobs {
+		if j.Remote != (j.ExternalID == "4") {
+			t.Errorf("job %s remote = %v", j.ExternalID, j.Remote)
+		}
+	}
+
+	if len(src.queries) != 3 {
+		t.Fatalf("expected a search per place and one for remote roles, got %+v", src.queries)
+	}
+	for _, sent := range src.queries {
+		if sent.Country != "ng" || len(sent.Locations) != 0 || sent.IncludeRemote {
+			t.Errorf("sub-query not expanded: %+v", sent)
+		}
+	}
+}
+
+func TestAggregatorSearchPage_PlacesPageSeparately(t *testing.T) {
+	paged := &fakePager{
+		name:  "paged",
+		pages: map[string][]domain.SerpJob{"": {{ExternalID: "p:1", Title: "Backend Engineer"}}},
+		next:  map[string]string{"": "p2"},
+	}
+	agg := NewAggregator(time.Second, paged)
+	_, next, err := agg.SearchPage(context.Background(), Query{Title: "Engineer", Locations: []string{"Lagos", "Accra"}}, "")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+
+	tokens := map[string]string{}
+	if err := decodePageToken(next, &tokens); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(tokens) != 2 || tokens["paged#0"] != "p2" || tokens["paged#1"] != "p2" {
+		t.Errorf("tokens = %v", tokens)
+	}
+}
@@ -0,0 +1,215 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheStore holds search pages shared by every user.
+type CacheStore interface {
+	// Get returns ok false when key is missing or has expired.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Keyer is a Source that knows which queries it answers identically, e.g.
+// SerpApi, which sends title and experience level upstream as one phrase.
+type Keyer interface {
+	CacheKey(q Query) string
+}
+
+// cacheStats counts, per source, searches answered from the shared cache
+// (hits), fetched upstream (misses) and answered by joining an identical
+// fetch already in flight (joined). Admins read them, with the overall hit
+// ratio, from CacheStats.
+var cacheStats = expvar.NewMap("job_search_cache")
+
+func init() {
+	expvar.Publish("job_search_cache_hit_ratio", expvar.Func(func() any { return cacheHitRatio() }))
+}
+
+// CacheStats returns the cache counters, as a JSON object, and the overall
+// hit ratio.
+func CacheStats() (json.RawMessage, float64) {
+	return json.RawMessage(cacheStats.String()), cacheHitRatio()
+}
+
+func cacheHitRatio() float64 {
+	var hits, total int64
+	cacheStats.Do(func(kv expvar.KeyValue) {
+		n, ok := kv.Value.(*expvar.Int)
+		if !ok {
+			return
+		}
+		switch {
+		case strings.HasSuffix(kv.Key, ".hits"), strings.HasSuffix(kv.Key, ".joined"):
+			hits += n.Value()
+			total += n.Value()
+		case strings.HasSuffix(kv.Key, ".misses"):
+			total += n.Value()
+		}
+	})
+	if total == 0 {
+		return 0.0
+	}
+	return float64(hits) / float64(total)
+}
+
+// sharedFetchTimeout bounds an upstream fetch made on behalf of every caller
+// waiting on it; each caller still gives up at its own deadline.
+const sharedFetchTimeout = time.Minute
+
+// Cached is a Source whose pages are shared between users for ttl, so the
+// same search by many users reaches the board once. Concurrent identical
+// searches wait for a single fetch.
+type Cached struct {
+	src    Source
+	store  CacheStore
+	ttl    time.Duration
+	flight singleflight.Group
+}
+
+func NewCached(src Source, store CacheStore, ttl time.Duration) *Cached {
+	return &Cached{src: src, store: store, ttl: ttl}
+}
+
+func (c *Cached) Name() string { return c.src.Name() }
+
+func (c *Cached) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	jobs, _, err := c.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+type cachedPage struct {
+	Jobs []domain.SerpJob `json:"jobs"`
+	Next string           `json:"next,omitempty"`
+}
+
+// SearchPage serves the page shared by everyone making the same upstream
+// search. The user's own exclude terms are not sent upstream; they are
+// applied to the shared page, so they do not split the cache.
+func (c *Cached) SearchPage(ctx context.Context, q Query, token string) ([]domain.SerpJob, string, error) {
+	excluded := q.ExcludeTerms
+	q.ExcludeTerms = nil
+	key := c.key(q, token)
+
+	if page, ok := c.load(ctx, key); ok {
+		cacheStats.Add(c.Name()+".hits", 1)
+		return withoutExcluded(page.Jobs, excluded), page.Next, nil
+	}
+
+	// The fetch outlives a caller that gives up, so the others waiting on it
+	// and the next search still get its result.
+	ch := c.flight.DoChan(key, func() (any, error) {
+		cacheStats.Add(c.Name()+".misses", 1)
+		fctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedFetchTimeout)
+		defer cancel()
+		jobs, next, err := SearchPage(fctx, c.src, q, token)
+		if err != nil {
+			return nil, err
+		}
+		page := &cachedPage{Jobs: jobs, Next: next}
+		c.save(fctx, key, page)
+		return page, nil
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, "", res.Err
+		}
+		if res.Shared {
+			cacheStats.Add(c.Name()+".joined", 1)
+		}
+		page := res.Val.(*cachedPage)
+		return withoutExcluded(page.Jobs, excluded), page.Next, nil
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+}
+
+func (c *Cached) load(ctx context.Context, key string) (*cachedPage, bool) {
+	b, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		log.Printf("job search cache read failed for %s: %v", c.Name(), err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	var page cachedPage
+	if err := json.Unmarshal(b, &page); err != nil {
+		log.Printf("job search cache entry for %s is unreadable: %v", c.Name(), err)
+		return nil, false
+	}
+	return &page, true
+}
+
+func (c *Cached) save(ctx context.Context, key string, page *cachedPage) {
+	b, err := json.Marshal(page)
+	if err == nil {
+		err = c.store.Set(ctx, key, b, c.ttl)
+	}
+	if err != nil {
+		log.Printf("job search cache write failed for %s: %v", c.Name(), err)
+	}
+}
+
+func (c *Cached) key(q Query, token string) string {
+	query := defaultCacheKey(q)
+	if k, ok := c.src.(Keyer); ok {
+		query = k.CacheKey(q)
+	}
+	raw := strings.ToLower(strings.Join([]string{
+		strings.Join(strings.Fields(query), " "),
+		strings.Join(strings.Fields(q.Location), " "),
+		q.Country,
+		q.Language,
+		q.DatePosted,
+		q.EmploymentType,
+		strconv.FormatBool(q.RemoteOnly),
+		token,
+	}, "|"))
+	sum := sha1.Sum([]byte(raw))
+	return "job_search:" + c.Name() + ":" + hex.EncodeToString(sum[:])
+}
+
+func defaultCacheKey(q Query) string {
+	return q.Title + "|" + q.ExperienceLevel + "|" + strings.Join(q.RelatedTitles, ",")
+}
+
+// RedisCacheStore keeps shared search pages in Redis, which expires them.
+type RedisCacheStore struct {
+	client *redis.Client
+}
+
+func NewRedisCacheStore(client *redis.Client) *RedisCacheStore {
+	return &RedisCacheStore{client: client}
+}
+
+func (s *RedisCacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+func (s *RedisCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return s.client.Set(ctx, key, value, ttl).Err()
+}
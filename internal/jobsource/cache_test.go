@@ -0,0 +1,195 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.data[key]
+	return b, ok, nil
+}
+
+func (m *memStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		m.data = map[string][]byte{}
+	}
+	m.data[key] = value
+	return nil
+}
+
+func (m *memStore) len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.data)
+}
+
+// countingSource records how many times it was asked, blocking each call
+// until release is closed when it is set.
+type countingSource struct {
+	calls   atomic.Int32
+	release chan struct{}
+	err     error
+}
+
+func (s *countingSource) Name() string { return "counting" }
+
+func (s *countingSource) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	s.calls.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.SerpJob{{ExternalID: "counting:1", Title: q.Title}}, nil
+}
+
+func TestCachedSharesResults(t *testing.T) {
+	src := &countingSource{}
+	c := NewCached(src, &memStore{}, time.Hour)
+
+	for _, title := range []string{"Frontend Developer", "  frontend   developer", "FRONTEND DEVELOPER"} {
+		jobs, err := c.Search(context.Background(), Query{Title: title, ExperienceLevel: "junior", Location: "Lagos"})
+		if err != nil || len(jobs) != 1 {
+			t.Fatalf("jobs=%v err=%v", jobs, err)
+		}
+	}
+	if n := src.calls.Load(); n != 1 {
+		t.Fatalf("source called %d times, want 1", n)
+	}
+
+	// Other filters are another search.
+	if _, err := c.Search(context.Background(), Query{Title: "Frontend Developer", ExperienceLevel: "junior", Location: "Lagos", RemoteOnly: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n := src.calls.Load(); n != 2 {
+		t.Fatalf("source called %d times, want 2", n)
+	}
+}
+
+func TestCachedExcludeTermsShareTheSearch(t *testing.T) {
+	src := &countingSource{}
+	c := NewCached(src, &memStore{}, time.Hour)
+	q := Query{Title: "Senior Frontend Developer", Location: "Lagos"}
+
+	jobs, err := c.Search(context.Background(), q)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("jobs=%v err=%v", jobs, err)
+	}
+
+	// Another user's exclude terms are applied to the shared page.
+	q.ExcludeTerms = []string{"senior"}
+	jobs, err = c.Search(context.Background(), q)
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("jobs=%v err=%v", jobs, err)
+	}
+	q.ExcludeTerms = []string{"intern"}
+	jobs, err = c.Search(context.Background(), q)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("jobs=%v err=%v", jobs, err)
+	}
+
+	if n := src.calls.Load(); n != 1 {
+		t.Fatalf("source called %d times, want 1", n)
+	}
+}
+
+func TestCachedSingleFlight(t *testing.T) {
+	src := &countingSource{release: make(chan struct{})}
+	c := NewCached(src, &memStore{}, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Search(context.Background(), Query{Title: "Engineer"}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	// Let the callers pile up on the first fetch.
+	time.Sleep(50 * time.Millisecond)
+	close(src.release)
+	wg.Wait()
+
+	if n := src.calls.Load(); n != 1 {
+		t.Fatalf("source called %d times, want 1", n)
+	}
+}
+
+func TestCachedDoesNotCacheErrors(t *testing.T) {
+	src := &countingSource{err: errors.New("503")}
+	c := NewCached(src, &memStore{}, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Search(context.Background(), Query{Title: "Engineer"}); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+	if n := src.calls.Load(); n != 2 {
+		t.Fatalf("source called %d times, want 2", n)
+	}
+}
+
+func TestCachedCallerGivesUp(t *testing.T) {
+	src := &countingSource{release: make(chan struct{})}
+	store := &memStore{}
+	c := NewCached(src, store, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Search(ctx, Query{Title: "Engineer"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	// The fetch carries on and fills the cache for the next caller.
+	close(src.release)
+	deadline := time.Now().Add(time.Second)
+	for store.len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := c.Search(context.Background(), Query{Title: "Engineer"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := src.calls.Load(); n != 1 {
+		t.Fatalf("source called %d times, want 1", n)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	c := NewCached(&countingSource{}, &memStore{}, time.Hour)
+	for i := 0; i < 2; i++ {
+		if _, err := c.Search(context.Background(), Query{Title: "Stats Engineer"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	raw, ratio := CacheStats()
+	var counts map[string]int64
+	if err := json.Unmarshal(raw, &counts); err != nil {
+		t.Fatalf("cache stats %s: %v", raw, err)
+	}
+	if counts["counting.hits"] < 1 || counts["counting.misses"] < 1 {
+		t.Fatalf("counts = %v", counts)
+	}
+	if ratio <= 0 || ratio >= 1 {
+		t.Fatalf("hit ratio = %v", ratio)
+	}
+}
@@ -0,0 +1,96 @@
+package jobsource
+
+import (
+	"aiki/internal/pkg/jobmatch"
+	"strings"
+)
+
+// country is how listings name a country. Regions are the wider areas a
+// remote role may be open to, as in "Remote (EMEA)".
+type country struct {
+	name    string
+	aliases []string
+	regions []string
+}
+
+// countries are keyed by lower-case ISO 3166 code.
+var countries = map[string]country{
+	"ng": {name: "Nigeria", regions: []string{"africa", "emea"}},
+	"gh": {name: "Ghana", regions: []string{"africa", "emea"}},
+	"ke": {name: "Kenya", regions: []string{"africa", "emea"}},
+	"za": {name: "South Africa", regions: []string{"africa", "emea"}},
+	"eg": {name: "Egypt", regions: []string{"africa", "emea", "mena"}},
+	"ma": {name: "Morocco", regions: []string{"africa", "emea", "mena"}},
+	"rw": {name: "Rwanda", regions: []string{"africa", "emea"}},
+	"gb": {name: "United Kingdom", aliases: []string{"uk", "england", "scotland", "wales", "great britain"}, regions: []string{"europe", "emea"}},
+	"ie": {name: "Ireland", regions: []string{"europe", "emea", "eu"}},
+	"de": {name: "Germany", aliases: []string{"deutschland"}, regions: []string{"europe", "emea", "eu"}},
+	"fr": {name: "France", regions: []string{"europe", "emea", "eu"}},
+	"nl": {name: "Netherlands", aliases: []string{"holland"}, regions: []string{"europe", "emea", "eu"}},
+	"be": {name: "Belgium", regions: []string{"europe", "emea", "eu"}},
+	"es": {name: "Spain", aliases: []string{"espana"}, regions: []string{"europe", "emea", "eu"}},
+	"pt": {name: "Portugal", regions: []string{"europe", "emea", "eu"}},
+	"it": {name: "Italy", regions: []string{"europe", "emea", "eu"}},
+	"at": {name: "Austria", regions: []string{"europe", "emea", "eu"}},
+	"ch": {name: "Switzerland", regions: []string{"europe", "emea"}},
+	"pl": {name: "Poland", regions: []string{"europe", "emea", "eu"}},
+	"se": {name: "Sweden", regions: []string{"europe", "emea", "eu"}},
+	"ae": {name: "United Arab Emirates", aliases: []string{"uae", "dubai"}, regions: []string{"emea", "mena"}},
+	"in": {name: "India", regions: []string{"asia", "apac"}},
+	"sg": {name: "Singapore", regions: []string{"asia", "apac"}},
+	"ph": {name: "Philippines", regions: []string{"asia", "apac"}},
+	"au": {name: "Australia", regions: []string{"apac"}},
+	"nz": {name: "New Zealand", regions: []string{"apac"}},
+	"us": {name: "United States", aliases: []string{"usa", "us", "united states of america"}, regions: []string{"americas", "north america"}},
+	"ca": {name: "Canada", regions: []string{"americas", "north america"}},
+	"mx": {name: "Mexico", regions: []string{"americas", "latam", "latin america"}},
+	"br": {name: "Brazil", aliases: []string{"brasil"}, regions: []string{"americas", "latam", "latin america"}},
+}
+
+// CountryName returns the English name of an ISO 3166 code, or "" when it
+// is not one the sources are mapped for.
+func CountryName(code string) string {
+	return countries[strings.ToLower(code)].name
+}
+
+// inCountry reports whether location names the country with the given code,
+// e.g. "Lagos, Nigeria" for "ng". With open set, a region the country is
+// part of, such as "EMEA", also counts.
+func inCountry(location, code string, open bool) bool {
+	c, ok := countries[strings.ToLower(code)]
+	if !ok {
+		return false
+	}
+	loc := jobmatch.LocationKey(location)
+	names := append([]string{c.name}, c.aliases...)
+	if open {
+		names = append(names, c.regions...)
+	}
+	for _, name := range names {
+		if key := jobmatch.LocationKey(name); key != "" && containsPhrase(loc, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether the words of want appear together in have.
+func containsPhrase(have, want string) bool {
+	return strings.Contains(" "+have+" ", " "+want+" ")
+}
+
+// openToCountry reports whether a remote role's candidate location, such as
+// Remotive's "USA Only" or "Worldwide", lets in people from the country with
+// the given code. A role that names no place is open to all.
+func openToCountry(location, code string) bool {
+	loc := jobmatch.LocationKey(location)
+	if loc == "" || code == "" {
+		return true
+	}
+	for _, anywhere := range []string{"worldwide", "anywhere", "global", "international"} {
+		if containsPhrase(loc, anywhere) {
+			return true
+		}
+	}
+	return inCountry(location, code, true)
+}
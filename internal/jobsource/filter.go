@@ -0,0 +1,163 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/jobmatch"
+	"aiki/internal/pkg/jobtext"
+	"strings"
+	"time"
+)
+
+// datePostedDays is the oldest posting, in whole days, each date filter lets
+// through.
+var datePostedDays = map[string]int{
+	domain.DatePostedToday:     0,
+	domain.DatePostedThreeDays: 3,
+	domain.DatePostedWeek:      7,
+	domain.DatePostedMonth:     30,
+}
+
+// filterJobs drops postings that miss the date, remote and excluded term
+// filters of q, for sources that cannot filter upstream. A posting that does
+// not say when it was posted is kept.
+func filterJobs(jobs []domain.SerpJob, q Query, now time.Time) []domain.SerpJob {
+	maxDays, byDate := datePostedDays[q.DatePosted]
+	if !byDate && !q.RemoteOnly && len(q.ExcludeTerms) == 0 {
+		return jobs
+	}
+
+	kept := make([]domain.SerpJob, 0, len(jobs))
+	for _, job := range jobs {
+		if q.RemoteOnly && !job.Remote && !IsRemote(job.Location) {
+			continue
+		}
+		if hasExcludedTerm(job.Title, q.ExcludeTerms) {
+			continue
+		}
+		if byDate {
+			if age, ok := postedAgeDays(job.PostedAt, now); ok && age > maxDays {
+				continue
+			}
+		}
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+// withoutExcluded returns the jobs whose titles have none of terms, leaving
+// jobs itself, which may be shared, as it is.
+func withoutExcluded(jobs []domain.SerpJob, terms []string) []domain.SerpJob {
+	if len(terms) == 0 {
+		return jobs
+	}
+	kept := make([]domain.SerpJob, 0, len(jobs))
+	for _, job := range jobs {
+		if !hasExcludedTerm(job.Title, terms) {
+			kept = append(kept, job)
+		}
+	}
+	return kept
+}
+
+func hasExcludedTerm(title string, terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	words := " " + jobmatch.TitleKey(title) + " "
+	for _, term := range terms {
+		if strings.Contains(words, " "+strings.ToLower(term)+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRemote reports whether a location names remote work rather than a place.
+func IsRemote(location string) bool {
+	loc := " " + jobmatch.LocationKey(location) + " "
+	for _, word := range []string{" remote ", " anywhere ", " work from home ", " wfh "} {
+		if strings.Contains(loc, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// remotePhrases in a description mark a remote role; notRemotePhrases
+// override them, since "this is not a remote role" contains "remote role".
+var (
+	remotePhrases = []string{
+		"fully remote", "100% remote", "remote first", "remote-first", "remote friendly",
+		"remote-friendly", "remote role", "remote position", "remote opportunity",
+		"work from home", "work from anywhere", "work remotely", "working remotely",
+		"telecommute",
+	}
+	notRemotePhrases = []string{
+		"not remote", "not a remote", "no remote", "not fully remote", "non-remote",
+		"on-site only", "onsite only", "in-office only", "in office only", "cannot be remote",
+	}
+)
+
+// DetectRemote reports whether a listing is for a remote role, from its
+// location, its title, e.g. "Backend Engineer (Remote)", or its description.
+func DetectRemote(job domain.SerpJob) bool {
+	if IsRemote(job.Location) || containsPhrase(jobmatch.LocationKey(job.Title), "remote") {
+		return true
+	}
+	desc := strings.ToLower(job.Description)
+	for _, phrase := range notRemotePhrases {
+		if strings.Contains(desc, phrase) {
+			return false
+		}
+	}
+	for _, phrase := range remotePhrases {
+		if strings.Contains(desc, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// postedAgeDays reads how many whole days ago a posting went up, from either
+// an ISO date or text such as "3 days ago".
+func postedAgeDays(postedAt string, now time.Time) (int, bool) {
+	t, ok := jobtext.ParsePostedAt(postedAt, now)
+	if !ok {
+		return 0, false
+	}
+	day := func(t time.Time) time.Time {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	days := int(day(now).Sub(day(t)).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+	return days, true
+}
+
+// employmentKeywords are how boards spell each employment type.
+var employmentKeywords = map[string][]string{
+	domain.EmploymentFullTime:   {"full time", "fulltime", "permanent"},
+	domain.EmploymentPartTime:   {"part time", "parttime"},
+	domain.EmploymentContract:   {"contract", "contractor", "freelance", "temporary"},
+	domain.EmploymentInternship: {"intern", "internship"},
+}
+
+// matchesEmploymentType compares a board's own employment type, such as
+// Remotive's "full_time" or Lever's "Full-time", with the wanted one. An
+// unknown type matches.
+func matchesEmploymentType(have, want string) bool {
+	if want == "" || strings.TrimSpace(have) == "" {
+		return true
+	}
+	text := " " + strings.Join(strings.FieldsFunc(strings.ToLower(have), func(r rune) bool {
+		return r == '-' || r == '_' || r == ' ' || r == '/' || r == ','
+	}), " ") + " "
+	for _, kw := range employmentKeywords[want] {
+		if strings.Contains(text, " "+kw+" ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestPostedAgeDays(t *testing.T) {
+	now := time.Date(2024, 5, 20, 15, 0, 0, 0, time.UTC)
+	cases := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"2024-05-17", 3, true},
+		{"2024-05-20T09:30:00Z", 0, true},
+		{"3 days ago", 3, true},
+		{"30+ days ago", 30, true},
+		{"2 weeks ago", 14, true},
+		{"5 hours ago", 0, true},
+		{"Just posted", 0, true},
+		{"yesterday", 1, true},
+		{"", 0, false},
+		{"recently", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := postedAgeDays(c.in, now)
+		if got != c.want || ok != c.ok {
+			t.Errorf("postedAgeDays(%q) = %d, %v; want %d, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestFilterJobs_KeepsUndatedPostings(t *testing.T) {
+	now := time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)
+	jobs := []domain.SerpJob{
+		{ExternalID: "1", PostedAt: "2 days ago"},
+		{ExternalID: "2", PostedAt: "2 weeks ago"},
+		{ExternalID: "3"},
+	}
+	got := filterJobs(jobs, Query{DatePosted: domain.DatePostedThreeDays}, now)
+	if len(got) != 2 || got[0].ExternalID != "1" || got[1].ExternalID != "3" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestMatchesEmploymentType(t *testing.T) {
+	cases := []struct {
+		have, want string
+		match      bool
+	}{
+		{"full_time", domain.EmploymentFullTime, true},
+		{"Full-time", domain.EmploymentFullTime, true},
+		{"Part-time", domain.EmploymentFullTime, false},
+		{"Contractor", domain.EmploymentContract, true},
+		{"Intern", domain.EmploymentInternship, true},
+		{"", domain.EmploymentContract, true},
+		{"Full-time", "", true},
+	}
+	for _, c := range cases {
+		if got := matchesEmploymentType(c.have, c.want); got != c.match {
+			t.Errorf("matchesEmploymentType(%q, %q) = %v, want %v", c.have, c.want, got, c.match)
+		}
+	}
+}
+
+func TestFilterJobs_ExcludeTerms(t *testing.T) {
+	jobs := []domain.SerpJob{
+		{ExternalID: "1", Title: "Backend Engineer"},
+		{ExternalID: "2", Title: "Sr. Backend Engineer"},
+		{ExternalID: "3", Title: "Seniority Analyst"},
+	}
+	got := filterJobs(jobs, Query{ExcludeTerms: []string{"senior"}}, time.Now())
+	if len(got) != 2 || got[0].ExternalID != "1" || got[1].ExternalID != "3" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestDetectRemote(t *testing.T) {
+	cases := []struct {
+		job  domain.SerpJob
+		want bool
+	}{
+		{domain.SerpJob{Title: "Backend Engineer", Location: "Remote - EMEA"}, true},
+		{domain.SerpJob{Title: "Backend Engineer (Remote)", Location: "Lagos"}, true},
+		{domain.SerpJob{Title: "Backend Engineer", Location: "Lagos", Description: "We are a remote-first team."}, true},
+		{domain.SerpJob{Title: "Backend Engineer", Location: "Lagos", Description: "This is not a remote role."}, false},
+		{domain.SerpJob{Title: "Backend Engineer", Location: "Lagos", Description: "Office in Yaba."}, false},
+	}
+	for _, c := range cases {
+		if got := DetectRemote(c.job); got != c.want {
+			t.Errorf("DetectRemote(%q, %q, %q) = %v, want %v", c.job.Title, c.job.Location, c.job.Description, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const greenhouseBaseURL = "https://boards-api.greenhouse.io/v1/boards"
+
+// Greenhouse reads the public job boards of companies hiring through
+// Greenhouse. Boards list every open role, so postings are filtered against
+// the query here. No key is needed.
+type Greenhouse struct {
+	baseURL    string
+	httpClient *http.Client
+	boards     []string
+}
+
+// NewGreenhouse reads the given board tokens, the company slug in
+// boards.greenhouse.io/<token>.
+func NewGreenhouse(boards []string) *Greenhouse {
+	return &Greenhouse{baseURL: greenhouseBaseURL, httpClient: newHTTPClient(), boards: boards}
+}
+
+func (g *Greenhouse) Name() string {
+	return "greenhouse"
+}
+
+type greenhouseResponse struct {
+	Jobs []struct {
+		ID          int64  `json:"id"`
+		Title       string `json:"title"`
+		AbsoluteURL string `json:"absolute_url"`
+		CompanyName string `json:"company_name"`
+		UpdatedAt   string `json:"updated_at"`
+		Content     string `json:"content"`
+		Location    struct {
+			Name string `json:"name"`
+		} `json:"location"`
+	} `json:"jobs"`
+}
+
+func (g *Greenhouse) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	return searchBoards(ctx, g.boards, func(board string) ([]domain.SerpJob, error) {
+		return g.searchBoard(ctx, board, q)
+	})
+}
+
+func (g *Greenhouse) searchBoard(ctx context.Context, board string, q Query) ([]domain.SerpJob, error) {
+	reqURL := fmt.Sprintf("%s/%s/jobs?content=true", g.baseURL, url.PathEscape(board))
+	body, err := getJSON(ctx, g.httpClient, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("greenhouse board %s: %w", board, err)
+	}
+
+	var result greenhouseResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode greenhouse board %s: %w", board, err)
+	}
+
+	now := time.Now()
+	var jobs []domain.SerpJob
+	for _, j := range result.Jobs {
+		if !matchesQuery(j.Title, j.Location.Name, q) {
+			continue
+		}
+		company := j.CompanyName
+		if company == "" {
+			company = board
+		}
+		jobs = append(jobs, domain.SerpJob{
+			ExternalID:  externalID(g.Name(), board, strconv.FormatInt(j.ID, 10)),
+			Title:       j.Title,
+			CompanyName: company,
+			Location:    j.Location.Name,
+			// The board API returns the description as escaped HTML.
+			Description: htmlToText(html.UnescapeString(j.Content)),
+			Link:        j.AbsoluteURL,
+			Platform:    "Greenhouse",
+			PostedAt:    datePart(j.UpdatedAt),
+			FetchedAt:   now,
+		})
+	}
+	return jobs, nil
+}
+
+// searchBoards runs search for each company board. A board that fails is
+// skipped unless they all do.
+func searchBoards(ctx context.Context, boards []string, search func(board string) ([]domain.SerpJob, error)) ([]domain.SerpJob, error) {
+	var (
+		jobs []domain.SerpJob
+		errs []error
+	)
+	for _, board := range boards {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		found, err := search(board)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		jobs = append(jobs, found...)
+	}
+	if len(boards) > 0 && len(jobs) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,123 @@
+// Package jobsource fetches job postings for recommendations. Each job board
+// is a Source; Aggregator fans a search out to several of them and merges the
+// results.
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Query is what the user is looking for, taken from their profile. The
+// filters use the domain.DatePosted* and domain.Employment* values; a source
+// that cannot apply one upstream leaves it to Aggregator.
+type Query struct {
+	Title           string
+	ExperienceLevel string
+	// RelatedTitles are searched alongside Title, by sources that can.
+	RelatedTitles []string
+
+	// Location is a city or region; empty searches all of Country, or
+	// anywhere when that is empty too. Country is a lower-case ISO 3166 code
+	// such as "ng" and Language an ISO 639 code such as "en".
+	Location string
+	Country  string
+	Language string
+	// Locations, when set, are searched one by one in place of Location and
+	// the results merged; an empty entry searches all of Country.
+	// IncludeRemote adds a search for remote roles open to Country. Both are
+	// expanded by Aggregator; a single source only reads Location.
+	Locations     []string
+	IncludeRemote bool
+
+	DatePosted     string
+	EmploymentType string
+	RemoteOnly     bool
+	// ExcludeTerms are title words the user does not want, such as a
+	// seniority they keep dismissing.
+	ExcludeTerms []string
+}
+
+// Source is one place job postings come from.
+type Source interface {
+	// Name identifies the source in logs and in the external IDs of its jobs.
+	Name() string
+	Search(ctx context.Context, q Query) ([]domain.SerpJob, error)
+}
+
+// Pager is a Source that can continue a search past its first page.
+type Pager interface {
+	Source
+	// SearchPage fetches the page token points to, the first when it is
+	// empty. next is empty on the last page.
+	SearchPage(ctx context.Context, q Query, token string) (jobs []domain.SerpJob, next string, err error)
+}
+
+// SearchPage pages through src when it is a Pager. Any other source only
+// has a first page.
+func SearchPage(ctx context.Context, src Source, q Query, token string) ([]domain.SerpJob, string, error) {
+	if p, ok := src.(Pager); ok {
+		return p.SearchPage(ctx, q, token)
+	}
+	if token != "" {
+		return nil, "", nil
+	}
+	jobs, err := src.Search(ctx, q)
+	return jobs, "", err
+}
+
+const (
+	defaultHTTPTimeout = 15 * time.Second
+	maxResponseBytes   = 10 << 20
+	userAgent          = "Mozilla/5.0 (compatible; AikiJobSearch/1.0; +https://aiki.app)"
+)
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: defaultHTTPTimeout}
+}
+
+// getJSON fetches url and returns the body of a 200 response.
+func getJSON(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+}
+
+// externalID namespaces a source's own job ID so IDs from different boards
+// cannot collide in the recommendation cache.
+func externalID(source string, parts ...string) string {
+	return source + ":" + strings.Join(parts, ":")
+}
+
+// SplitList parses a comma-separated setting, dropping blanks.
+func SplitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var errAllSourcesFailed = errors.New("every job source failed")
@@ -0,0 +1,291 @@
+package jobsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	return b
+}
+
+// serveFixtures answers each path with the named fixture and anything else
+// with a 404.
+func serveFixtures(t *testing.T, fixtures map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(loadFixture(t, name))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+var backendInLagos = Query{Title: "Backend Engineer", Location: "Lagos, Nigeria"}
+
+func TestRemotiveSearch(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query().Get("search")
+		_, _ = w.Write(loadFixture(t, "remotive.json"))
+	}))
+	defer srv.Close()
+
+	r := NewRemotive()
+	r.baseURL = srv.URL
+	jobs, err := r.Search(context.Background(), backendInLagos)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if gotQuery != "Backend Engineer" {
+		t.Errorf("search param = %q", gotQuery)
+	}
+	// The marketing role does not match the title.
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d: %+v", len(jobs), jobs)
+	}
+
+	j := jobs[0]
+	if j.ExternalID != "remotive:1913408" {
+		t.Errorf("external id = %q", j.ExternalID)
+	}
+	if j.Location != "Remote (Europe, Africa)" || j.PostedAt != "2026-05-28" || j.Platform != "Remotive" {
+		t.Errorf("unexpected job: %+v", j)
+	}
+	if strings.Contains(j.Description, "<") || !strings.Contains(j.Description, "Design APIs in Go\nOwn PostgreSQL schemas") {
+		t.Errorf("description not converted to text: %q", j.Description)
+	}
+	if jobs[1].Location != "Remote" {
+		t.Errorf("blank location = %q, want Remote", jobs[1].Location)
+	}
+}
+
+func TestGreenhouseSearch(t *testing.T) {
+	srv := serveFixtures(t, map[string]string{"/flutterwave/jobs": "greenhouse.json"})
+
+	g := NewGreenhouse([]string{"flutterwave", "closed-board"})
+	g.baseURL = srv.URL
+	jobs, err := g.Search(context.Background(), backendInLagos)
+	if err != nil {
+		t.Fatalf("a failing board should be skipped: %v", err)
+	}
+	// Nairobi is outside the query and the designer role is not a match.
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d: %+v", len(jobs), jobs)
+	}
+
+	j := jobs[0]
+	if j.ExternalID != "greenhouse:flutterwave:4012345" || j.CompanyName != "Flutterwave" || j.PostedAt != "2026-05-27" {
+		t.Errorf("unexpected job: %+v", j)
+	}
+	if j.Description != "Join the core payments team.\nGo and Kafka" {
+		t.Errorf("description = %q", j.Description)
+	}
+	if jobs[1].Location != "Remote" {
+		t.Errorf("expected the remote role, got %+v", jobs[1])
+	}
+}
+
+func TestGreenhouseSearch_AllBoardsFail(t *testing.T) {
+	srv := serveFixtures(t, nil)
+
+	g := NewGreenhouse([]string{"closed-board"})
+	g.baseURL = srv.URL
+	if _, err := g.Search(context.Background(), backendInLagos); err == nil {
+		t.Fatal("expected an error when every board fails")
+	}
+}
+
+func TestLeverSearch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/kuda" || r.URL.Query().Get("mode") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(loadFixture(t, "lever.json"))
+	}))
+	defer srv.Close()
+
+	l := NewLever([]string{"kuda"})
+	l.baseURL = srv.URL
+	jobs, err := l.Search(context.Background(), backendInLagos)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d: %+v", len(jobs), jobs)
+	}
+
+	j := jobs[0]
+	if j.ExternalID != "lever:kuda:5f1c2b9e-1a2b-4c3d-8e9f-0a1b2c3d4e5f" {
+		t.Errorf("external id = %q", j.ExternalID)
+	}
+	if j.PostedAt != "2026-05-28" {
+		t.Errorf("posted at = %q", j.PostedAt)
+	}
+	if j.Salary != "NGN 800000–1200000 per-month" {
+		t.Errorf("salary = %q", j.Salary)
+	}
+}
+
+func TestAdzunaSearch(t *testing.T) {
+	var gotPath, gotAppID, gotWhere string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAppID = r.URL.Query().Get("app_id")
+		gotWhere = r.URL.Query().Get("where")
+		_, _ = w.Write(loadFixture(t, "adzuna.json"))
+	}))
+	defer srv.Close()
+
+	a := NewAdzuna("id-123", "key-456", "GB")
+	a.baseURL = srv.URL
+	jobs, err := a.Search(context.Background(), Query{Title: "Backend Engineer", Location: "London"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if gotPath != "/gb/search/1" || gotAppID != "id-123" || gotWhere != "London" {
+		t.Errorf("request path=%q app_id=%q where=%q", gotPath, gotAppID, gotWhere)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	j := jobs[0]
+	if j.Title != "Backend Engineer" {
+		t.Errorf("title highlight not stripped: %q", j.Title)
+	}
+	if j.ExternalID != "adzuna:4987654321" || j.CompanyName != "Monzo" || j.Salary != "65000–85000" || j.PostedAt != "2026-05-29" {
+		t.Errorf("unexpected job: %+v", j)
+	}
+	if jobs[1].Salary != "" {
+		t.Errorf("expected no salary, got %q", jobs[1].Salary)
+	}
+}
+
+func TestAdzunaSearch_ErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	a := NewAdzuna("bad", "bad", "gb")
+	a.baseURL = srv.URL
+	if _, err := a.Search(context.Background(), backendInLagos); err == nil {
+		t.Fatal("expected an error for a non-200 response")
+	}
+}
+
+func TestMatchesQuery(t *testing.T) {
+	cases := []struct {
+		title, location string
+		want            bool
+	}{
+		{"Senior Backend Engineer", "Lagos", true},
+		{"Sr. Backend Engineer", "Nigeria", true},
+		{"Backend Engineer", "Remote - EMEA", true},
+		{"Backend Engineer", "", true},
+		{"Backend Engineer", "Nairobi, Kenya", false},
+		{"Frontend Engineer", "Lagos", false},
+	}
+	for _, c := range cases {
+		if got := matchesQuery(c.title, c.location, backendInLagos); got != c.want {
+			t.Errorf("matchesQuery(%q, %q) = %v, want %v", c.title, c.location, got, c.want)
+		}
+	}
+}
+
+func TestMatchesQuery_RelatedTitles(t *testing.T) {
+	q := Query{Title: "Backend Engineer", RelatedTitles: []string{"Go Engineer"}}
+	if !matchesQuery("Senior Go Engineer", "", q) {
+		t.Error("expected a related title to match")
+	}
+	if matchesQuery("Frontend Engineer", "", q) {
+		t.Error("expected an unrelated title not to match")
+	}
+}
+
+func TestMatchesQuery_Country(t *testing.T) {
+	q := Query{Title: "Backend Engineer", Country: "ng"}
+	cases := []struct {
+		location string
+		want     bool
+	}{
+		{"Lagos, Nigeria", true},
+		{"Remote", true},
+		{"", true},
+		{"London, UK", false},
+	}
+	for _, c := range cases {
+		if got := matchesQuery("Backend Engineer", c.location, q); got != c.want {
+			t.Errorf("matchesQuery(%q) = %v, want %v", c.location, got, c.want)
+		}
+	}
+}
+
+func TestOpenToCountry(t *testing.T) {
+	cases := []struct {
+		location, country string
+		want              bool
+	}{
+		{"Worldwide", "ng", true},
+		{"", "ng", true},
+		{"EMEA", "ng", true},
+		{"Nigeria, Kenya", "ng", true},
+		{"USA Only", "ng", false},
+		{"USA Only", "us", true},
+		{"UK", "gb", true},
+		{"Europe", "", true},
+	}
+	for _, c := range cases {
+		if got := openToCountry(c.location, c.country); got != c.want {
+			t.Errorf("openToCountry(%q, %q) = %v, want %v", c.location, c.country, got, c.want)
+		}
+	}
+}
+
+func TestAdzunaSearch_Country(t *testing.T) {
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write(loadFixture(t, "adzuna.json"))
+	}))
+	defer srv.Close()
+
+	a := NewAdzuna("id", "key", "gb")
+	a.baseURL = srv.URL
+	if _, err := a.Search(context.Background(), Query{Title: "Backend Engineer", Country: "za"}); err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if gotPath != "/za/search/1" {
+		t.Errorf("path = %q, want the query's country", gotPath)
+	}
+
+	gotPath = ""
+	jobs, err := a.Search(context.Background(), Query{Title: "Backend Engineer", Country: "ng"})
+	if err != nil || len(jobs) != 0 || gotPath != "" {
+		t.Errorf("uncovered country: jobs=%v err=%v path=%q", jobs, err, gotPath)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := SplitList(" stripe, ,figma,")
+	if len(got) != 2 || got[0] != "stripe" || got[1] != "figma" {
+		t.Errorf("SplitList = %q", got)
+	}
+}
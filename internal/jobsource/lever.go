@@ -0,0 +1,101 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const leverBaseURL = "https://api.lever.co/v0/postings"
+
+// Lever reads the public postings of companies hiring through Lever. Like
+// Greenhouse, a company's feed lists every open role and is filtered here.
+// No key is needed.
+type Lever struct {
+	baseURL    string
+	httpClient *http.Client
+	companies  []string
+}
+
+// NewLever reads the given companies, the slug in jobs.lever.co/<company>.
+func NewLever(companies []string) *Lever {
+	return &Lever{baseURL: leverBaseURL, httpClient: newHTTPClient(), companies: companies}
+}
+
+func (l *Lever) Name() string {
+	return "lever"
+}
+
+type leverPosting struct {
+	ID               string `json:"id"`
+	Text             string `json:"text"`
+	HostedURL        string `json:"hostedUrl"`
+	CreatedAt        int64  `json:"createdAt"` // Unix milliseconds
+	DescriptionPlain string `json:"descriptionPlain"`
+	Categories       struct {
+		Location   string `json:"location"`
+		Commitment string `json:"commitment"`
+		Team       string `json:"team"`
+	} `json:"categories"`
+	SalaryRange *struct {
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+	} `json:"salaryRange"`
+}
+
+func (l *Lever) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	return searchBoards(ctx, l.companies, func(company string) ([]domain.SerpJob, error) {
+		return l.searchCompany(ctx, company, q)
+	})
+}
+
+func (l *Lever) searchCompany(ctx context.Context, company string, q Query) ([]domain.SerpJob, error) {
+	reqURL := fmt.Sprintf("%s/%s?mode=json", l.baseURL, url.PathEscape(company))
+	body, err := getJSON(ctx, l.httpClient, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("lever company %s: %w", company, err)
+	}
+
+	var postings []leverPosting
+	if err := json.Unmarshal(body, &postings); err != nil {
+		return nil, fmt.Errorf("failed to decode lever postings for %s: %w", company, err)
+	}
+
+	now := time.Now()
+	var jobs []domain.SerpJob
+	for _, p := range postings {
+		if !matchesQuery(p.Text, p.Categories.Location, q) || !matchesEmploymentType(p.Categories.Commitment, q.EmploymentType) {
+			continue
+		}
+		var postedAt string
+		if p.CreatedAt > 0 {
+			postedAt = time.UnixMilli(p.CreatedAt).UTC().Format("2006-01-02")
+		}
+		var salary string
+		if p.SalaryRange != nil && p.SalaryRange.Max > 0 {
+			salary = fmt.Sprintf("%s %.0f–%.0f", p.SalaryRange.Currency, p.SalaryRange.Min, p.SalaryRange.Max)
+			if p.SalaryRange.Interval != "" {
+				salary += " " + p.SalaryRange.Interval
+			}
+		}
+		jobs = append(jobs, domain.SerpJob{
+			ExternalID:  externalID(l.Name(), company, p.ID),
+			Title:       p.Text,
+			CompanyName: company,
+			Location:    p.Categories.Location,
+			Description: p.DescriptionPlain,
+			Link:        p.HostedURL,
+			Platform:    "Lever",
+			PostedAt:    postedAt,
+			Salary:      salary,
+			FetchedAt:   now,
+		})
+	}
+	return jobs, nil
+}
@@ -0,0 +1,211 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrQuotaExhausted is returned by a Metered source once its monthly budget
+// is used up.
+var ErrQuotaExhausted = errors.New("job source monthly budget is used up")
+
+// Counter keeps counts that expire.
+type Counter interface {
+	// Incr adds one to key, keeping it for at least ttl, and returns the
+	// new count.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	// Decr takes one from key, undoing an Incr.
+	Decr(ctx context.Context, key string) error
+	// Get returns zero for a key that is missing or has expired.
+	Get(ctx context.Context, key string) (int64, error)
+}
+
+// QuotaManager counts upstream calls against each paid source's monthly plan
+// and limits how often a user may start a new search. Fetching from a source
+// stops at a threshold percentage of its plan, leaving headroom for calls
+// already in flight.
+type QuotaManager struct {
+	counter          Counter
+	plans            map[string]int64
+	thresholdPercent int64
+	refreshLimit     int64
+	refreshWindow    time.Duration
+	now              func() time.Time
+}
+
+// NewQuotaManager tracks the sources in plans, which maps a source name to
+// its monthly call allowance. A user may start refreshLimit new searches per
+// refreshWindow; a limit of zero turns the check off.
+func NewQuotaManager(counter Counter, plans map[string]int64, thresholdPercent, refreshLimit int64, refreshWindow time.Duration) *QuotaManager {
+	if thresholdPercent <= 0 || thresholdPercent > 100 {
+		thresholdPercent = 100
+	}
+	return &QuotaManager{
+		counter:          counter,
+		plans:            plans,
+		thresholdPercent: thresholdPercent,
+		refreshLimit:     refreshLimit,
+		refreshWindow:    refreshWindow,
+		now:              time.Now,
+	}
+}
+
+// Spend records one upstream call to source, or returns ErrQuotaExhausted
+// when its budget for the month is gone. Sources without a plan are counted
+// but never refused.
+//
+// The call is counted before the budget is checked, so that concurrent
+// callers, on this replica or another, cannot all pass the check; a refused
+// call is taken off the count again.
+func (m *QuotaManager) Spend(ctx context.Context, source string) error {
+	month := m.now().UTC()
+	key := quotaKey(source, month)
+	// Kept past the end of the month so the admin report can still show it.
+	used, err := m.counter.Incr(ctx, key, 62*24*time.Hour)
+	if err != nil {
+		return fmt.Errorf("count %s call: %w", source, err)
+	}
+	if budget, ok := m.budget(source); ok && used > budget {
+		if err := m.counter.Decr(ctx, key); err != nil {
+			return fmt.Errorf("uncount %s call: %w", source, err)
+		}
+		return ErrQuotaExhausted
+	}
+	return nil
+}
+
+// AllowRefresh counts a new search started by the user and returns
+// domain.ErrSearchRateLimited once they have started too many this window.
+func (m *QuotaManager) AllowRefresh(ctx context.Context, userID int32) error {
+	if m.refreshLimit <= 0 || m.refreshWindow <= 0 {
+		return nil
+	}
+	bucket := m.now().UTC().Truncate(m.refreshWindow).Unix()
+	key := "search_refresh:" + strconv.Itoa(int(userID)) + ":" + strconv.FormatInt(bucket, 10)
+	n, err := m.counter.Incr(ctx, key, m.refreshWindow)
+	if err != nil {
+		return fmt.Errorf("count search refresh: %w", err)
+	}
+	if n > m.refreshLimit {
+		return domain.ErrSearchRateLimited
+	}
+	return nil
+}
+
+// Usage reports this month's calls to every tracked source.
+func (m *QuotaManager) Usage(ctx context.Context) ([]domain.SearchQuotaUsage, error) {
+	now := m.now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usage := make([]domain.SearchQuotaUsage, 0, len(m.plans))
+	for source, plan := range m.plans {
+		used, err := m.counter.Get(ctx, quotaKey(source, now))
+		if err != nil {
+			return nil, fmt.Errorf("read %s quota: %w", source, err)
+		}
+		u := domain.SearchQuotaUsage{
+			Source:   source,
+			Month:    now.Format("2006-01"),
+			Used:     used,
+			Plan:     plan,
+			ResetsAt: monthStart.AddDate(0, 1, 0),
+		}
+		// Without a plan the calls are only counted.
+		if budget, ok := m.budget(source); ok {
+			u.Budget = budget
+			u.Remaining = max(budget-used, 0)
+			u.PercentUsed = float64(used) * 100 / float64(plan)
+			u.Exhausted = used >= budget
+		}
+		usage = append(usage, u)
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Source < usage[j].Source })
+	return usage, nil
+}
+
+func (m *QuotaManager) budget(source string) (int64, bool) {
+	plan, ok := m.plans[source]
+	if !ok || plan <= 0 {
+		return 0, false
+	}
+	return plan * m.thresholdPercent / 100, true
+}
+
+func quotaKey(source string, t time.Time) string {
+	return "search_quota:" + source + ":" + t.Format("2006-01")
+}
+
+// Metered is a Source whose upstream calls are counted by a QuotaManager,
+// and refused once its monthly budget is used up.
+type Metered struct {
+	src   Source
+	quota *QuotaManager
+}
+
+func NewMetered(src Source, quota *QuotaManager) *Metered {
+	return &Metered{src: src, quota: quota}
+}
+
+func (m *Metered) Name() string { return m.src.Name() }
+
+func (m *Metered) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	jobs, _, err := m.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+func (m *Metered) SearchPage(ctx context.Context, q Query, token string) ([]domain.SerpJob, string, error) {
+	if err := m.quota.Spend(ctx, m.src.Name()); err != nil {
+		return nil, "", err
+	}
+	return SearchPage(ctx, m.src, q, token)
+}
+
+// CacheKey keeps the wrapped source's cache key, so wrapping does not split
+// the shared cache.
+func (m *Metered) CacheKey(q Query) string {
+	if k, ok := m.src.(Keyer); ok {
+		return k.CacheKey(q)
+	}
+	return defaultCacheKey(q)
+}
+
+// RedisCounter keeps counts in Redis.
+type RedisCounter struct {
+	client *redis.Client
+}
+
+func NewRedisCounter(client *redis.Client) *RedisCounter {
+	return &RedisCounter{client: client}
+}
+
+func (c *RedisCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (c *RedisCounter) Decr(ctx context.Context, key string) error {
+	return c.client.Decr(ctx, key).Err()
+}
+
+func (c *RedisCounter) Get(ctx context.Context, key string) (int64, error) {
+	n, err := c.client.Get(ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return n, err
+}
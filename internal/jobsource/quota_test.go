@@ -0,0 +1,154 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memCounter struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func (m *memCounter) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = map[string]int64{}
+	}
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+func (m *memCounter) Decr(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[key]--
+	return nil
+}
+
+func (m *memCounter) Get(ctx context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	n := m.counts[key]
+	m.mu.Unlock()
+	// Let other callers run between a read and whatever follows it, as a
+	// round trip to Redis would.
+	runtime.Gosched()
+	return n, nil
+}
+
+func TestMeteredStopsAtBudget(t *testing.T) {
+	quota := NewQuotaManager(&memCounter{}, map[string]int64{"counting": 10}, 50, 0, 0)
+	src := &countingSource{}
+	metered := NewMetered(src, quota)
+
+	for i := 0; i < 5; i++ {
+		if _, err := metered.Search(context.Background(), Query{Title: "Engineer"}); err != nil {
+			t.Fatalf("call %d: %v", i+1, err)
+		}
+	}
+	// 50% of a plan of 10 is 5 calls.
+	if _, err := metered.Search(context.Background(), Query{Title: "Engineer"}); !errors.Is(err, ErrQuotaExhausted) {
+		t.Fatalf("expected ErrQuotaExhausted, got %v", err)
+	}
+	if n := src.calls.Load(); n != 5 {
+		t.Fatalf("source called %d times, want 5", n)
+	}
+
+	usage, err := quota.Usage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 1 || usage[0].Used != 5 || usage[0].Budget != 5 || !usage[0].Exhausted || usage[0].PercentUsed != 50 {
+		t.Fatalf("usage = %+v", usage)
+	}
+}
+
+func TestSpendConcurrentlyStaysWithinBudget(t *testing.T) {
+	quota := NewQuotaManager(&memCounter{}, map[string]int64{"serpapi": 5}, 100, 0, 0)
+
+	var (
+		wg      sync.WaitGroup
+		start   = make(chan struct{})
+		mu      sync.Mutex
+		spent   int
+		refused int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := quota.Spend(context.Background(), "serpapi")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				spent++
+			case errors.Is(err, ErrQuotaExhausted):
+				refused++
+			default:
+				t.Error(err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if spent != 5 || refused != 45 {
+		t.Fatalf("spent %d and refused %d calls, want 5 and 45", spent, refused)
+	}
+	usage, err := quota.Usage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Refused calls are not counted.
+	if usage[0].Used != 5 {
+		t.Fatalf("usage = %+v", usage)
+	}
+}
+
+func TestQuotaWithoutPlanOnlyCounts(t *testing.T) {
+	quota := NewQuotaManager(&memCounter{}, map[string]int64{"serpapi": 0}, 90, 0, 0)
+	for i := 0; i < 3; i++ {
+		if err := quota.Spend(context.Background(), "serpapi"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	usage, err := quota.Usage(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage[0].Used != 3 || usage[0].Exhausted || usage[0].PercentUsed != 0 {
+		t.Fatalf("usage = %+v", usage)
+	}
+}
+
+func TestAllowRefresh(t *testing.T) {
+	now := time.Date(2024, 5, 20, 10, 15, 0, 0, time.UTC)
+	quota := NewQuotaManager(&memCounter{}, nil, 100, 2, time.Hour)
+	quota.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := quota.AllowRefresh(context.Background(), 7); err != nil {
+			t.Fatalf("refresh %d: %v", i+1, err)
+		}
+	}
+	if err := quota.AllowRefresh(context.Background(), 7); !errors.Is(err, domain.ErrSearchRateLimited) {
+		t.Fatalf("expected ErrSearchRateLimited, got %v", err)
+	}
+	// Other users have their own allowance.
+	if err := quota.AllowRefresh(context.Background(), 8); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(time.Hour)
+	if err := quota.AllowRefresh(context.Background(), 7); err != nil {
+		t.Fatalf("next window: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package jobsource
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const remotiveBaseURL = "https://remotive.com/api/remote-jobs"
+
+// Remotive searches remotive.com, a board of remote jobs. It needs no key.
+// With a country wanted, only roles open to candidates there are kept.
+type Remotive struct {
+	baseURL    string
+	httpClient *http.Client
+	limit      int
+}
+
+func NewRemotive() *Remotive {
+	return &Remotive{baseURL: remotiveBaseURL, httpClient: newHTTPClient(), limit: 30}
+}
+
+func (r *Remotive) Name() string {
+	return "remotive"
+}
+
+type remotiveResponse struct {
+	Jobs []struct {
+		ID                        int64  `json:"id"`
+		URL                       string `json:"url"`
+		Title                     string `json:"title"`
+		CompanyName               string `json:"company_name"`
+		JobType                   string `json:"job_type"`
+		PublicationDate           string `json:"publication_date"`
+		CandidateRequiredLocation string `json:"candidate_required_location"`
+		Salary                    string `json:"salary"`
+		Description               string `json:"description"`
+	} `json:"jobs"`
+}
+
+func (r *Remotive) Search(ctx context.Context, q Query) ([]domain.SerpJob, error) {
+	params := url.Values{}
+	params.Set("search", q.Title)
+	params.Set("limit", strconv.Itoa(r.limit))
+
+	body, err := getJSON(ctx, r.httpClient, r.baseURL+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("remotive request failed: %w", err)
+	}
+
+	var result remotiveResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode remotive response: %w", err)
+	}
+
+	now := time.Now()
+	jobs := make([]domain.SerpJob, 0, len(result.Jobs))
+	for _, j := range result.Jobs {
+		location := "Remote"
+		if j.CandidateRequiredLocation != "" {
+			location = "Remote (" + j.CandidateRequiredLocation + ")"
+		}
+		if !matchesQuery(j.Title, location, q) || !matchesEmploymentType(j.JobType, q.EmploymentType) {
+			continue
+		}
+		if !openToCountry(j.CandidateRequiredLocation, q.Country) {
+			continue
+		}
+		jobs = append(jobs, domain.SerpJob{
+			ExternalID:  externalID(r.Name(), strconv.FormatInt(j.ID, 10)),
+			Title:       j.Title,
+			CompanyName: j.CompanyName,
+			Location:    location,
+			Description: htmlToText(j.Description),
+			Link:        j.URL,
+			Platform:    "Remotive",
+			PostedAt:    datePart(j.PublicationDate),
+			Salary:      j.Salary,
+			Remote:      true,
+			FetchedAt:   now,
+		})
+	}
+	return jobs, nil
+}
+
+// datePart keeps the YYYY-MM-DD of an ISO 8601 timestamp.
+func datePart(ts string) string {
+	if len(ts) >= len("2006-01-02") {
+		return ts[:len("2006-01-02")]
+	}
+	return ts
+}
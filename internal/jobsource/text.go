@@ -0,0 +1,97 @@
+package jobsource
+
+import (
+	"aiki/internal/pkg/jobmatch"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockTags end a line when converting HTML descriptions to text.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "li": true, "ul": true, "ol": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "tr": true,
+}
+
+// htmlToText flattens an HTML job description into plain text with one line
+// per block, since the cache and the tracker store descriptions as text.
+func htmlToText(s string) string {
+	if !strings.Contains(s, "<") {
+		return strings.TrimSpace(s)
+	}
+
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(s))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return tidyLines(b.String())
+		case html.TextToken:
+			b.Write(z.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			if blockTags[string(name)] {
+				b.WriteByte('\n')
+			}
+		}
+	}
+}
+
+func tidyLines(s string) string {
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// matchesQuery filters boards that return every open role rather than search
+// results. Every word of the wanted title, or of one of the related titles,
+// must appear in the job title, and when a location is wanted the job must be
+// in it or remote. With only a country wanted, the job must be in that
+// country or remote.
+func matchesQuery(title, location string, q Query) bool {
+	if !matchesTitle(jobmatch.TitleKey(title), q) {
+		return false
+	}
+
+	loc := jobmatch.LocationKey(location)
+	if loc == "" || IsRemote(location) {
+		return true
+	}
+	if strings.TrimSpace(q.Location) == "" {
+		return q.Country == "" || CountryName(q.Country) == "" || inCountry(location, q.Country, false)
+	}
+	// "Lagos, Nigeria" matches a job in "Lagos" or one in "Nigeria".
+	for _, part := range strings.Split(q.Location, ",") {
+		if key := jobmatch.LocationKey(part); key != "" && containsWords(loc, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTitle(key string, q Query) bool {
+	for _, want := range append([]string{q.Title}, q.RelatedTitles...) {
+		if containsWords(key, jobmatch.TitleKey(want)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWords reports whether every word of want appears in have.
+func containsWords(have, want string) bool {
+	words := map[string]bool{}
+	for _, w := range strings.Fields(have) {
+		words[w] = true
+	}
+	for _, w := range strings.Fields(want) {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"strings"
+
+	"aiki/internal/domain"
+	"aiki/internal/pkg/response"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Admin lets through only the users whose email is in emails. It must run
+// after Auth, which puts the email in the context.
+func Admin(emails []string) echo.MiddlewareFunc {
+	allowed := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		allowed[strings.ToLower(strings.TrimSpace(email))] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			email, ok := c.Get("user_email").(string)
+			if !ok || !allowed[strings.ToLower(email)] {
+				return response.Error(c, domain.ErrForbidden)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminMiddleware(t *testing.T) {
+	e := echo.New()
+	admin := Admin([]string{"Ops@Aiki.app", " lead@aiki.app"})
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	}
+
+	tests := []struct {
+		name  string
+		email any
+		want  int
+	}{
+		{"listed email", "ops@aiki.app", http.StatusOK},
+		{"listed email trimmed", "lead@aiki.app", http.StatusOK},
+		{"other user", "someone@example.com", http.StatusForbidden},
+		{"no email in context", nil, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.email != nil {
+				c.Set("user_email", tt.email)
+			}
+
+			_ = admin(handler)(c)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
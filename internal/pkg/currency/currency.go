@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rates maps ISO 4217 codes to the value of one unit expressed in a common
+// reference currency. Only the ratios matter, so any reference works as long
+// as the whole table uses the same one.
+type Rates map[string]float64
+
+// DefaultRates is a rough USD-referenced table used when no rates are
+// configured. Override or extend it with OFFER_EXCHANGE_RATES.
+func DefaultRates() Rates {
+	return Rates{
+		"USD": 1,
+		"EUR": 1.08,
+		"GBP": 1.27,
+		"CAD": 0.73,
+		"AUD": 0.66,
+		"CHF": 1.12,
+		"JPY": 0.0067,
+		"INR": 0.012,
+		"NGN": 0.00065,
+		"GHS": 0.064,
+		"KES": 0.0077,
+		"ZAR": 0.054,
+		"EGP": 0.02,
+	}
+}
+
+// ParseRates reads a "CODE=rate" list separated by commas, e.g.
+// "EUR=1.08,GBP=1.27,NGN=0.00065". Codes are upper-cased.
+func ParseRates(spec string) (Rates, error) {
+	rates := Rates{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("exchange rate %q: expected CODE=rate", pair)
+		}
+		code = Normalize(code)
+		if len(code) != 3 {
+			return nil, fmt.Errorf("exchange rate %q: currency code must have 3 letters", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("exchange rate %q: rate must be a positive number", pair)
+		}
+		rates[code] = rate
+	}
+	return rates, nil
+}
+
+// Merge returns a copy of r with every rate in other added or replaced.
+func (r Rates) Merge(other Rates) Rates {
+	out := make(Rates, len(r)+len(other))
+	for code, rate := range r {
+		out[code] = rate
+	}
+	for code, rate := range other {
+		out[code] = rate
+	}
+	return out
+}
+
+// Supports reports whether the table has a rate for code.
+func (r Rates) Supports(code string) bool {
+	_, ok := r[Normalize(code)]
+	return ok
+}
+
+// Convert turns amount in from into to. ok is false when either currency is
+// missing from the table.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return 0, false
+	}
+	return amount * fromRate / toRate, true
+}
+
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,53 @@
+package currency
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseRates(t *testing.T) {
+	rates, err := ParseRates(" eur=1.10, GBP=1.25 ,,")
+	if err != nil {
+		t.Fatalf("ParseRates returned error: %v", err)
+	}
+	if rates["EUR"] != 1.10 || rates["GBP"] != 1.25 || len(rates) != 2 {
+		t.Errorf("ParseRates() = %v", rates)
+	}
+
+	for _, bad := range []string{"EUR", "EURO=1", "EUR=abc", "EUR=0", "EUR=-2"} {
+		if _, err := ParseRates(bad); err == nil {
+			t.Errorf("ParseRates(%q) should fail", bad)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	rates := Rates{"USD": 1, "EUR": 1.1, "NGN": 0.0005}
+
+	cases := []struct {
+		amount   float64
+		from, to string
+		want     float64
+		ok       bool
+	}{
+		{100, "EUR", "USD", 110, true},
+		{110, "usd", "eur", 100, true},
+		{2_000_000, "NGN", "USD", 1000, true},
+		{50, "GBP", "GBP", 50, true},
+		{50, "GBP", "USD", 0, false},
+		{50, "USD", "JPY", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := rates.Convert(tc.amount, tc.from, tc.to)
+		if ok != tc.ok || math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("Convert(%v, %s, %s) = %v, %v; want %v, %v", tc.amount, tc.from, tc.to, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Rates{"USD": 1, "EUR": 1.08}.Merge(Rates{"EUR": 1.2, "XOF": 0.0016})
+	if merged["USD"] != 1 || merged["EUR"] != 1.2 || merged["XOF"] != 0.0016 {
+		t.Errorf("Merge() = %v", merged)
+	}
+}
@@ -0,0 +1,173 @@
+// Package jobmatch normalises job postings so the same role saved twice, from
+// recommendations and by hand, can be recognised as a duplicate.
+package jobmatch
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Reasons a pair of jobs is considered a duplicate.
+const (
+	ReasonSameLink           = "same_link"
+	ReasonSameRole           = "same_company_title_location"
+	ReasonSameRoleNoLocation = "same_company_title"
+)
+
+// Posting is the part of a job the matcher looks at.
+type Posting struct {
+	Title       string
+	CompanyName string
+	Location    string
+	Link        string
+}
+
+// Match reports why two postings look like the same role, or "" when they do
+// not. A shared canonical link is conclusive; otherwise company and title must
+// both match, and the location must match when both jobs have one.
+func Match(a, b Posting) string {
+	if la, lb := CanonicalLink(a.Link), CanonicalLink(b.Link); la != "" && la == lb {
+		return ReasonSameLink
+	}
+
+	ca, cb := CompanyKey(a.CompanyName), CompanyKey(b.CompanyName)
+	ta, tb := TitleKey(a.Title), TitleKey(b.Title)
+	if ca == "" || ta == "" || ca != cb || ta != tb {
+		return ""
+	}
+
+	locA, locB := LocationKey(a.Location), LocationKey(b.Location)
+	switch {
+	case locA == "" || locB == "":
+		return ReasonSameRoleNoLocation
+	case locA == locB:
+		return ReasonSameRole
+	default:
+		return ""
+	}
+}
+
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true, "plc": true,
+	"gmbh": true, "ag": true, "sa": true, "srl": true, "bv": true, "nv": true,
+	"pty": true, "pvt": true, "lp": true, "llp": true,
+}
+
+// CompanyKey lower-cases the name, drops punctuation and legal suffixes such
+// as "Inc." or "GmbH".
+func CompanyKey(name string) string {
+	words := tokens(name)
+	for len(words) > 1 && companySuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+var titleAbbreviations = map[string]string{
+	"sr":    "senior",
+	"snr":   "senior",
+	"jr":    "junior",
+	"jnr":   "junior",
+	"eng":   "engineer",
+	"engr":  "engineer",
+	"dev":   "developer",
+	"mgr":   "manager",
+	"mngr":  "manager",
+	"assoc": "associate",
+	"swe":   "software engineer",
+	"sde":   "software engineer",
+	"ii":    "2",
+	"iii":   "3",
+	"iv":    "4",
+}
+
+var parenthetical = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\]`)
+
+// TitleKey lower-cases the title, drops bracketed asides such as "(Remote)"
+// and expands common abbreviations.
+func TitleKey(title string) string {
+	title = parenthetical.ReplaceAllString(title, " ")
+	words := tokens(title)
+	for i, w := range words {
+		if full, ok := titleAbbreviations[w]; ok {
+			words[i] = full
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// LocationKey lower-cases the location and drops punctuation, so "Lagos,
+// Nigeria" and "lagos nigeria" compare equal.
+func LocationKey(location string) string {
+	return strings.Join(tokens(location), " ")
+}
+
+// trackingParams are query parameters that identify the visitor or campaign
+// rather than the posting.
+var trackingParams = map[string]bool{
+	"ref": true, "refid": true, "referer": true, "referrer": true, "src": true,
+	"source": true, "trk": true, "trkinfo": true, "trackingid": true, "from": true,
+	"gh_src": true, "lever-source": true, "lever-origin": true, "utm": true,
+	"fbclid": true, "gclid": true, "mc_cid": true, "mc_eid": true, "sid": true,
+	"currentjobid": true, "position": true, "pagenum": true, "origin": true,
+	"alternatechannel": true, "eba": true, "ebp": true, "tk": true,
+}
+
+var linkedInJobPath = regexp.MustCompile(`^/(?:comm/)?jobs/view/(?:[^/]*-)?(\d+)`)
+
+// CanonicalLink reduces an apply link to the part that identifies the
+// posting: host without "www.", path without a trailing slash and only the
+// query parameters that are not tracking noise, sorted. It returns "" for
+// anything that is not an http(s) URL.
+func CanonicalLink(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.TrimRight(u.EscapedPath(), "/")
+
+	// LinkedIn serves the same posting under /jobs/view/<id>, /jobs/view/<slug>-<id>
+	// and /comm/jobs/view/<id>.
+	if strings.HasSuffix(host, "linkedin.com") {
+		if m := linkedInJobPath.FindStringSubmatch(path); m != nil {
+			return "linkedin.com/jobs/view/" + m[1]
+		}
+		host = "linkedin.com"
+	}
+
+	var kept []string
+	for key, values := range u.Query() {
+		k := strings.ToLower(key)
+		if trackingParams[k] || strings.HasPrefix(k, "utm_") {
+			continue
+		}
+		for _, v := range values {
+			kept = append(kept, k+"="+v)
+		}
+	}
+	sort.Strings(kept)
+
+	link := host + path
+	if len(kept) > 0 {
+		link += "?" + strings.Join(kept, "&")
+	}
+	return link
+}
+
+// tokens splits s into lower-case words of letters and digits. "&" becomes
+// "and" so "AT&T" and "AT and T" give the same words.
+func tokens(s string) []string {
+	s = strings.ToLower(strings.ReplaceAll(s, "&", " and "))
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
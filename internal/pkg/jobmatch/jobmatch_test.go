@@ -0,0 +1,66 @@
+package jobmatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalLink(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://www.linkedin.com/jobs/view/3901234567/?refId=abc&trackingId=xyz", "linkedin.com/jobs/view/3901234567"},
+		{"https://ng.linkedin.com/jobs/view/backend-engineer-at-paystack-3901234567", "linkedin.com/jobs/view/3901234567"},
+		{"http://linkedin.com/comm/jobs/view/3901234567", "linkedin.com/jobs/view/3901234567"},
+		{"https://boards.greenhouse.io/acme/jobs/123?gh_src=abc&utm_source=google", "boards.greenhouse.io/acme/jobs/123"},
+		{"https://ng.indeed.com/viewjob?from=serp&jk=abc123&vjs=3", "ng.indeed.com/viewjob?jk=abc123&vjs=3"},
+		{"https://jobs.lever.co/acme/5f1c/#apply", "jobs.lever.co/acme/5f1c"},
+		{"not a url", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CanonicalLink(tt.raw), tt.raw)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	assert.Equal(t, "paystack", CompanyKey("Paystack, Inc."))
+	assert.Equal(t, "acme", CompanyKey("The ACME Corp"))
+	assert.Equal(t, "at and t", CompanyKey("AT&T"))
+	// A name made only of a suffix word is kept.
+	assert.Equal(t, "company", CompanyKey("Company"))
+
+	assert.Equal(t, "senior software engineer", TitleKey("Sr. Software Eng (Remote)"))
+	assert.Equal(t, "backend developer 2", TitleKey("Backend Dev II"))
+
+	assert.Equal(t, "lagos nigeria", LocationKey("Lagos, Nigeria"))
+}
+
+func TestMatch(t *testing.T) {
+	manual := Posting{Title: "Senior Backend Engineer", CompanyName: "Paystack", Location: "Lagos, Nigeria"}
+
+	tests := []struct {
+		name  string
+		other Posting
+		want  string
+	}{
+		{"same role", Posting{Title: "Sr Backend Engineer", CompanyName: "Paystack Inc", Location: "Lagos Nigeria"}, ReasonSameRole},
+		{"no location on one side", Posting{Title: "Senior Backend Engineer", CompanyName: "paystack"}, ReasonSameRoleNoLocation},
+		{"different city", Posting{Title: "Senior Backend Engineer", CompanyName: "Paystack", Location: "Nairobi"}, ""},
+		{"different title", Posting{Title: "Backend Engineer", CompanyName: "Paystack", Location: "Lagos"}, ""},
+		{"different company", Posting{Title: "Senior Backend Engineer", CompanyName: "Flutterwave", Location: "Lagos, Nigeria"}, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Match(manual, tt.other), tt.name)
+	}
+
+	a := Posting{Title: "Engineer", Link: "https://www.linkedin.com/jobs/view/1?trk=x"}
+	b := Posting{Title: "Something else entirely", Link: "https://linkedin.com/jobs/view/1"}
+	assert.Equal(t, ReasonSameLink, Match(a, b))
+
+	// Postings without a company never match on title alone.
+	assert.Equal(t, "", Match(Posting{Title: "Engineer"}, Posting{Title: "Engineer"}))
+}
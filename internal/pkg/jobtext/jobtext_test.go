@@ -0,0 +1,73 @@
+package jobtext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSalary(t *testing.T) {
+	tests := []struct {
+		text string
+		want Salary
+	}{
+		{"₦300K–₦450K a month", Salary{300_000, 450_000, "NGN", PeriodMonth, 3_600_000, 5_400_000}},
+		{"$90k - $120k", Salary{90_000, 120_000, "USD", PeriodYear, 90_000, 120_000}},
+		{"£40-50k per annum", Salary{40_000, 50_000, "GBP", PeriodYear, 40_000, 50_000}},
+		{"$25–$35 an hour", Salary{25, 35, "USD", PeriodHour, 52_000, 72_800}},
+		{"€600 a week", Salary{600, 600, "EUR", PeriodWeek, 31_200, 31_200}},
+		{"NGN 800,000 - 1,200,000 monthly", Salary{800_000, 1_200_000, "NGN", PeriodMonth, 9_600_000, 14_400_000}},
+		{"KSh 150K/mo", Salary{150_000, 150_000, "KES", PeriodMonth, 1_800_000, 1_800_000}},
+		{"CA$110K a year", Salary{110_000, 110_000, "CAD", PeriodYear, 110_000, 110_000}},
+		{"1.2M naira", Salary{1_200_000, 1_200_000, "NGN", PeriodYear, 1_200_000, 1_200_000}},
+		// No period and too small to guess.
+		{"$500", Salary{500, 500, "USD", "", 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParseSalary(tt.text)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, text := range []string{"", "Competitive", "DOE"} {
+		_, ok := ParseSalary(text)
+		assert.False(t, ok, text)
+	}
+}
+
+func TestParsePostedAt(t *testing.T) {
+	anchor := time.Date(2024, 5, 20, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"3 days ago", anchor.AddDate(0, 0, -3)},
+		{"30+ days ago", anchor.AddDate(0, 0, -30)},
+		{"a day ago", anchor.AddDate(0, 0, -1)},
+		{"5 hours ago", anchor.Add(-5 * time.Hour)},
+		{"2 weeks ago", anchor.AddDate(0, 0, -14)},
+		{"an hour ago", anchor.Add(-time.Hour)},
+		{"Just posted", anchor},
+		{"yesterday", anchor.AddDate(0, 0, -1)},
+		{"2024-05-17", time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{"2024-05-17T09:30:00Z", time.Date(2024, 5, 17, 9, 30, 0, 0, time.UTC)},
+		{"2024-05-17T09:30:00.123+01:00", time.Date(2024, 5, 17, 8, 30, 0, 123_000_000, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := ParsePostedAt(tt.text, anchor)
+			assert.True(t, ok)
+			assert.True(t, tt.want.Equal(got), "got %v, want %v", got, tt.want)
+		})
+	}
+
+	for _, text := range []string{"", "recently"} {
+		_, ok := ParsePostedAt(text, anchor)
+		assert.False(t, ok, text)
+	}
+}
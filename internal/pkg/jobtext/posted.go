@@ -0,0 +1,72 @@
+package jobtext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var relativeAge = regexp.MustCompile(`(\d+|an?)\+?\s*(minute|min|hour|hr|day|week|month)s?\s+ago`)
+
+var postedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"Jan 2, 2006",
+	"2 Jan 2006",
+}
+
+// ParsePostedAt reads when a job was posted, from an absolute date or text
+// such as "3 days ago", "30+ days ago" or "just posted". Relative text is
+// counted back from anchor, the time it was fetched.
+func ParsePostedAt(text string, anchor time.Time) (time.Time, bool) {
+	s := strings.ToLower(strings.TrimSpace(text))
+	if s == "" {
+		return time.Time{}, false
+	}
+	switch s {
+	case "just posted", "today", "just now", "now":
+		return anchor, true
+	case "yesterday":
+		return anchor.AddDate(0, 0, -1), true
+	}
+
+	for _, layout := range postedLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	// Timestamps with a zone or fraction the layouts above do not cover.
+	if len(s) >= len("2006-01-02") {
+		if t, err := time.Parse("2006-01-02", s[:len("2006-01-02")]); err == nil {
+			return t, true
+		}
+	}
+
+	m := relativeAge.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	n := 1
+	if m[1] != "a" && m[1] != "an" {
+		v, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		n = v
+	}
+	switch m[2] {
+	case "minute", "min":
+		return anchor.Add(-time.Duration(n) * time.Minute), true
+	case "hour", "hr":
+		return anchor.Add(-time.Duration(n) * time.Hour), true
+	case "week":
+		return anchor.AddDate(0, 0, -7*n), true
+	case "month":
+		return anchor.AddDate(0, -n, 0), true
+	default:
+		return anchor.AddDate(0, 0, -n), true
+	}
+}
@@ -0,0 +1,140 @@
+// Package jobtext reads the free-text salary and posting date job boards
+// give, such as "₦300K–₦450K a month" or "3 days ago".
+package jobtext
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pay periods a salary can be quoted in.
+const (
+	PeriodHour  = "hour"
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// periodsPerYear annualises a salary, assuming full-time hours.
+var periodsPerYear = map[string]float64{
+	PeriodHour:  2080,
+	PeriodDay:   260,
+	PeriodWeek:  52,
+	PeriodMonth: 12,
+	PeriodYear:  1,
+}
+
+// Salary is a pay range read from text. Currency is empty when the text does
+// not say; Period is empty when it cannot be told, and then the annual
+// figures are zero.
+type Salary struct {
+	Min       float64
+	Max       float64
+	Currency  string
+	Period    string
+	AnnualMin float64
+	AnnualMax float64
+}
+
+var salaryAmount = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*([kKmM]\b)?`)
+
+// currencyMarks are checked in order, so longer marks come before the
+// symbols they contain.
+var currencyMarks = []struct{ mark, code string }{
+	{"us$", "USD"}, {"ca$", "CAD"}, {"c$", "CAD"}, {"a$", "AUD"}, {"au$", "AUD"},
+	{"ksh", "KES"}, {"gh₵", "GHS"}, {"₵", "GHS"},
+	{"₦", "NGN"}, {"£", "GBP"}, {"€", "EUR"}, {"₹", "INR"}, {"¥", "JPY"}, {"$", "USD"},
+}
+
+var currencyCodes = regexp.MustCompile(`\b(usd|eur|gbp|cad|aud|chf|jpy|inr|ngn|ghs|kes|zar|egp|naira)\b`)
+
+var periodPatterns = []struct {
+	re     *regexp.Regexp
+	period string
+}{
+	{regexp.MustCompile(`\b(an? |per |/ ?)(hour|hr)\b|\bhourly\b|/h\b`), PeriodHour},
+	{regexp.MustCompile(`\b(an? |per |/ ?)day\b|\bdaily\b`), PeriodDay},
+	{regexp.MustCompile(`\b(an? |per |/ ?)(week|wk)\b|\bweekly\b`), PeriodWeek},
+	{regexp.MustCompile(`\b(an? |per |/ ?)(month|mo|mth)\b|\bmonthly\b|\bp\.?m\.?$`), PeriodMonth},
+	{regexp.MustCompile(`\b(an? |per |/ ?)(year|yr|annum)\b|\b(yearly|annually|annual)\b|\bp\.?a\.?\b`), PeriodYear},
+}
+
+// ParseSalary reads a salary such as "$90k - $120k", "₦300K–₦450K a month"
+// or "£40-50k per annum". A single figure is both ends of the range. When the
+// period is not stated, a figure in thousands is taken as yearly.
+func ParseSalary(text string) (Salary, bool) {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	matches := salaryAmount.FindAllStringSubmatch(lower, -1)
+
+	var amounts []float64
+	var multipliers []float64
+	for _, m := range matches {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil || amount <= 0 {
+			continue
+		}
+		mult := 1.0
+		switch m[2] {
+		case "k":
+			mult = 1_000
+		case "m":
+			mult = 1_000_000
+		}
+		amounts = append(amounts, amount)
+		multipliers = append(multipliers, mult)
+	}
+	if len(amounts) == 0 {
+		return Salary{}, false
+	}
+	if len(amounts) > 2 {
+		amounts, multipliers = amounts[:2], multipliers[:2]
+	}
+	// "40-50k": the suffix on the last figure covers the first.
+	last := multipliers[len(multipliers)-1]
+	for i := range amounts {
+		if multipliers[i] == 1 && last > 1 && amounts[i] < 1000 {
+			multipliers[i] = last
+		}
+		amounts[i] *= multipliers[i]
+	}
+
+	s := Salary{Min: amounts[0], Max: amounts[len(amounts)-1]}
+	if s.Min > s.Max {
+		s.Min, s.Max = s.Max, s.Min
+	}
+	s.Currency = parseCurrency(lower)
+	s.Period = parsePeriod(lower)
+	if s.Period == "" && s.Max >= 10_000 {
+		s.Period = PeriodYear
+	}
+	if n, ok := periodsPerYear[s.Period]; ok {
+		s.AnnualMin, s.AnnualMax = s.Min*n, s.Max*n
+	}
+	return s, true
+}
+
+func parseCurrency(lower string) string {
+	if m := currencyCodes.FindString(lower); m != "" {
+		if m == "naira" {
+			return "NGN"
+		}
+		return strings.ToUpper(m)
+	}
+	for _, c := range currencyMarks {
+		if strings.Contains(lower, c.mark) {
+			return c.code
+		}
+	}
+	return ""
+}
+
+func parsePeriod(lower string) string {
+	for _, p := range periodPatterns {
+		if p.re.MatchString(lower) {
+			return p.period
+		}
+	}
+	return ""
+}
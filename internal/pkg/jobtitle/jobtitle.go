@@ -0,0 +1,242 @@
+// Package jobtitle maps the free-text job titles and experience levels people
+// type, such as "Snr. Software Engr", onto a bundled taxonomy of standard
+// titles and levels, so searches are made with words job boards recognise.
+package jobtitle
+
+import (
+	"aiki/internal/pkg/jobmatch"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// byKey finds an occupation by the title key of its title or an alias.
+	byKey = map[string]*Occupation{}
+	// byTitleWord finds the level a leading title word marks.
+	byTitleWord = map[string]string{}
+	// byLevelAlias finds a level by the title key of one of its aliases.
+	byLevelAlias = map[string]string{}
+	// levelKeys lists every level alias key, longest first, so "mid level"
+	// is tried before "mid".
+	levelKeys []string
+)
+
+func init() {
+	for i := range occupations {
+		o := &occupations[i]
+		for _, name := range append([]string{o.Title}, o.Aliases...) {
+			if _, taken := byKey[jobmatch.TitleKey(name)]; !taken {
+				byKey[jobmatch.TitleKey(name)] = o
+			}
+		}
+	}
+	for _, l := range levels {
+		for _, alias := range append([]string{l.Key}, l.Aliases...) {
+			key := jobmatch.TitleKey(alias)
+			byLevelAlias[key] = l.Key
+			levelKeys = append(levelKeys, key)
+		}
+		for _, w := range l.TitleWords {
+			byTitleWord[jobmatch.TitleKey(w)] = l.Key
+		}
+	}
+	sort.SliceStable(levelKeys, func(i, j int) bool {
+		return len(strings.Fields(levelKeys[i])) > len(strings.Fields(levelKeys[j]))
+	})
+}
+
+// Parsed is a job title read against the taxonomy. Title is the standard
+// title when the role is known, otherwise what was typed without its
+// seniority. Level is the seniority the title led with, or "".
+type Parsed struct {
+	Title      string
+	Level      string
+	Occupation *Occupation
+}
+
+// Parse reads title, e.g. "Snr. Software Engr" gives Software Engineer at
+// senior level. A title the taxonomy lists as a whole, such as "Lead
+// Developer", is kept whole rather than read as a seniority.
+func Parse(title string) Parsed {
+	title = strings.Join(strings.Fields(title), " ")
+	if o, ok := byKey[jobmatch.TitleKey(title)]; ok {
+		return Parsed{Title: o.Title, Occupation: o}
+	}
+
+	level, rest := splitLevel(title)
+	if o, ok := byKey[jobmatch.TitleKey(rest)]; ok {
+		return Parsed{Title: o.Title, Level: level, Occupation: o}
+	}
+	if rest == "" {
+		rest = title
+		level = ""
+	}
+	return Parsed{Title: rest, Level: level}
+}
+
+// Normalise returns the standard title and level for a profile's job title
+// and experience level. The level falls back to the seniority the title led
+// with, then to what was typed.
+func Normalise(title, level string) (string, string) {
+	p := Parse(title)
+	if l, ok := ParseLevel(level); ok {
+		return p.Title, l
+	}
+	if p.Level != "" {
+		return p.Title, p.Level
+	}
+	return p.Title, strings.TrimSpace(level)
+}
+
+// splitLevel takes the seniority words off the front of title, e.g. "Entry
+// Level" or "Sr.", returning the last level they named and what is left.
+func splitLevel(title string) (string, string) {
+	words := strings.Fields(title)
+	var level string
+outer:
+	for len(words) > 0 {
+		for n := min(2, len(words)); n > 0; n-- {
+			if l, ok := byTitleWord[jobmatch.TitleKey(strings.Join(words[:n], " "))]; ok {
+				level = l
+				words = words[n:]
+				continue outer
+			}
+		}
+		break
+	}
+	return level, strings.Join(words, " ")
+}
+
+var yearsOfExperience = regexp.MustCompile(`(\d+)\s*(?:\+|plus)?\s*(?:years?|yrs?)\b`)
+
+// ParseLevel reads an experience level such as "Senior-level", "jnr" or
+// "4 years". ok is false when it names none.
+func ParseLevel(text string) (level string, ok bool) {
+	key := jobmatch.TitleKey(text)
+	if key == "" {
+		return "", false
+	}
+	if l, ok := byLevelAlias[key]; ok {
+		return l, true
+	}
+	if m := yearsOfExperience.FindStringSubmatch(strings.ToLower(text)); m != nil {
+		years, _ := strconv.Atoi(m[1])
+		return levelForYears(years), true
+	}
+	padded := " " + key + " "
+	for _, alias := range levelKeys {
+		if strings.Contains(padded, " "+alias+" ") {
+			return byLevelAlias[alias], true
+		}
+	}
+	return "", false
+}
+
+func levelForYears(years int) string {
+	switch {
+	case years < 1:
+		return LevelBeginner
+	case years < 3:
+		return LevelJunior
+	case years < 6:
+		return LevelMid
+	case years < 10:
+		return LevelSenior
+	default:
+		return LevelLead
+	}
+}
+
+// LevelQuery is how level is put to job boards, e.g. "mid level" for mid. An
+// unknown level is passed through.
+func LevelQuery(level string) string {
+	for _, l := range levels {
+		if l.Key == level {
+			return l.Query
+		}
+	}
+	return level
+}
+
+// Levels returns the experience ladder, least senior first.
+func Levels() []Level {
+	return append([]Level(nil), levels...)
+}
+
+// Related returns the titles searched alongside title, none when it is not
+// a known role.
+func Related(title string) []string {
+	p := Parse(title)
+	if p.Occupation == nil {
+		return nil
+	}
+	return append([]string(nil), p.Occupation.Related...)
+}
+
+// Suggest returns up to limit standard titles for what has been typed so
+// far, e.g. "snr back" suggests Backend Engineer. Titles starting with the
+// text come first, then those with words starting with each typed word; a
+// match on the title itself beats one on an alias.
+func Suggest(text string, limit int) []Occupation {
+	_, rest := splitLevel(strings.Join(strings.Fields(text), " "))
+	want := strings.Fields(jobmatch.TitleKey(rest))
+	if len(want) == 0 || limit <= 0 {
+		return nil
+	}
+
+	type match struct {
+		o     *Occupation
+		score int
+	}
+	var matches []match
+	for i := range occupations {
+		o := &occupations[i]
+		best := -1
+		for j, name := range append([]string{o.Title}, o.Aliases...) {
+			score := matchScore(jobmatch.TitleKey(name), want)
+			if score < 0 {
+				continue
+			}
+			if j > 0 {
+				score++
+			}
+			if best < 0 || score < best {
+				best = score
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, match{o, best})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+
+	out := make([]Occupation, 0, min(limit, len(matches)))
+	for _, m := range matches[:min(limit, len(matches))] {
+		out = append(out, *m.o)
+	}
+	return out
+}
+
+// matchScore is 0 when name starts with the wanted words, 2 when each of
+// them starts some word of name, and -1 otherwise.
+func matchScore(name string, want []string) int {
+	if strings.HasPrefix(name, strings.Join(want, " ")) {
+		return 0
+	}
+	words := strings.Fields(name)
+	for _, w := range want {
+		found := false
+		for _, n := range words {
+			if strings.HasPrefix(n, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return -1
+		}
+	}
+	return 2
+}
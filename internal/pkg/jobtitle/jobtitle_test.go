@@ -0,0 +1,102 @@
+package jobtitle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		in, title, level string
+		known            bool
+	}{
+		{"Snr. Software Engr", "Software Engineer", LevelSenior, true},
+		{"backend developer", "Backend Engineer", "", true},
+		{"Sr Back-End Dev", "Backend Engineer", LevelSenior, true},
+		{"Entry Level QA Tester", "QA Engineer", LevelBeginner, true},
+		{"SWE", "Software Engineer", "", true},
+		// Listed whole, so "lead" is not read as a level.
+		{"Lead Developer", "Technical Lead", "", true},
+		{"Principal Golang Engineer", "Go Engineer", LevelLead, true},
+		{"Senior  Blockchain Wizard", "Blockchain Wizard", LevelSenior, false},
+		{"Lead", "Lead", "", false},
+	}
+	for _, c := range cases {
+		p := Parse(c.in)
+		assert.Equal(t, c.title, p.Title, c.in)
+		assert.Equal(t, c.level, p.Level, c.in)
+		assert.Equal(t, c.known, p.Occupation != nil, c.in)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]string{
+		"senior":           LevelSenior,
+		"Snr":              LevelSenior,
+		"Mid-Level":        LevelMid,
+		"entry level":      LevelBeginner,
+		"Staff":            LevelLead,
+		"VP":               LevelExecutive,
+		"4 years":          LevelMid,
+		"10+ yrs":          LevelLead,
+		"Senior level, IC": LevelSenior,
+	}
+	for in, want := range cases {
+		got, ok := ParseLevel(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, ok := ParseLevel("whatever")
+	assert.False(t, ok)
+}
+
+func TestNormalise(t *testing.T) {
+	title, level := Normalise("Snr. Software Engr", "mid-level")
+	assert.Equal(t, "Software Engineer", title)
+	assert.Equal(t, LevelMid, level)
+
+	// Without a readable level, the title's seniority is used.
+	title, level = Normalise("Snr. Software Engr", "")
+	assert.Equal(t, "Software Engineer", title)
+	assert.Equal(t, LevelSenior, level)
+
+	title, level = Normalise("Blockchain Wizard", " apprentice ")
+	assert.Equal(t, "Blockchain Wizard", title)
+	assert.Equal(t, "apprentice", level)
+}
+
+func TestLevelQuery(t *testing.T) {
+	assert.Equal(t, "entry level", LevelQuery(LevelBeginner))
+	assert.Equal(t, "mid level", LevelQuery(LevelMid))
+	assert.Equal(t, "apprentice", LevelQuery("apprentice"))
+}
+
+func TestRelated(t *testing.T) {
+	assert.Contains(t, Related("Backend Developer"), "Go Engineer")
+	assert.Nil(t, Related("Blockchain Wizard"))
+}
+
+func TestSuggest(t *testing.T) {
+	titles := func(os []Occupation) []string {
+		out := make([]string, len(os))
+		for i, o := range os {
+			out[i] = o.Title
+		}
+		return out
+	}
+
+	got := titles(Suggest("snr back", 5))
+	if assert.NotEmpty(t, got) {
+		assert.Equal(t, "Backend Engineer", got[0])
+	}
+
+	// A title starting with the text beats one that only contains it.
+	got = titles(Suggest("data", 10))
+	assert.Equal(t, []string{"Data Engineer", "Data Scientist", "Data Analyst"}, got[:3])
+
+	assert.Len(t, Suggest("e", 2), 2)
+	assert.Empty(t, Suggest("  ", 5))
+	assert.Empty(t, Suggest("zzz", 5))
+}
@@ -0,0 +1,299 @@
+package jobtitle
+
+// Experience levels, from least to most senior. They are the values stored in
+// a profile's experience level.
+const (
+	LevelBeginner  = "beginner"
+	LevelJunior    = "junior"
+	LevelMid       = "mid"
+	LevelSenior    = "senior"
+	LevelLead      = "lead"
+	LevelManager   = "manager"
+	LevelExecutive = "executive"
+)
+
+// Level is a step of the experience ladder. Query is how it is put to job
+// boards; Aliases are the ways people write it. TitleWords are the aliases
+// that also mark seniority when they lead a job title, as in "Senior Backend
+// Engineer"; manager and executive are part of the role instead.
+type Level struct {
+	Key        string
+	Label      string
+	Query      string
+	Aliases    []string
+	TitleWords []string
+}
+
+var levels = []Level{
+	{
+		Key: LevelBeginner, Label: "Entry level", Query: "entry level",
+		Aliases:    []string{"beginner", "entry", "entry level", "graduate", "grad", "trainee", "fresher", "no experience"},
+		TitleWords: []string{"entry level", "graduate", "grad", "trainee"},
+	},
+	{
+		Key: LevelJunior, Label: "Junior", Query: "junior",
+		Aliases:    []string{"junior", "jr", "jnr", "associate"},
+		TitleWords: []string{"junior", "associate"},
+	},
+	{
+		Key: LevelMid, Label: "Mid level", Query: "mid level",
+		Aliases:    []string{"mid", "mid level", "middle", "intermediate"},
+		TitleWords: []string{"mid level", "intermediate"},
+	},
+	{
+		Key: LevelSenior, Label: "Senior", Query: "senior",
+		Aliases:    []string{"senior", "sr", "snr", "experienced"},
+		TitleWords: []string{"senior"},
+	},
+	{
+		Key: LevelLead, Label: "Lead / Staff", Query: "lead",
+		Aliases:    []string{"lead", "staff", "principal", "tech lead", "team lead", "expert"},
+		TitleWords: []string{"lead", "staff", "principal"},
+	},
+	{
+		Key: LevelManager, Label: "Manager", Query: "manager",
+		Aliases: []string{"manager", "management", "head", "head of"},
+	},
+	{
+		Key: LevelExecutive, Label: "Executive", Query: "executive",
+		Aliases: []string{"executive", "director", "vp", "vice president", "chief", "c level", "cxo"},
+	},
+}
+
+// Occupation is a standard job title. Aliases are other ways of writing it;
+// Related are titles whose postings usually suit the same person, searched
+// alongside it.
+type Occupation struct {
+	Title   string
+	Aliases []string
+	Related []string
+}
+
+// occupations is the bundled taxonomy. Aliases are compared after
+// jobmatch.TitleKey, so abbreviations it expands need not be listed.
+var occupations = []Occupation{
+	// Software
+	{
+		Title:   "Software Engineer",
+		Aliases: []string{"software developer", "programmer", "software development engineer", "application developer", "coder"},
+		Related: []string{"Backend Engineer", "Full Stack Engineer"},
+	},
+	{
+		Title:   "Backend Engineer",
+		Aliases: []string{"backend developer", "back end engineer", "back end developer", "server side developer", "api developer", "api engineer"},
+		Related: []string{"Go Engineer", "Software Engineer"},
+	},
+	{
+		Title:   "Frontend Engineer",
+		Aliases: []string{"frontend developer", "front end engineer", "front end developer", "ui developer", "ui engineer", "web developer"},
+		Related: []string{"React Developer", "Full Stack Engineer"},
+	},
+	{
+		Title:   "Full Stack Engineer",
+		Aliases: []string{"full stack developer", "fullstack engineer", "fullstack developer"},
+		Related: []string{"Backend Engineer", "Frontend Engineer"},
+	},
+	{
+		Title:   "Go Engineer",
+		Aliases: []string{"go developer", "golang engineer", "golang developer"},
+		Related: []string{"Backend Engineer"},
+	},
+	{
+		Title:   "Python Developer",
+		Aliases: []string{"python engineer", "django developer"},
+		Related: []string{"Backend Engineer"},
+	},
+	{
+		Title:   "Java Developer",
+		Aliases: []string{"java engineer", "spring developer"},
+		Related: []string{"Backend Engineer"},
+	},
+	{
+		Title:   "React Developer",
+		Aliases: []string{"react engineer", "reactjs developer", "react js developer"},
+		Related: []string{"Frontend Engineer"},
+	},
+	{
+		Title:   "Mobile Developer",
+		Aliases: []string{"mobile engineer", "mobile app developer", "flutter developer", "react native developer"},
+		Related: []string{"iOS Developer", "Android Developer"},
+	},
+	{
+		Title:   "iOS Developer",
+		Aliases: []string{"ios engineer", "swift developer"},
+		Related: []string{"Mobile Developer"},
+	},
+	{
+		Title:   "Android Developer",
+		Aliases: []string{"android engineer", "kotlin developer"},
+		Related: []string{"Mobile Developer"},
+	},
+	{
+		Title:   "DevOps Engineer",
+		Aliases: []string{"devops", "platform engineer", "infrastructure engineer", "cloud engineer", "build engineer"},
+		Related: []string{"Site Reliability Engineer"},
+	},
+	{
+		Title:   "Site Reliability Engineer",
+		Aliases: []string{"sre", "reliability engineer", "production engineer"},
+		Related: []string{"DevOps Engineer"},
+	},
+	{
+		Title:   "QA Engineer",
+		Aliases: []string{"quality assurance engineer", "test engineer", "software tester", "tester", "qa tester", "qa analyst", "sdet", "automation tester"},
+		Related: []string{"Software Engineer"},
+	},
+	{
+		Title:   "Security Engineer",
+		Aliases: []string{"cybersecurity engineer", "cyber security engineer", "security analyst", "information security analyst", "appsec engineer"},
+		Related: []string{"DevOps Engineer"},
+	},
+	{
+		Title:   "Engineering Manager",
+		Aliases: []string{"software engineering manager", "development manager", "head of engineering"},
+		Related: []string{"Technical Lead"},
+	},
+	{
+		Title:   "Technical Lead",
+		Aliases: []string{"tech lead", "lead engineer", "lead developer"},
+		Related: []string{"Engineering Manager", "Software Engineer"},
+	},
+
+	// Data
+	{
+		Title:   "Data Engineer",
+		Aliases: []string{"big data engineer", "etl developer", "data platform engineer"},
+		Related: []string{"Analytics Engineer", "Backend Engineer"},
+	},
+	{
+		Title:   "Data Scientist",
+		Aliases: []string{"data science", "applied scientist"},
+		Related: []string{"Machine Learning Engineer", "Data Analyst"},
+	},
+	{
+		Title:   "Data Analyst",
+		Aliases: []string{"business intelligence analyst", "bi analyst", "reporting analyst", "insights analyst"},
+		Related: []string{"Business Analyst", "Analytics Engineer"},
+	},
+	{
+		Title:   "Analytics Engineer",
+		Aliases: []string{"bi engineer", "business intelligence engineer"},
+		Related: []string{"Data Engineer", "Data Analyst"},
+	},
+	{
+		Title:   "Machine Learning Engineer",
+		Aliases: []string{"ml engineer", "ai engineer", "deep learning engineer", "mlops engineer"},
+		Related: []string{"Data Scientist"},
+	},
+
+	// Product and design
+	{
+		Title:   "Product Manager",
+		Aliases: []string{"pm", "product owner", "technical product manager"},
+		Related: []string{"Project Manager", "Business Analyst"},
+	},
+	{
+		Title:   "Project Manager",
+		Aliases: []string{"programme manager", "program manager", "delivery manager", "scrum master"},
+		Related: []string{"Product Manager"},
+	},
+	{
+		Title:   "Product Designer",
+		Aliases: []string{"ux ui designer", "ui ux designer", "interaction designer"},
+		Related: []string{"UX Designer", "UI Designer"},
+	},
+	{
+		Title:   "UX Designer",
+		Aliases: []string{"user experience designer", "ux researcher", "user researcher"},
+		Related: []string{"Product Designer"},
+	},
+	{
+		Title:   "UI Designer",
+		Aliases: []string{"user interface designer", "visual designer", "web designer"},
+		Related: []string{"Product Designer", "Graphic Designer"},
+	},
+	{
+		Title:   "Graphic Designer",
+		Aliases: []string{"graphics designer", "brand designer", "creative designer"},
+		Related: []string{"UI Designer"},
+	},
+	{
+		Title:   "Business Analyst",
+		Aliases: []string{"ba", "business systems analyst", "systems analyst"},
+		Related: []string{"Data Analyst", "Product Manager"},
+	},
+
+	// Marketing, sales and support
+	{
+		Title:   "Digital Marketer",
+		Aliases: []string{"digital marketing specialist", "digital marketing manager", "marketing specialist", "growth marketer", "performance marketer"},
+		Related: []string{"Content Writer", "Social Media Manager"},
+	},
+	{
+		Title:   "Social Media Manager",
+		Aliases: []string{"social media specialist", "community manager", "social media executive"},
+		Related: []string{"Digital Marketer", "Content Writer"},
+	},
+	{
+		Title:   "Content Writer",
+		Aliases: []string{"copywriter", "content creator", "content strategist", "technical writer"},
+		Related: []string{"Digital Marketer"},
+	},
+	{
+		Title:   "Sales Representative",
+		Aliases: []string{"sales executive", "sales associate", "business development representative", "sales development representative", "bdr", "sdr", "account executive"},
+		Related: []string{"Account Manager"},
+	},
+	{
+		Title:   "Account Manager",
+		Aliases: []string{"client manager", "key account manager", "relationship manager"},
+		Related: []string{"Customer Success Manager", "Sales Representative"},
+	},
+	{
+		Title:   "Customer Success Manager",
+		Aliases: []string{"customer success", "client success manager", "customer experience manager"},
+		Related: []string{"Account Manager", "Customer Support Specialist"},
+	},
+	{
+		Title:   "Customer Support Specialist",
+		Aliases: []string{"customer service representative", "customer support", "customer service", "support agent", "help desk", "call centre agent", "call center agent"},
+		Related: []string{"Customer Success Manager"},
+	},
+
+	// Business functions
+	{
+		Title:   "Accountant",
+		Aliases: []string{"chartered accountant", "staff accountant", "accounts officer", "bookkeeper"},
+		Related: []string{"Financial Analyst", "Auditor"},
+	},
+	{
+		Title:   "Financial Analyst",
+		Aliases: []string{"finance analyst", "fp and a analyst", "investment analyst"},
+		Related: []string{"Accountant", "Business Analyst"},
+	},
+	{
+		Title:   "Auditor",
+		Aliases: []string{"internal auditor", "external auditor", "audit associate"},
+		Related: []string{"Accountant"},
+	},
+	{
+		Title:   "HR Manager",
+		Aliases: []string{"human resources manager", "people manager", "hr generalist", "hr business partner", "people partner"},
+		Related: []string{"Recruiter"},
+	},
+	{
+		Title:   "Recruiter",
+		Aliases: []string{"talent acquisition specialist", "talent acquisition", "technical recruiter", "sourcer"},
+		Related: []string{"HR Manager"},
+	},
+	{
+		Title:   "Operations Manager",
+		Aliases: []string{"operations lead", "ops manager", "business operations manager"},
+		Related: []string{"Project Manager"},
+	},
+	{
+		Title:   "Administrative Assistant",
+		Aliases: []string{"admin assistant", "office administrator", "executive assistant", "personal assistant", "office assistant"},
+		Related: []string{"Operations Manager"},
+	},
+}
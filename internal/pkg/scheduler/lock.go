@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"expvar"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Locker hands a named lock to one holder at a time across every replica.
+type Locker interface {
+	// TryLock returns ok false, without waiting, when the lock is held. The
+	// lock is released by calling release, or when ttl runs out.
+	TryLock(ctx context.Context, name string, ttl time.Duration) (release func(), ok bool, err error)
+}
+
+// lockStats counts, per job, the runs skipped because another replica held
+// the lock. Admins read them on /api/v1/admin/debug/vars.
+var lockStats = expvar.NewMap("scheduler_lock_skips")
+
+// exclusive runs job only if no other replica is running it. The lock is
+// held for at most ttl, and job is given until then to finish.
+func (s *Scheduler) exclusive(name string, ttl time.Duration, job func(ctx context.Context)) {
+	ctx, cancel := context.WithTimeout(context.Background(), ttl)
+	defer cancel()
+
+	release, ok, err := s.locker.TryLock(ctx, name, ttl)
+	if err != nil {
+		log.Printf("scheduler: failed to lock %s: %v", name, err)
+		return
+	}
+	if !ok {
+		log.Printf("scheduler: skipping %s, another replica is running it", name)
+		lockStats.Add(name, 1)
+		return
+	}
+	defer release()
+	job(ctx)
+}
+
+// RedisLocker keeps locks in Redis.
+type RedisLocker struct {
+	client *redis.Client
+}
+
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{client: client}
+}
+
+// releaseScript deletes the lock only if it still holds our token, so a run
+// that outlived its ttl cannot release a lock another replica has since taken.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+func (l *RedisLocker) TryLock(ctx context.Context, name string, ttl time.Duration) (func(), bool, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, false, err
+	}
+	token := hex.EncodeToString(b)
+	key := "scheduler_lock:" + name
+
+	ok, err := l.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	release := func() {
+		if err := releaseScript.Run(context.Background(), l.client, []string{key}, token).Err(); err != nil {
+			log.Printf("scheduler: failed to release %s lock: %v", name, err)
+		}
+	}
+	return release, true, nil
+}
@@ -8,26 +8,106 @@ import (
 )
 
 type Scheduler struct {
-	notifService service.NotificationService
+	notifService       service.NotificationService
+	jobService         service.JobService
+	automationService  service.AutomationService
+	goalService        service.GoalService
+	savedSearchService service.SavedSearchService
+	serpJobService     service.SerpJobService
+	homeService        service.HomeService
+	locker             Locker
+	prefetchEvery      time.Duration
+	sessionSweepEvery  time.Duration
 }
 
-func NewScheduler(notifService service.NotificationService) *Scheduler {
-	return &Scheduler{notifService: notifService}
+func NewScheduler(
+	notifService service.NotificationService,
+	jobService service.JobService,
+	automationService service.AutomationService,
+	goalService service.GoalService,
+	savedSearchService service.SavedSearchService,
+	serpJobService service.SerpJobService,
+	homeService service.HomeService,
+	locker Locker,
+	prefetchEvery time.Duration,
+	sessionSweepEvery time.Duration,
+) *Scheduler {
+	return &Scheduler{
+		notifService:       notifService,
+		jobService:         jobService,
+		automationService:  automationService,
+		goalService:        goalService,
+		savedSearchService: savedSearchService,
+		serpJobService:     serpJobService,
+		homeService:        homeService,
+		locker:             locker,
+		prefetchEvery:      prefetchEvery,
+		sessionSweepEvery:  sessionSweepEvery,
+	}
 }
 
 // Start begins the background scheduler. Call this in a goroutine from main.go.
 func (s *Scheduler) Start() {
 	log.Println("✓ Notification scheduler started")
 
-	go s.runAt(18, 0, "daily_reminder", func() {
+	go s.runAt(3, 0, "purge_job_trash", func() {
+		ctx := context.Background()
+		s.jobService.PurgeTrash(ctx)
+	})
+
+	go s.runAt(6, 0, "saved_searches", func() {
+		ctx := context.Background()
+		s.savedSearchService.RunDueSearches(ctx)
+	})
+
+	go s.runAt(7, 0, "job_automation", func() {
+		ctx := context.Background()
+		s.automationService.RunRules(ctx)
+	})
+
+	go s.runAt(8, 0, "saved_search_digest", func() {
+		ctx := context.Background()
+		s.savedSearchService.SendDigests(ctx)
+	})
+
+	go s.runAt(9, 0, "contact_follow_up", func() {
+		ctx := context.Background()
+		s.notifService.SendContactFollowUps(ctx)
+	})
+
+	go s.runAt(9, 0, "offer_deadline", func() {
+		ctx := context.Background()
+		s.notifService.SendOfferDeadlineReminders(ctx)
+	})
+
+	// Reminders and goal notifications follow each user's time zone, so they
+	// are checked hourly.
+	go s.runHourly("daily_reminder", func() {
 		ctx := context.Background()
 		s.notifService.SendDailyReminders(ctx)
 	})
 
-	go s.runAt(20, 0, "streak_warning", func() {
+	go s.runHourly("goal_progress", func() {
+		ctx := context.Background()
+		s.goalService.SendGoalNotifications(ctx)
+	})
+
+	go s.runHourly("streak_warning", func() {
 		ctx := context.Background()
 		s.notifService.SendStreakWarnings(ctx)
 	})
+
+	if s.prefetchEvery > 0 {
+		go s.runEvery(s.prefetchEvery, "recommendation_prefetch", func() {
+			s.exclusive("recommendation_prefetch", s.prefetchEvery, s.serpJobService.PrefetchRecommendations)
+		})
+	}
+
+	if s.sessionSweepEvery > 0 {
+		go s.runEvery(s.sessionSweepEvery, "stale_focus_sessions", func() {
+			s.exclusive("stale_focus_sessions", s.sessionSweepEvery, s.homeService.ExpireStaleSessions)
+		})
+	}
 }
 
 // runAt runs a job every day at the specified hour and minute (24hr).
@@ -49,3 +129,25 @@ func (s *Scheduler) runAt(hour, minute int, name string, job func()) {
 		job()
 	}
 }
+
+// runHourly runs a job at the start of every hour.
+func (s *Scheduler) runHourly(name string, job func()) {
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Hour).Add(time.Hour)
+		time.Sleep(next.Sub(now))
+		log.Printf("scheduler: running %s", name)
+		job()
+	}
+}
+
+// runEvery runs a job every interval, starting one interval from now.
+func (s *Scheduler) runEvery(interval time.Duration, name string, job func()) {
+	log.Printf("scheduler: running %s every %s", name, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		log.Printf("scheduler: running %s", name)
+		job()
+	}
+}
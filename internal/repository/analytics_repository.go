@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AnalyticsRepository interface {
+	GetApplicationFunnel(ctx context.Context, userID int32, from, to time.Time) ([]domain.ApplicationFunnelRow, error)
+}
+
+type analyticsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(dbPool *pgxpool.Pool) AnalyticsRepository {
+	return &analyticsRepository{db: dbPool}
+}
+
+// GetApplicationFunnel returns every applied job with a funnel milestone
+// (application, interview, offer or rejection) inside [from, to]. A job counts
+// as applied from its date_applied, midnight of that day in the user's time
+// zone, or otherwise from the first time it left the saved status. Times are
+// returned in UTC. Archived and trashed jobs are left out.
+func (r *analyticsRepository) GetApplicationFunnel(ctx context.Context, userID int32, from, to time.Time) ([]domain.ApplicationFunnelRow, error) {
+	const query = `
+		WITH applied AS (
+			SELECT
+				j.id,
+				COALESCE(NULLIF(TRIM(j.platform), ''), 'unknown') AS platform,
+				CASE
+					WHEN EXISTS (SELECT 1 FROM serp_job_cache s WHERE s.tracker_job_id = j.id) THEN 'recommended'
+					ELSE 'manual'
+				END AS source,
+				COALESCE(j.date_applied AT TIME ZONE u.timezone AT TIME ZONE 'UTC', (
+					SELECT MIN(e.changed_at) FROM job_status_events e
+					WHERE e.job_id = j.id AND e.to_status <> 'saved'
+				)) AS applied_at
+			FROM jobs j
+			JOIN users u ON u.id = j.user_id
+			WHERE j.user_id = $1 AND j.archived_at IS NULL AND j.deleted_at IS NULL
+		), milestones AS (
+			SELECT
+				a.id,
+				a.platform,
+				a.source,
+				a.applied_at,
+				(SELECT MIN(e.changed_at) FROM job_status_events e
+				 WHERE e.job_id = a.id AND e.to_status IN ('interview', 'offer')) AS interview_at,
+				(SELECT MIN(e.changed_at) FROM job_status_events e
+				 WHERE e.job_id = a.id AND e.to_status = 'offer') AS offer_at,
+				(SELECT MIN(e.changed_at) FROM job_status_events e
+				 WHERE e.job_id = a.id AND e.to_status = 'rejected') AS rejected_at,
+				(SELECT MIN(e.changed_at) FROM job_status_events e
+				 WHERE e.job_id = a.id
+				   AND e.to_status IN ('interview', 'offer', 'rejected')
+				   AND e.changed_at >= a.applied_at) AS first_response_at
+			FROM applied a
+			WHERE a.applied_at IS NOT NULL
+		)
+		SELECT id, platform, source, applied_at, interview_at, offer_at, rejected_at, first_response_at
+		FROM milestones
+		WHERE applied_at BETWEEN $2 AND $3
+		   OR interview_at BETWEEN $2 AND $3
+		   OR offer_at BETWEEN $2 AND $3
+		   OR rejected_at BETWEEN $2 AND $3
+		ORDER BY applied_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, PgTimeHelper(from.UTC()), PgTimeHelper(to.UTC()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var funnel []domain.ApplicationFunnelRow
+	for rows.Next() {
+		var (
+			row                                                   domain.ApplicationFunnelRow
+			appliedAt, interviewAt, offerAt, rejectedAt, firstRsp pgtype.Timestamp
+		)
+		if err := rows.Scan(
+			&row.JobID,
+			&row.Platform,
+			&row.Source,
+			&appliedAt,
+			&interviewAt,
+			&offerAt,
+			&rejectedAt,
+			&firstRsp,
+		); err != nil {
+			return nil, err
+		}
+		row.AppliedAt = timestampPtr(appliedAt)
+		row.InterviewAt = timestampPtr(interviewAt)
+		row.OfferAt = timestampPtr(offerAt)
+		row.RejectedAt = timestampPtr(rejectedAt)
+		row.FirstResponseAt = timestampPtr(firstRsp)
+		funnel = append(funnel, row)
+	}
+
+	return funnel, rows.Err()
+}
+
+func timestampPtr(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
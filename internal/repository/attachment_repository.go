@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.JobAttachment, quotaBytes int64) (*domain.JobAttachment, error)
+	ListByJobID(ctx context.Context, jobID int32) ([]domain.JobAttachment, error)
+	Get(ctx context.Context, jobID, attachmentID int32) (*domain.JobAttachment, error)
+	Delete(ctx context.Context, jobID, attachmentID int32) error
+	GetUsage(ctx context.Context, userID int32) (int64, error)
+	SaveSnapshot(ctx context.Context, snapshot *domain.JobDescriptionSnapshot) error
+	GetSnapshot(ctx context.Context, jobID int32) (*domain.JobDescriptionSnapshot, error)
+}
+
+type attachmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttachmentRepository(dbPool *pgxpool.Pool) AttachmentRepository {
+	return &attachmentRepository{db: dbPool}
+}
+
+// attachmentColumns is shared by every query that returns attachment metadata; scanAttachment reads them in this order.
+const attachmentColumns = `a.id, a.job_id, a.user_id, a.file_name, a.content_type, a.kind, a.size_bytes, a.created_at`
+
+// Create stores the file if it fits in the user's remaining quota. The user
+// row is locked so concurrent uploads cannot both squeeze under the limit.
+func (r *attachmentRepository) Create(ctx context.Context, attachment *domain.JobAttachment, quotaBytes int64) (*domain.JobAttachment, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, attachment.UserID); err != nil {
+		return nil, err
+	}
+
+	var used int64
+	if err := tx.QueryRow(ctx,
+		`SELECT COALESCE(SUM(size_bytes), 0)::bigint FROM job_attachments WHERE user_id = $1`,
+		attachment.UserID,
+	).Scan(&used); err != nil {
+		return nil, err
+	}
+	if used+attachment.SizeBytes > quotaBytes {
+		return nil, domain.ErrStorageQuotaExceeded
+	}
+
+	query := `
+		INSERT INTO job_attachments AS a (job_id, user_id, file_name, content_type, kind, size_bytes, data)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + attachmentColumns
+	created, err := scanAttachment(tx.QueryRow(ctx, query,
+		attachment.JobID,
+		attachment.UserID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Kind,
+		attachment.SizeBytes,
+		attachment.Data,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *attachmentRepository) ListByJobID(ctx context.Context, jobID int32) ([]domain.JobAttachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM job_attachments a WHERE a.job_id = $1 ORDER BY a.created_at DESC, a.id DESC`
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []domain.JobAttachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// Get returns the attachment including its file contents.
+func (r *attachmentRepository) Get(ctx context.Context, jobID, attachmentID int32) (*domain.JobAttachment, error) {
+	query := `SELECT a.data, ` + attachmentColumns + ` FROM job_attachments a WHERE a.id = $1 AND a.job_id = $2`
+	var data []byte
+	attachment, err := scanAttachment(prefixScanner{r.db.QueryRow(ctx, query, attachmentID, jobID), []any{&data}})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	attachment.Data = data
+	return attachment, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, jobID, attachmentID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM job_attachments WHERE id = $1 AND job_id = $2`, attachmentID, jobID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// GetUsage returns the bytes the user's attachments take up, trashed jobs included.
+func (r *attachmentRepository) GetUsage(ctx context.Context, userID int32) (int64, error) {
+	var used int64
+	err := r.db.QueryRow(ctx,
+		`SELECT COALESCE(SUM(size_bytes), 0)::bigint FROM job_attachments WHERE user_id = $1`,
+		userID,
+	).Scan(&used)
+	return used, err
+}
+
+// SaveSnapshot records the job's description snapshot. Snapshots are
+// immutable: if the job already has one, it is kept.
+func (r *attachmentRepository) SaveSnapshot(ctx context.Context, snapshot *domain.JobDescriptionSnapshot) error {
+	const query = `
+		INSERT INTO job_description_snapshots (
+			job_id, user_id, title, company_name, location, platform, link, description, salary, posted_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (job_id) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query,
+		snapshot.JobID,
+		snapshot.UserID,
+		snapshot.Title,
+		nullableString(snapshot.CompanyName),
+		nullableString(snapshot.Location),
+		nullableString(snapshot.Platform),
+		nullableString(snapshot.Link),
+		snapshot.Description,
+		nullableString(snapshot.Salary),
+		nullableString(snapshot.PostedAt),
+	)
+	return err
+}
+
+func (r *attachmentRepository) GetSnapshot(ctx context.Context, jobID int32) (*domain.JobDescriptionSnapshot, error) {
+	const query = `
+		SELECT job_id, user_id, title, company_name, location, platform, link, description, salary, posted_at, captured_at
+		FROM job_description_snapshots
+		WHERE job_id = $1
+	`
+	var (
+		snapshot                                                domain.JobDescriptionSnapshot
+		companyName, location, platform, link, salary, postedAt *string
+		capturedAt                                              pgtype.Timestamp
+	)
+	err := r.db.QueryRow(ctx, query, jobID).Scan(
+		&snapshot.JobID,
+		&snapshot.UserID,
+		&snapshot.Title,
+		&companyName,
+		&location,
+		&platform,
+		&link,
+		&snapshot.Description,
+		&salary,
+		&postedAt,
+		&capturedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSnapshotNotFound
+		}
+		return nil, err
+	}
+	snapshot.CompanyName = derefString(companyName)
+	snapshot.Location = derefString(location)
+	snapshot.Platform = derefString(platform)
+	snapshot.Link = derefString(link)
+	snapshot.Salary = derefString(salary)
+	snapshot.PostedAt = derefString(postedAt)
+	snapshot.CapturedAt = capturedAt.Time
+	return &snapshot, nil
+}
+
+func scanAttachment(scanner rowScanner) (*domain.JobAttachment, error) {
+	var (
+		attachment domain.JobAttachment
+		createdAt  pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&attachment.ID,
+		&attachment.JobID,
+		&attachment.UserID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Kind,
+		&attachment.SizeBytes,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	attachment.CreatedAt = createdAt.Time
+	return &attachment, nil
+}
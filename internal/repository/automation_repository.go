@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AutomationRepository interface {
+	ListRules(ctx context.Context, userID int32) ([]domain.AutomationRule, error)
+	GetRule(ctx context.Context, ruleID, userID int32) (*domain.AutomationRule, error)
+	CreateRule(ctx context.Context, rule *domain.AutomationRule) (*domain.AutomationRule, error)
+	UpdateRule(ctx context.Context, rule *domain.AutomationRule) (*domain.AutomationRule, error)
+	DeleteRule(ctx context.Context, ruleID, userID int32) error
+	ListEnabledRules(ctx context.Context) ([]domain.AutomationRule, error)
+	ListRuleCandidates(ctx context.Context, rule *domain.AutomationRule) ([]domain.RuleCandidate, error)
+	ApplyRuleActions(ctx context.Context, rule *domain.AutomationRule, jobID int32, actions []string, message string) (bool, error)
+	ListJobTimeline(ctx context.Context, jobID int32) ([]domain.JobTimelineEvent, error)
+}
+
+type automationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAutomationRepository(dbPool *pgxpool.Pool) AutomationRepository {
+	return &automationRepository{db: dbPool}
+}
+
+// ruleColumns is shared by every query that returns a rule; scanRule reads them in this order.
+const ruleColumns = `r.id, r.user_id, r.name, r.enabled, r.status, r.after_days, r.mark_ghosted, r.nudge, r.archive, r.grace_days, r.created_at, r.updated_at`
+
+func (r *automationRepository) ListRules(ctx context.Context, userID int32) ([]domain.AutomationRule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM automation_rules r WHERE r.user_id = $1 ORDER BY r.created_at, r.id`
+	return r.queryRules(ctx, query, userID)
+}
+
+func (r *automationRepository) GetRule(ctx context.Context, ruleID, userID int32) (*domain.AutomationRule, error) {
+	query := `SELECT ` + ruleColumns + ` FROM automation_rules r WHERE r.id = $1 AND r.user_id = $2`
+	rule, err := scanRule(r.db.QueryRow(ctx, query, ruleID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *automationRepository) CreateRule(ctx context.Context, rule *domain.AutomationRule) (*domain.AutomationRule, error) {
+	query := `
+		INSERT INTO automation_rules AS r (user_id, name, enabled, status, after_days, mark_ghosted, nudge, archive, grace_days)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + ruleColumns
+	return scanRule(r.db.QueryRow(ctx, query,
+		rule.UserID,
+		rule.Name,
+		rule.Enabled,
+		rule.Status,
+		rule.AfterDays,
+		rule.MarkGhosted,
+		rule.Nudge,
+		rule.Archive,
+		rule.GraceDays,
+	))
+}
+
+func (r *automationRepository) UpdateRule(ctx context.Context, rule *domain.AutomationRule) (*domain.AutomationRule, error) {
+	query := `
+		UPDATE automation_rules AS r
+		SET name = $3, enabled = $4, status = $5, after_days = $6,
+		    mark_ghosted = $7, nudge = $8, archive = $9, grace_days = $10
+		WHERE r.id = $1 AND r.user_id = $2
+		RETURNING ` + ruleColumns
+	updated, err := scanRule(r.db.QueryRow(ctx, query,
+		rule.ID,
+		rule.UserID,
+		rule.Name,
+		rule.Enabled,
+		rule.Status,
+		rule.AfterDays,
+		rule.MarkGhosted,
+		rule.Nudge,
+		rule.Archive,
+		rule.GraceDays,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRuleNotFound
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteRule removes the rule. Events it recorded stay on the job timelines
+// with their rule_id cleared.
+func (r *automationRepository) DeleteRule(ctx context.Context, ruleID, userID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM automation_rules WHERE id = $1 AND user_id = $2`, ruleID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRuleNotFound
+	}
+	return nil
+}
+
+// ListEnabledRules returns every enabled rule of every active user.
+func (r *automationRepository) ListEnabledRules(ctx context.Context) ([]domain.AutomationRule, error) {
+	query := `
+		SELECT ` + ruleColumns + `
+		FROM automation_rules r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.enabled = TRUE AND u.is_active = TRUE
+		ORDER BY r.user_id, r.id`
+	return r.queryRules(ctx, query)
+}
+
+// ListRuleCandidates returns the user's live jobs in the rule's status with
+// the time they entered it and what the rule has already done to them. A rule
+// that has not been saved yet (ID 0) has no history.
+func (r *automationRepository) ListRuleCandidates(ctx context.Context, rule *domain.AutomationRule) ([]domain.RuleCandidate, error) {
+	const query = `
+		SELECT
+			j.id, j.user_id, j.title, COALESCE(j.company_name, ''), j.status,
+			COALESCE(
+				(SELECT MAX(se.changed_at) FROM job_status_events se WHERE se.job_id = j.id),
+				j.updated_at, j.created_at
+			) AS status_since,
+			j.ghosted_at IS NOT NULL,
+			(SELECT MAX(ev.created_at) FROM job_events ev
+			 WHERE ev.job_id = j.id AND ev.rule_id = $3 AND ev.event_type = 'nudged'),
+			(SELECT MAX(ev.created_at) FROM job_events ev
+			 WHERE ev.job_id = j.id AND ev.rule_id = $3 AND ev.event_type = 'archived')
+		FROM jobs j
+		WHERE j.user_id = $1 AND j.status = $2
+		  AND j.archived_at IS NULL AND j.deleted_at IS NULL
+		ORDER BY status_since, j.id
+	`
+	rows, err := r.db.Query(ctx, query, rule.UserID, rule.Status, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []domain.RuleCandidate
+	for rows.Next() {
+		var (
+			c                    domain.RuleCandidate
+			statusSince          pgtype.Timestamp
+			nudgedAt, archivedAt pgtype.Timestamp
+		)
+		if err := rows.Scan(
+			&c.JobID,
+			&c.UserID,
+			&c.Title,
+			&c.CompanyName,
+			&c.Status,
+			&statusSince,
+			&c.Ghosted,
+			&nudgedAt,
+			&archivedAt,
+		); err != nil {
+			return nil, err
+		}
+		c.StatusSince = statusSince.Time
+		c.NudgedAt = timestampPtr(nudgedAt)
+		c.ArchivedAt = timestampPtr(archivedAt)
+		candidates = append(candidates, c)
+	}
+	return candidates, rows.Err()
+}
+
+// ApplyRuleActions marks the job ghosted and/or archived as the actions say
+// and records one timeline event per action, in a single transaction. Jobs
+// the user trashed, archived or moved on since the candidates were listed are
+// left alone, and false is returned.
+func (r *automationRepository) ApplyRuleActions(ctx context.Context, rule *domain.AutomationRule, jobID int32, actions []string, message string) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var ghost, archive bool
+	for _, action := range actions {
+		switch action {
+		case domain.RuleActionMarkGhosted:
+			ghost = true
+		case domain.RuleActionArchive:
+			archive = true
+		}
+	}
+
+	var lockedID int32
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM jobs
+		WHERE id = $1 AND user_id = $2 AND status = $3
+		  AND archived_at IS NULL AND deleted_at IS NULL
+		FOR UPDATE`, jobID, rule.UserID, rule.Status,
+	).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if ghost || archive {
+		const update = `
+			UPDATE jobs
+			SET ghosted_at  = CASE WHEN $2::boolean THEN COALESCE(ghosted_at, NOW()) ELSE ghosted_at END,
+			    archived_at = CASE WHEN $3::boolean THEN NOW() ELSE archived_at END
+			WHERE id = $1
+		`
+		if _, err := tx.Exec(ctx, update, jobID, ghost, archive); err != nil {
+			return false, err
+		}
+	}
+
+	for _, action := range actions {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO job_events (job_id, user_id, rule_id, event_type, message) VALUES ($1, $2, $3, $4, $5)`,
+			jobID, rule.UserID, rule.ID, domain.RuleActionEvent[action], message,
+		); err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ListJobTimeline merges the job's status history with the events rules
+// recorded on it, oldest first.
+func (r *automationRepository) ListJobTimeline(ctx context.Context, jobID int32) ([]domain.JobTimelineEvent, error) {
+	const query = `
+		SELECT 'status_change', se.from_status, se.to_status, NULL::int, NULL::text, FALSE, se.changed_at
+		FROM job_status_events se
+		WHERE se.job_id = $1
+		UNION ALL
+		SELECT ev.event_type, NULL, NULL, ev.rule_id, ev.message, TRUE, ev.created_at
+		FROM job_events ev
+		WHERE ev.job_id = $1
+		ORDER BY 7, 1
+	`
+	rows, err := r.db.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.JobTimelineEvent{}
+	for rows.Next() {
+		var (
+			event                         domain.JobTimelineEvent
+			fromStatus, toStatus, message *string
+			occurredAt                    pgtype.Timestamp
+		)
+		if err := rows.Scan(
+			&event.Type,
+			&fromStatus,
+			&toStatus,
+			&event.RuleID,
+			&message,
+			&event.Automatic,
+			&occurredAt,
+		); err != nil {
+			return nil, err
+		}
+		event.FromStatus = derefString(fromStatus)
+		event.ToStatus = derefString(toStatus)
+		event.Message = derefString(message)
+		event.OccurredAt = occurredAt.Time
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *automationRepository) queryRules(ctx context.Context, query string, args ...any) ([]domain.AutomationRule, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []domain.AutomationRule{}
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+	return rules, rows.Err()
+}
+
+func scanRule(scanner rowScanner) (*domain.AutomationRule, error) {
+	var (
+		rule                 domain.AutomationRule
+		createdAt, updatedAt pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&rule.ID,
+		&rule.UserID,
+		&rule.Name,
+		&rule.Enabled,
+		&rule.Status,
+		&rule.AfterDays,
+		&rule.MarkGhosted,
+		&rule.Nudge,
+		&rule.Archive,
+		&rule.GraceDays,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rule.CreatedAt = createdAt.Time
+	rule.UpdatedAt = updatedAt.Time
+	return &rule, nil
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ContactRepository interface {
+	Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Update(ctx context.Context, contact *domain.Contact) (*domain.Contact, error)
+	Delete(ctx context.Context, contactID, userID int32) error
+	GetByID(ctx context.Context, contactID, userID int32) (*domain.Contact, error)
+	List(ctx context.Context, userID int32, filter domain.ContactFilter) ([]domain.Contact, error)
+	LinkJob(ctx context.Context, contactID, jobID int32) error
+	UnlinkJob(ctx context.Context, contactID, jobID int32) error
+	AddInteraction(ctx context.Context, userID int32, interaction *domain.ContactInteraction) (*domain.ContactInteraction, error)
+	ListInteractions(ctx context.Context, contactID int32) ([]domain.ContactInteraction, error)
+	DeleteInteraction(ctx context.Context, interactionID, contactID int32) error
+}
+
+type contactRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewContactRepository(dbPool *pgxpool.Pool) ContactRepository {
+	return &contactRepository{db: dbPool}
+}
+
+// contactColumns is shared by every query that returns contacts; scanContact reads them in this order.
+const contactColumns = `
+	c.id, c.user_id, c.name, c.company, c.role, c.email, c.linkedin_url, c.notes,
+	c.next_touch_at, c.next_touch_note, c.created_at, c.updated_at,
+	COALESCE((
+		SELECT array_agg(cj.job_id ORDER BY cj.job_id) FROM contact_jobs cj
+		INNER JOIN jobs j ON j.id = cj.job_id
+		WHERE cj.contact_id = c.id AND j.deleted_at IS NULL
+	), '{}') AS job_ids,
+	(SELECT MAX(ci.occurred_at) FROM contact_interactions ci WHERE ci.contact_id = c.id) AS last_interaction_at`
+
+func (r *contactRepository) Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	nextTouch, err := parseDate(contact.NextTouchAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const query = `
+		INSERT INTO contacts (user_id, name, company, role, email, linkedin_url, notes, next_touch_at, next_touch_note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+	var contactID int32
+	err = tx.QueryRow(ctx, query,
+		contact.UserID,
+		contact.Name,
+		nullableString(contact.Company),
+		nullableString(contact.Role),
+		nullableString(contact.Email),
+		nullableString(contact.LinkedInURL),
+		nullableString(contact.Notes),
+		nextTouch,
+		nullableString(contact.NextTouchNote),
+	).Scan(&contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := replaceContactJobs(ctx, tx, contact.UserID, contactID, contact.JobIDs); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, contactID, contact.UserID)
+}
+
+// Update overwrites the contact's fields. Job links are only replaced when
+// contact.JobIDs is non-nil. Changing the next-touch date re-arms its reminder.
+func (r *contactRepository) Update(ctx context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	nextTouch, err := parseDate(contact.NextTouchAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const query = `
+		UPDATE contacts
+		SET name = $3,
+			company = $4,
+			role = $5,
+			email = $6,
+			linkedin_url = $7,
+			notes = $8,
+			next_touch_notified = CASE
+				WHEN next_touch_at IS DISTINCT FROM $9::date THEN FALSE
+				ELSE next_touch_notified
+			END,
+			next_touch_at = $9::date,
+			next_touch_note = $10
+		WHERE id = $1 AND user_id = $2
+	`
+	tag, err := tx.Exec(ctx, query,
+		contact.ID,
+		contact.UserID,
+		contact.Name,
+		nullableString(contact.Company),
+		nullableString(contact.Role),
+		nullableString(contact.Email),
+		nullableString(contact.LinkedInURL),
+		nullableString(contact.Notes),
+		nextTouch,
+		nullableString(contact.NextTouchNote),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, domain.ErrContactNotFound
+	}
+
+	if contact.JobIDs != nil {
+		if err := replaceContactJobs(ctx, tx, contact.UserID, contact.ID, contact.JobIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, contact.ID, contact.UserID)
+}
+
+func (r *contactRepository) Delete(ctx context.Context, contactID, userID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM contacts WHERE id = $1 AND user_id = $2`, contactID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrContactNotFound
+	}
+	return nil
+}
+
+func (r *contactRepository) GetByID(ctx context.Context, contactID, userID int32) (*domain.Contact, error) {
+	query := `SELECT ` + contactColumns + ` FROM contacts c WHERE c.id = $1 AND c.user_id = $2`
+	contact, err := scanContact(r.db.QueryRow(ctx, query, contactID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrContactNotFound
+		}
+		return nil, err
+	}
+	return contact, nil
+}
+
+func (r *contactRepository) List(ctx context.Context, userID int32, filter domain.ContactFilter) ([]domain.Contact, error) {
+	query := `SELECT ` + contactColumns + `
+		FROM contacts c
+		WHERE c.user_id = $1
+		  AND ($2::int IS NULL OR EXISTS (
+			SELECT 1 FROM contact_jobs cj WHERE cj.contact_id = c.id AND cj.job_id = $2::int
+		  ))
+		  AND ($3::text = '' OR
+			c.name ILIKE '%' || $3::text || '%' OR
+			c.company ILIKE '%' || $3::text || '%' OR
+			c.role ILIKE '%' || $3::text || '%')
+		ORDER BY c.name, c.id
+	`
+	rows, err := r.db.Query(ctx, query, userID, filter.JobID, filter.Query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := []domain.Contact{}
+	for rows.Next() {
+		contact, err := scanContact(rows)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, *contact)
+	}
+	return contacts, rows.Err()
+}
+
+func (r *contactRepository) LinkJob(ctx context.Context, contactID, jobID int32) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO contact_jobs (contact_id, job_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, contactID, jobID)
+	return err
+}
+
+func (r *contactRepository) UnlinkJob(ctx context.Context, contactID, jobID int32) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM contact_jobs WHERE contact_id = $1 AND job_id = $2`, contactID, jobID)
+	return err
+}
+
+func (r *contactRepository) AddInteraction(ctx context.Context, userID int32, interaction *domain.ContactInteraction) (*domain.ContactInteraction, error) {
+	const query = `
+		INSERT INTO contact_interactions (contact_id, user_id, type, occurred_at, notes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, contact_id, type, occurred_at, COALESCE(notes, ''), created_at
+	`
+	return scanInteraction(r.db.QueryRow(ctx, query,
+		interaction.ContactID,
+		userID,
+		interaction.Type,
+		PgTimeHelper(interaction.OccurredAt),
+		nullableString(interaction.Notes),
+	))
+}
+
+func (r *contactRepository) ListInteractions(ctx context.Context, contactID int32) ([]domain.ContactInteraction, error) {
+	const query = `
+		SELECT id, contact_id, type, occurred_at, COALESCE(notes, ''), created_at
+		FROM contact_interactions
+		WHERE contact_id = $1
+		ORDER BY occurred_at DESC, id DESC
+	`
+	rows, err := r.db.Query(ctx, query, contactID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	interactions := []domain.ContactInteraction{}
+	for rows.Next() {
+		interaction, err := scanInteraction(rows)
+		if err != nil {
+			return nil, err
+		}
+		interactions = append(interactions, *interaction)
+	}
+	return interactions, rows.Err()
+}
+
+func (r *contactRepository) DeleteInteraction(ctx context.Context, interactionID, contactID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM contact_interactions WHERE id = $1 AND contact_id = $2`, interactionID, contactID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInteractionNotFound
+	}
+	return nil
+}
+
+// replaceContactJobs sets the contact's job links, silently skipping jobs the
+// user does not own. Links to trashed jobs are left alone so they come back if
+// the job is restored.
+func replaceContactJobs(ctx context.Context, tx pgx.Tx, userID, contactID int32, jobIDs []int32) error {
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM contact_jobs cj
+		USING jobs j
+		WHERE cj.contact_id = $1 AND j.id = cj.job_id AND j.deleted_at IS NULL
+	`, contactID); err != nil {
+		return err
+	}
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO contact_jobs (contact_id, job_id)
+		SELECT $1, j.id FROM jobs j
+		WHERE j.id = ANY($2::int[]) AND j.user_id = $3 AND j.deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`, contactID, jobIDs, userID)
+	return err
+}
+
+func parseDate(value string) (pgtype.Date, error) {
+	if value == "" {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return pgtype.Date{}, domain.ErrInvalidDateFormat
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+func scanContact(scanner rowScanner) (*domain.Contact, error) {
+	var (
+		c                 domain.Contact
+		company           *string
+		role              *string
+		email             *string
+		linkedInURL       *string
+		notes             *string
+		nextTouchAt       pgtype.Date
+		nextTouchNote     *string
+		createdAt         pgtype.Timestamp
+		updatedAt         pgtype.Timestamp
+		lastInteractionAt pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&c.ID,
+		&c.UserID,
+		&c.Name,
+		&company,
+		&role,
+		&email,
+		&linkedInURL,
+		&notes,
+		&nextTouchAt,
+		&nextTouchNote,
+		&createdAt,
+		&updatedAt,
+		&c.JobIDs,
+		&lastInteractionAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.Company = derefString(company)
+	c.Role = derefString(role)
+	c.Email = derefString(email)
+	c.LinkedInURL = derefString(linkedInURL)
+	c.Notes = derefString(notes)
+	c.NextTouchNote = derefString(nextTouchNote)
+	c.CreatedAt = createdAt.Time
+	c.UpdatedAt = updatedAt.Time
+	if nextTouchAt.Valid {
+		c.NextTouchAt = nextTouchAt.Time.Format("2006-01-02")
+	}
+	if lastInteractionAt.Valid {
+		t := lastInteractionAt.Time
+		c.LastInteractionAt = &t
+	}
+	return &c, nil
+}
+
+func scanInteraction(scanner rowScanner) (*domain.ContactInteraction, error) {
+	var (
+		i          domain.ContactInteraction
+		occurredAt pgtype.Timestamp
+		createdAt  pgtype.Timestamp
+	)
+	if err := scanner.Scan(&i.ID, &i.ContactID, &i.Type, &occurredAt, &i.Notes, &createdAt); err != nil {
+		return nil, err
+	}
+	i.OccurredAt = occurredAt.Time
+	i.CreatedAt = createdAt.Time
+	return &i, nil
+}
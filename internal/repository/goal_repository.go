@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type GoalRepository interface {
+	ListGoals(ctx context.Context, userID int32) ([]domain.Goal, error)
+	GetGoal(ctx context.Context, goalID, userID int32) (*domain.Goal, error)
+	CreateGoal(ctx context.Context, goal *domain.Goal) (*domain.Goal, error)
+	UpdateGoal(ctx context.Context, goal *domain.Goal) (*domain.Goal, error)
+	DeleteGoal(ctx context.Context, goalID, userID int32) error
+	AddCheckIn(ctx context.Context, goalID, amount int32, note string) error
+	GetActivity(ctx context.Context, userID int32, from, to time.Time) (*domain.GoalActivity, error)
+	ListUsersWithGoals(ctx context.Context, localHour int) ([]int32, error)
+	MarkReachedNotified(ctx context.Context, goalID int32, periodStart time.Time) error
+	MarkAtRiskNotified(ctx context.Context, goalID int32, periodStart time.Time) error
+}
+
+type goalRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewGoalRepository(dbPool *pgxpool.Pool) GoalRepository {
+	return &goalRepository{db: dbPool}
+}
+
+// goalColumns is shared by every query that returns a goal; scanGoal reads them in this order.
+const goalColumns = `g.id, g.user_id, g.title, g.metric, g.target, g.period, g.reached_notified_for, g.at_risk_notified_for, g.created_at, g.updated_at`
+
+func (r *goalRepository) ListGoals(ctx context.Context, userID int32) ([]domain.Goal, error) {
+	query := `SELECT ` + goalColumns + ` FROM user_goals g WHERE g.user_id = $1 ORDER BY g.created_at, g.id`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	goals := []domain.Goal{}
+	for rows.Next() {
+		goal, err := scanGoal(rows)
+		if err != nil {
+			return nil, err
+		}
+		goals = append(goals, *goal)
+	}
+	return goals, rows.Err()
+}
+
+func (r *goalRepository) GetGoal(ctx context.Context, goalID, userID int32) (*domain.Goal, error) {
+	query := `SELECT ` + goalColumns + ` FROM user_goals g WHERE g.id = $1 AND g.user_id = $2`
+	goal, err := scanGoal(r.db.QueryRow(ctx, query, goalID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrGoalNotFound
+		}
+		return nil, err
+	}
+	return goal, nil
+}
+
+func (r *goalRepository) CreateGoal(ctx context.Context, goal *domain.Goal) (*domain.Goal, error) {
+	query := `
+		INSERT INTO user_goals AS g (user_id, title, metric, target, period)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + goalColumns
+	return scanGoal(r.db.QueryRow(ctx, query, goal.UserID, goal.Title, goal.Metric, goal.Target, goal.Period))
+}
+
+// UpdateGoal replaces the goal's settings. Changing what or over which period
+// it measures resets the notifications sent for the current period.
+func (r *goalRepository) UpdateGoal(ctx context.Context, goal *domain.Goal) (*domain.Goal, error) {
+	query := `
+		UPDATE user_goals AS g
+		SET title = $3, metric = $4, target = $5, period = $6,
+		    reached_notified_for = CASE WHEN (g.metric, g.target, g.period) = ($4::text, $5::int, $6::text) THEN g.reached_notified_for END,
+		    at_risk_notified_for = CASE WHEN (g.metric, g.target, g.period) = ($4::text, $5::int, $6::text) THEN g.at_risk_notified_for END
+		WHERE g.id = $1 AND g.user_id = $2
+		RETURNING ` + goalColumns
+	updated, err := scanGoal(r.db.QueryRow(ctx, query, goal.ID, goal.UserID, goal.Title, goal.Metric, goal.Target, goal.Period))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrGoalNotFound
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *goalRepository) DeleteGoal(ctx context.Context, goalID, userID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM user_goals WHERE id = $1 AND user_id = $2`, goalID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrGoalNotFound
+	}
+	return nil
+}
+
+func (r *goalRepository) AddCheckIn(ctx context.Context, goalID, amount int32, note string) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO goal_check_ins (goal_id, amount, note) VALUES ($1, $2, $3)`,
+		goalID, amount, nullableString(note),
+	)
+	return err
+}
+
+// GetActivity totals everything goals measure over [from, to), which start
+// at midnight in the user's time zone. A job counts as applied from its
+// date_applied, a day in the user's time zone, or otherwise from the first
+// time it left the saved status, as in the application analytics. Archived
+// jobs still count; trashed ones do not.
+func (r *goalRepository) GetActivity(ctx context.Context, userID int32, from, to time.Time) (*domain.GoalActivity, error) {
+	const query = `
+		SELECT
+			(SELECT COUNT(*) FROM (
+				SELECT COALESCE(j.date_applied AT TIME ZONE u.timezone AT TIME ZONE 'UTC', (
+					SELECT MIN(e.changed_at) FROM job_status_events e
+					WHERE e.job_id = j.id AND e.to_status <> 'saved'
+				)) AS applied_at
+				FROM jobs j
+				JOIN users u ON u.id = j.user_id
+				WHERE j.user_id = $1 AND j.deleted_at IS NULL
+			) a WHERE a.applied_at >= $2 AND a.applied_at < $3)::int,
+			(SELECT COUNT(DISTINCT e.job_id) FROM job_status_events e
+			 JOIN jobs j ON j.id = e.job_id
+			 WHERE e.user_id = $1 AND e.to_status = 'interview' AND j.deleted_at IS NULL
+			   AND e.changed_at >= $2 AND e.changed_at < $3)::int,
+			(SELECT COALESCE(SUM(dp.total_focus_seconds), 0) FROM daily_progress dp
+			 WHERE dp.user_id = $1 AND dp.date >= $4 AND dp.date < $5)::int,
+			(SELECT COALESCE(SUM(dp.sessions_completed), 0) FROM daily_progress dp
+			 WHERE dp.user_id = $1 AND dp.date >= $4 AND dp.date < $5)::int,
+			(SELECT COUNT(*) FROM contact_interactions ci
+			 WHERE ci.user_id = $1 AND ci.occurred_at >= $2 AND ci.occurred_at < $3)::int
+	`
+	activity := domain.GoalActivity{CheckIns: map[int32]int32{}}
+	// Timestamps are stored in UTC; daily_progress dates are the user's days.
+	err := r.db.QueryRow(ctx, query, userID, PgTimeHelper(from.UTC()), PgTimeHelper(to.UTC()),
+		pgtype.Date{Time: from, Valid: true}, pgtype.Date{Time: to, Valid: true},
+	).Scan(
+		&activity.Applications,
+		&activity.Interviews,
+		&activity.FocusSeconds,
+		&activity.FocusSessions,
+		&activity.Networking,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT c.goal_id, SUM(c.amount)::int
+		FROM goal_check_ins c
+		JOIN user_goals g ON g.id = c.goal_id
+		WHERE g.user_id = $1 AND c.logged_at >= $2 AND c.logged_at < $3
+		GROUP BY c.goal_id`,
+		userID, PgTimeHelper(from.UTC()), PgTimeHelper(to.UTC()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var goalID, amount int32
+		if err := rows.Scan(&goalID, &amount); err != nil {
+			return nil, err
+		}
+		activity.CheckIns[goalID] = amount
+	}
+	return &activity, rows.Err()
+}
+
+// ListUsersWithGoals returns the active users that have at least one goal
+// and for whom it is now localHour o'clock in their own time zone.
+func (r *goalRepository) ListUsersWithGoals(ctx context.Context, localHour int) ([]int32, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT g.user_id
+		FROM user_goals g
+		JOIN users u ON u.id = g.user_id
+		WHERE u.is_active = TRUE
+		  AND EXTRACT(HOUR FROM NOW() AT TIME ZONE u.timezone) = $1
+		ORDER BY g.user_id`, localHour)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
+
+func (r *goalRepository) MarkReachedNotified(ctx context.Context, goalID int32, periodStart time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE user_goals SET reached_notified_for = $2 WHERE id = $1`, goalID, pgtype.Date{Time: periodStart, Valid: true})
+	return err
+}
+
+func (r *goalRepository) MarkAtRiskNotified(ctx context.Context, goalID int32, periodStart time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE user_goals SET at_risk_notified_for = $2 WHERE id = $1`, goalID, pgtype.Date{Time: periodStart, Valid: true})
+	return err
+}
+
+func scanGoal(scanner rowScanner) (*domain.Goal, error) {
+	var (
+		goal                  domain.Goal
+		reachedFor, atRiskFor pgtype.Date
+		createdAt, updatedAt  pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&goal.ID,
+		&goal.UserID,
+		&goal.Title,
+		&goal.Metric,
+		&goal.Target,
+		&goal.Period,
+		&reachedFor,
+		&atRiskFor,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if reachedFor.Valid {
+		goal.ReachedNotifiedFor = &reachedFor.Time
+	}
+	if atRiskFor.Valid {
+		goal.AtRiskNotifiedFor = &atRiskFor.Time
+	}
+	goal.CreatedAt = createdAt.Time
+	goal.UpdatedAt = updatedAt.Time
+	return &goal, nil
+}
@@ -6,6 +6,7 @@ import (
 	"aiki/internal/pkg/response"
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -17,11 +18,22 @@ import (
 
 type HomeRepository interface {
 	// Focus Sessions
-	CreateSession(ctx context.Context, userID int32, durationSeconds int32) (*domain.FocusSession, error)
+	CreateSession(ctx context.Context, userID int32, req *domain.StartSessionRequest, at time.Time) (*domain.FocusSession, error)
 	GetSessionByID(ctx context.Context, sessionID int32) (*domain.FocusSession, error)
 	GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error)
-	UpdateSession(ctx context.Context, sessionID int32, elapsedSeconds int32, status domain.SessionStatus, endedAt *time.Time) (*domain.FocusSession, error)
-	GetUserSessionHistory(ctx context.Context, userID int32, limit, offset int32) ([]domain.FocusSession, error)
+	GetSessionSegments(ctx context.Context, sessionID int32) ([]domain.SessionSegment, error)
+	ResumeSession(ctx context.Context, sessionID int32, at time.Time) (*domain.FocusSession, error)
+	StopSession(ctx context.Context, sessionID int32, stop domain.SessionStop) (*domain.FocusSession, error)
+	GetUserSessionHistory(ctx context.Context, userID int32, filter domain.SessionFilter, limit, offset int32) ([]domain.FocusSession, error)
+	GetJobFocusTime(ctx context.Context, userID int32) ([]domain.JobFocusTime, error)
+	ListStaleSessions(ctx context.Context, now, pausedBefore time.Time, limit int32) ([]domain.FocusSession, error)
+
+	// Session presets
+	ListSessionPresets(ctx context.Context, userID int32) ([]domain.SessionPreset, error)
+	GetSessionPreset(ctx context.Context, presetID, userID int32) (*domain.SessionPreset, error)
+	CreateSessionPreset(ctx context.Context, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error)
+	UpdateSessionPreset(ctx context.Context, presetID, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error)
+	DeleteSessionPreset(ctx context.Context, presetID, userID int32) error
 
 	// Streaks
 	GetStreak(ctx context.Context, userID int32) (*domain.Streak, error)
@@ -32,6 +44,7 @@ type HomeRepository interface {
 	GetUserBadges(ctx context.Context, userID int32) ([]domain.UserBadge, error)
 	AwardBadge(ctx context.Context, userID, badgeID int32) error
 	GetUserBadgeCount(ctx context.Context, userID int32) (int32, error)
+	GetBadgeProgress(ctx context.Context, userID int32) (*domain.ProgressSummary, error)
 
 	// Progress
 	UpsertDailyProgress(ctx context.Context, userID int32, date time.Time, focusSeconds, sessions int32) error
@@ -52,75 +65,432 @@ func NewHomeRepository(dbPool *pgxpool.Pool) HomeRepository {
 // Focus Sessions
 // ─────────────────────────────────────────
 
-func (r *homeRepository) CreateSession(ctx context.Context, userID int32, durationSeconds int32) (*domain.FocusSession, error) {
-	session, err := r.queries.CreateFocusSession(ctx, db.CreateFocusSessionParams{
-		UserID:          userID,
-		DurationSeconds: durationSeconds,
-	})
+// focusSessionColumns are read by scanFocusSession in this order. The last is
+// when the session's running segment started, NULL while its clock is stopped.
+const focusSessionColumns = `s.id, s.user_id, s.duration_seconds, s.elapsed_seconds, s.reported_seconds, s.flagged,
+	s.job_id, s.goal_id, s.intention, s.reflection, s.focus_score,
+	s.mode, s.work_seconds, s.short_break_seconds, s.long_break_seconds, s.cycles, s.long_break_every, s.break_seconds, s.status,
+	s.started_at, s.ended_at, s.created_at, s.updated_at,
+	(SELECT g.started_at FROM focus_session_segments g WHERE g.session_id = s.id AND g.ended_at IS NULL ORDER BY g.started_at DESC LIMIT 1)`
+
+// CreateSession starts a session with its clock running from at.
+func (r *homeRepository) CreateSession(ctx context.Context, userID int32, req *domain.StartSessionRequest, at time.Time) (*domain.FocusSession, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var plan domain.PomodoroPlan
+	if req.Pomodoro != nil {
+		plan = *req.Pomodoro
+	}
+	var sessionID int32
+	err = tx.QueryRow(ctx, `
+		INSERT INTO focus_sessions (
+			user_id, duration_seconds, job_id, goal_id, intention, mode,
+			work_seconds, short_break_seconds, long_break_seconds, cycles, long_break_every,
+			status, started_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6,
+		        NULLIF($7, 0), NULLIF($8, 0), NULLIF($9, 0), NULLIF($10, 0), NULLIF($11, 0),
+		        'active', $12)
+		RETURNING id`,
+		userID, req.DurationSeconds, req.JobID, req.GoalID, req.Intention, req.Mode,
+		plan.WorkSeconds, plan.ShortBreakSeconds, plan.LongBreakSeconds, plan.Cycles, plan.LongBreakEvery,
+		PgTimeHelper(at.UTC()),
+	).Scan(&sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := startSegment(ctx, tx, sessionID, at); err != nil {
+		return nil, err
+	}
+
+	session, err := getSession(ctx, tx, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	return mapSession(session), nil
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
 }
 
 func (r *homeRepository) GetSessionByID(ctx context.Context, sessionID int32) (*domain.FocusSession, error) {
-	session, err := r.queries.GetFocusSessionByID(ctx, sessionID)
+	return getSession(ctx, r.db, sessionID)
+}
+
+func (r *homeRepository) GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error) {
+	session, err := scanFocusSession(r.db.QueryRow(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions s
+		WHERE s.user_id = $1 AND s.status IN ('active', 'paused')
+		ORDER BY s.started_at DESC
+		LIMIT 1`,
+		userID,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, response.ErrSessionNotFound
+			return nil, nil
 		}
 		return nil, err
 	}
-	return mapSession(session), nil
+	return session, nil
 }
 
-func (r *homeRepository) GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error) {
-	session, err := r.queries.GetActiveSession(ctx, userID)
+// GetSessionSegments returns the stretches the session's clock ran, oldest
+// first.
+func (r *homeRepository) GetSessionSegments(ctx context.Context, sessionID int32) ([]domain.SessionSegment, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT started_at, ended_at
+		FROM focus_session_segments
+		WHERE session_id = $1
+		ORDER BY started_at`,
+		sessionID,
+	)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+		return nil, err
+	}
+	defer rows.Close()
+
+	var segments []domain.SessionSegment
+	for rows.Next() {
+		var startedAt, endedAt pgtype.Timestamp
+		if err := rows.Scan(&startedAt, &endedAt); err != nil {
+			return nil, err
 		}
+		segments = append(segments, domain.SessionSegment{StartedAt: startedAt.Time, EndedAt: timestampPtr(endedAt)})
+	}
+	return segments, rows.Err()
+}
+
+// ResumeSession restarts a paused session's clock at at. It returns
+// response.ErrInvalidSessionStatus if the session is not paused.
+func (r *homeRepository) ResumeSession(ctx context.Context, sessionID int32, at time.Time) (*domain.FocusSession, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
 		return nil, err
 	}
-	return mapSession(session), nil
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `UPDATE focus_sessions SET status = 'active', updated_at = NOW() WHERE id = $1 AND status = 'paused'`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, response.ErrInvalidSessionStatus
+	}
+	if err := startSegment(ctx, tx, sessionID, at); err != nil {
+		return nil, err
+	}
+
+	session, err := getSession(ctx, tx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
 }
 
-func (r *homeRepository) UpdateSession(ctx context.Context, sessionID int32, elapsedSeconds int32, status domain.SessionStatus, endedAt *time.Time) (*domain.FocusSession, error) {
-	var pgEndedAt pgtype.Timestamp
-	if endedAt != nil {
-		pgEndedAt = pgtype.Timestamp{Time: *endedAt, Valid: true}
+// StopSession closes the session's running segment at stop.At and pauses or
+// ends it. A flag, once raised, stays; the reflection and focus score are
+// only written when the session ends. It returns
+// response.ErrInvalidSessionStatus if the session has already ended.
+func (r *homeRepository) StopSession(ctx context.Context, sessionID int32, stop domain.SessionStop) (*domain.FocusSession, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	session, err := r.queries.UpdateFocusSession(ctx, db.UpdateFocusSessionParams{
-		ID:             sessionID,
-		ElapsedSeconds: elapsedSeconds,
-		Status:         string(status),
-		EndedAt:        pgEndedAt,
-	})
+	ending := stop.Status != domain.SessionStatusPaused
+	var endedAt pgtype.Timestamp
+	if ending {
+		endedAt = PgTimeHelper(stop.At.UTC())
+	}
+	tag, err := tx.Exec(ctx, `
+		UPDATE focus_sessions
+		SET status           = $2,
+		    elapsed_seconds  = $3,
+		    break_seconds    = $10,
+		    reported_seconds = $4,
+		    flagged          = flagged OR $5,
+		    ended_at         = $6,
+		    reflection       = CASE WHEN $7 THEN $8 ELSE reflection END,
+		    focus_score      = CASE WHEN $7 THEN $9 ELSE focus_score END,
+		    updated_at       = NOW()
+		WHERE id = $1 AND status IN ('active', 'paused')`,
+		sessionID, string(stop.Status), stop.ElapsedSeconds, stop.ReportedSeconds, stop.Flagged, endedAt,
+		ending, stop.Reflection, stop.FocusScore, stop.BreakSeconds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, response.ErrInvalidSessionStatus
+	}
+	_, err = tx.Exec(ctx,
+		`UPDATE focus_session_segments SET ended_at = $2 WHERE session_id = $1 AND ended_at IS NULL`,
+		sessionID, PgTimeHelper(stop.At.UTC()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := getSession(ctx, tx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *homeRepository) GetUserSessionHistory(ctx context.Context, userID int32, filter domain.SessionFilter, limit, offset int32) ([]domain.FocusSession, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions s
+		WHERE s.user_id = $1
+		  AND ($4::int IS NULL OR s.job_id = $4::int)
+		  AND ($5::int IS NULL OR s.goal_id = $5::int)
+		  AND ($6::text = '' OR s.status = $6::text)
+		  AND ($7::text = '' OR
+			s.intention ILIKE '%' || $7::text || '%' ESCAPE '\' OR
+			s.reflection ILIKE '%' || $7::text || '%' ESCAPE '\')
+		  AND ($8::int IS NULL OR s.focus_score >= $8::int)
+		ORDER BY s.started_at DESC
+		LIMIT $2 OFFSET $3`,
+		userID, limit, offset, filter.JobID, filter.GoalID, string(filter.Status), escapeLike(filter.Query), filter.MinFocusScore,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []domain.FocusSession{}
+	for rows.Next() {
+		session, err := scanFocusSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, rows.Err()
+}
+
+// GetJobFocusTime totals the focus time of the user's completed sessions per
+// tracked job, most worked on first. Jobs in the trash are left out.
+func (r *homeRepository) GetJobFocusTime(ctx context.Context, userID int32) ([]domain.JobFocusTime, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT j.id, j.title, COALESCE(j.company_name, ''),
+		       SUM(s.elapsed_seconds)::bigint, COUNT(*),
+		       AVG(s.focus_score)::float8, MAX(s.started_at)
+		FROM focus_sessions s
+		JOIN jobs j ON j.id = s.job_id AND j.deleted_at IS NULL
+		WHERE s.user_id = $1 AND s.status = 'completed'
+		GROUP BY j.id, j.title, j.company_name
+		ORDER BY SUM(s.elapsed_seconds) DESC, MAX(s.started_at) DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []domain.JobFocusTime{}
+	for rows.Next() {
+		var (
+			t        domain.JobFocusTime
+			avgScore pgtype.Float8
+			last     pgtype.Timestamp
+		)
+		if err := rows.Scan(&t.JobID, &t.JobTitle, &t.CompanyName, &t.TotalFocusSeconds, &t.Sessions, &avgScore, &last); err != nil {
+			return nil, err
+		}
+		if avgScore.Valid {
+			t.AverageFocusScore = &avgScore.Float64
+		}
+		t.LastSessionAt = last.Time
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
+
+// ListStaleSessions returns the sessions left behind by a client that went
+// away: active ones whose clock has run their planned duration by now, and
+// paused ones whose clock stopped before pausedBefore.
+func (r *homeRepository) ListStaleSessions(ctx context.Context, now, pausedBefore time.Time, limit int32) ([]domain.FocusSession, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+focusSessionColumns+`
+		FROM focus_sessions s
+		WHERE (s.status = 'active' AND (
+		        SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(g.ended_at, $1) - g.started_at)), 0)
+		        FROM focus_session_segments g
+		        WHERE g.session_id = s.id
+		      ) >= s.duration_seconds)
+		   OR (s.status = 'paused' AND COALESCE(
+		        (SELECT MAX(g.ended_at) FROM focus_session_segments g WHERE g.session_id = s.id),
+		        s.updated_at
+		      ) < $2)
+		ORDER BY s.started_at
+		LIMIT $3`,
+		PgTimeHelper(now.UTC()), PgTimeHelper(pausedBefore.UTC()), limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []domain.FocusSession{}
+	for rows.Next() {
+		session, err := scanFocusSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, *session)
+	}
+	return sessions, rows.Err()
+}
+
+// queryer is a pool or a transaction.
+type queryer interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func getSession(ctx context.Context, q queryer, sessionID int32) (*domain.FocusSession, error) {
+	session, err := scanFocusSession(q.QueryRow(ctx,
+		`SELECT `+focusSessionColumns+` FROM focus_sessions s WHERE s.id = $1`,
+		sessionID,
+	))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, response.ErrSessionNotFound
 		}
 		return nil, err
 	}
-	return mapSession(session), nil
+	return session, nil
 }
 
-func (r *homeRepository) GetUserSessionHistory(ctx context.Context, userID int32, limit, offset int32) ([]domain.FocusSession, error) {
-	rows, err := r.queries.GetUserSessionHistory(ctx, db.GetUserSessionHistoryParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
-	})
+func startSegment(ctx context.Context, tx pgx.Tx, sessionID int32, at time.Time) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO focus_session_segments (session_id, started_at) VALUES ($1, $2)`,
+		sessionID, PgTimeHelper(at.UTC()),
+	)
+	return err
+}
+
+// ─────────────────────────────────────────
+// Session presets
+// ─────────────────────────────────────────
+
+const sessionPresetColumns = `p.id, p.user_id, p.name, p.work_seconds, p.short_break_seconds, p.long_break_seconds,
+	p.cycles, p.long_break_every, p.created_at, p.updated_at`
+
+func (r *homeRepository) ListSessionPresets(ctx context.Context, userID int32) ([]domain.SessionPreset, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+sessionPresetColumns+` FROM focus_session_presets p WHERE p.user_id = $1 ORDER BY p.name, p.id`,
+		userID,
+	)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	sessions := make([]domain.FocusSession, len(rows))
-	for i, row := range rows {
-		sessions[i] = *mapSession(row)
+	presets := []domain.SessionPreset{}
+	for rows.Next() {
+		preset, err := scanSessionPreset(rows)
+		if err != nil {
+			return nil, err
+		}
+		presets = append(presets, *preset)
 	}
-	return sessions, nil
+	return presets, rows.Err()
+}
+
+func (r *homeRepository) GetSessionPreset(ctx context.Context, presetID, userID int32) (*domain.SessionPreset, error) {
+	preset, err := scanSessionPreset(r.db.QueryRow(ctx,
+		`SELECT `+sessionPresetColumns+` FROM focus_session_presets p WHERE p.id = $1 AND p.user_id = $2`,
+		presetID, userID,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSessionPresetNotFound
+		}
+		return nil, err
+	}
+	return preset, nil
+}
+
+func (r *homeRepository) CreateSessionPreset(ctx context.Context, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error) {
+	return scanSessionPreset(r.db.QueryRow(ctx, `
+		INSERT INTO focus_session_presets AS p
+			(user_id, name, work_seconds, short_break_seconds, long_break_seconds, cycles, long_break_every)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+sessionPresetColumns,
+		userID, req.Name, req.WorkSeconds, req.ShortBreakSeconds, req.LongBreakSeconds, req.Cycles, req.LongBreakEvery,
+	))
+}
+
+func (r *homeRepository) UpdateSessionPreset(ctx context.Context, presetID, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error) {
+	preset, err := scanSessionPreset(r.db.QueryRow(ctx, `
+		UPDATE focus_session_presets AS p
+		SET name                = $3,
+		    work_seconds        = $4,
+		    short_break_seconds = $5,
+		    long_break_seconds  = $6,
+		    cycles              = $7,
+		    long_break_every    = $8
+		WHERE p.id = $1 AND p.user_id = $2
+		RETURNING `+sessionPresetColumns,
+		presetID, userID, req.Name, req.WorkSeconds, req.ShortBreakSeconds, req.LongBreakSeconds, req.Cycles, req.LongBreakEvery,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSessionPresetNotFound
+		}
+		return nil, err
+	}
+	return preset, nil
+}
+
+func (r *homeRepository) DeleteSessionPreset(ctx context.Context, presetID, userID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM focus_session_presets WHERE id = $1 AND user_id = $2`, presetID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSessionPresetNotFound
+	}
+	return nil
+}
+
+func scanSessionPreset(scanner rowScanner) (*domain.SessionPreset, error) {
+	var (
+		preset               domain.SessionPreset
+		createdAt, updatedAt pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&preset.ID,
+		&preset.UserID,
+		&preset.Name,
+		&preset.WorkSeconds,
+		&preset.ShortBreakSeconds,
+		&preset.LongBreakSeconds,
+		&preset.Cycles,
+		&preset.LongBreakEvery,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	preset.CreatedAt = createdAt.Time
+	preset.UpdatedAt = updatedAt.Time
+	return &preset, nil
 }
 
 // ─────────────────────────────────────────
@@ -215,6 +585,23 @@ func (r *homeRepository) GetUserBadgeCount(ctx context.Context, userID int32) (i
 	return int32(count), nil
 }
 
+// GetBadgeProgress totals the user's completed sessions and their focus time
+// for the session and focus time badges. Flagged sessions are left out.
+func (r *homeRepository) GetBadgeProgress(ctx context.Context, userID int32) (*domain.ProgressSummary, error) {
+	var summary domain.ProgressSummary
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(elapsed_seconds), 0)
+		FROM focus_sessions
+		WHERE user_id = $1 AND status = 'completed' AND NOT flagged`,
+		userID,
+	).Scan(&summary.SessionsCompleted, &summary.TotalFocusSeconds)
+	if err != nil {
+		return nil, err
+	}
+	summary.TotalFocusHours = float64(summary.TotalFocusSeconds) / 3600.0
+	return &summary, nil
+}
+
 // ─────────────────────────────────────────
 // Progress
 // ─────────────────────────────────────────
@@ -290,22 +677,65 @@ func (r *homeRepository) GetDailyProgressRange(ctx context.Context, userID int32
 // Mappers
 // ─────────────────────────────────────────
 
-func mapSession(s db.FocusSession) *domain.FocusSession {
-	var endedAt *time.Time
-	if s.EndedAt.Valid {
-		endedAt = &s.EndedAt.Time
+func scanFocusSession(scanner rowScanner) (*domain.FocusSession, error) {
+	var (
+		session                                                domain.FocusSession
+		status                                                 string
+		startedAt, endedAt, createdAt, updatedAt, runningSince pgtype.Timestamp
+		focusScore                                             pgtype.Int2
+		mode                                                   string
+		work, shortBreak, longBreak, cycles, longBreakEvery    pgtype.Int4
+	)
+	err := scanner.Scan(
+		&session.ID,
+		&session.UserID,
+		&session.DurationSeconds,
+		&session.ElapsedSeconds,
+		&session.ReportedSeconds,
+		&session.Flagged,
+		&session.JobID,
+		&session.GoalID,
+		&session.Intention,
+		&session.Reflection,
+		&focusScore,
+		&mode,
+		&work,
+		&shortBreak,
+		&longBreak,
+		&cycles,
+		&longBreakEvery,
+		&session.BreakSeconds,
+		&status,
+		&startedAt,
+		&endedAt,
+		&createdAt,
+		&updatedAt,
+		&runningSince,
+	)
+	if err != nil {
+		return nil, err
 	}
-	return &domain.FocusSession{
-		ID:              s.ID,
-		UserID:          s.UserID,
-		DurationSeconds: s.DurationSeconds,
-		ElapsedSeconds:  s.ElapsedSeconds,
-		Status:          domain.SessionStatus(s.Status),
-		StartedAt:       s.StartedAt.Time,
-		EndedAt:         endedAt,
-		CreatedAt:       s.CreatedAt.Time,
-		UpdatedAt:       s.UpdatedAt.Time,
+	session.Status = domain.SessionStatus(status)
+	session.StartedAt = startedAt.Time
+	session.EndedAt = timestampPtr(endedAt)
+	session.CreatedAt = createdAt.Time
+	session.UpdatedAt = updatedAt.Time
+	session.RunningSince = timestampPtr(runningSince)
+	if focusScore.Valid {
+		score := int32(focusScore.Int16)
+		session.FocusScore = &score
 	}
+	session.Mode = mode
+	if mode == domain.SessionModePomodoro {
+		session.Pomodoro = &domain.PomodoroPlan{
+			WorkSeconds:       work.Int32,
+			ShortBreakSeconds: shortBreak.Int32,
+			LongBreakSeconds:  longBreak.Int32,
+			Cycles:            cycles.Int32,
+			LongBreakEvery:    longBreakEvery.Int32,
+		}
+	}
+	return &session, nil
 }
 
 func mapStreak(s db.Streak) *domain.Streak {
@@ -323,6 +753,14 @@ func mapStreak(s db.Streak) *domain.Streak {
 	}
 }
 
+// likeEscaper escapes the characters LIKE treats specially, so text typed by a
+// user is matched as it is.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
 func derefString(s *string) string {
 	if s == nil {
 		return ""
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "cv", escapeLike("cv"))
+	assert.Equal(t, `100\%`, escapeLike("100%"))
+	assert.Equal(t, `my\_job`, escapeLike("my_job"))
+	assert.Equal(t, `a\\b`, escapeLike(`a\b`))
+}
+
+// seedFocusUser creates a user with a tracked job to link sessions to.
+func seedFocusUser(t *testing.T, pool *pgxpool.Pool, email string) (userID, jobID int32) {
+	ctx := context.Background()
+	user, err := NewUserRepository(pool).Create(ctx, &domain.User{Email: email}, "hashed_password")
+	require.NoError(t, err)
+	jobID, err = NewJobRepository(pool).Create(ctx, &domain.Job{
+		UserId:      user.ID,
+		Title:       "Backend Engineer",
+		CompanyName: "Flutterwave",
+		Status:      "applied",
+		DateApplied: "2026-06-01",
+	})
+	require.NoError(t, err)
+	return user.ID, jobID
+}
+
+// endSession runs a session for seconds and ends it with status.
+func endSession(t *testing.T, repo HomeRepository, userID int32, req *domain.StartSessionRequest, seconds int32, status domain.SessionStatus, stop domain.SessionStop) *domain.FocusSession {
+	ctx := context.Background()
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	req.Mode = domain.SessionModeSingle
+	if req.DurationSeconds == 0 {
+		req.DurationSeconds = 1500
+	}
+	session, err := repo.CreateSession(ctx, userID, req, start)
+	require.NoError(t, err)
+	stop.Status = status
+	stop.ElapsedSeconds = seconds
+	stop.At = start.Add(time.Duration(seconds) * time.Second)
+	ended, err := repo.StopSession(ctx, session.ID, stop)
+	require.NoError(t, err)
+	return ended
+}
+
+func TestHomeRepository_StopSessionKeepsReflectionForTheEnd(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewHomeRepository(pool)
+	ctx := context.Background()
+	userID, _ := seedFocusUser(t, pool, "reflect@test.com")
+
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	session, err := repo.CreateSession(ctx, userID, &domain.StartSessionRequest{Mode: domain.SessionModeSingle, DurationSeconds: 1500}, start)
+	require.NoError(t, err)
+
+	score := int32(4)
+	paused, err := repo.StopSession(ctx, session.ID, domain.SessionStop{
+		Status: domain.SessionStatusPaused, ElapsedSeconds: 600,
+		Reflection: "too early", FocusScore: &score, At: start.Add(10 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, paused.Reflection)
+	assert.Nil(t, paused.FocusScore)
+
+	_, err = repo.ResumeSession(ctx, session.ID, start.Add(15*time.Minute))
+	require.NoError(t, err)
+	ended, err := repo.StopSession(ctx, session.ID, domain.SessionStop{
+		Status: domain.SessionStatusCompleted, ElapsedSeconds: 1500,
+		Reflection: "tailored the CV", FocusScore: &score, At: start.Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tailored the CV", ended.Reflection)
+	require.NotNil(t, ended.FocusScore)
+	assert.Equal(t, score, *ended.FocusScore)
+}
+
+func TestHomeRepository_SessionHistoryFilters(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewHomeRepository(pool)
+	ctx := context.Background()
+	userID, jobID := seedFocusUser(t, pool, "history@test.com")
+
+	high, low := int32(5), int32(2)
+	endSession(t, repo, userID, &domain.StartSessionRequest{JobID: &jobID, Intention: "tailor CV for 100% match"}, 1500,
+		domain.SessionStatusCompleted, domain.SessionStop{FocusScore: &high})
+	endSession(t, repo, userID, &domain.StartSessionRequest{Intention: "practice interview"}, 600,
+		domain.SessionStatusAbandoned, domain.SessionStop{Reflection: "got distracted", FocusScore: &low})
+	endSession(t, repo, userID, &domain.StartSessionRequest{Intention: "cover_letter draft"}, 900,
+		domain.SessionStatusCompleted, domain.SessionStop{})
+
+	history := func(filter domain.SessionFilter) []string {
+		sessions, err := repo.GetUserSessionHistory(ctx, userID, filter, 10, 0)
+		require.NoError(t, err)
+		intentions := []string{}
+		for _, s := range sessions {
+			intentions = append(intentions, s.Intention)
+		}
+		return intentions
+	}
+
+	assert.Len(t, history(domain.SessionFilter{}), 3)
+	assert.Equal(t, []string{"tailor CV for 100% match"}, history(domain.SessionFilter{JobID: &jobID}))
+	assert.Equal(t, []string{"practice interview"}, history(domain.SessionFilter{Status: domain.SessionStatusAbandoned}))
+	assert.Equal(t, []string{"practice interview"}, history(domain.SessionFilter{Query: "DISTRACTED"}))
+	assert.Equal(t, []string{"tailor CV for 100% match"}, history(domain.SessionFilter{MinFocusScore: &high}))
+
+	// Wildcards typed by the user match only themselves.
+	assert.Equal(t, []string{"tailor CV for 100% match"}, history(domain.SessionFilter{Query: "100%"}))
+	assert.Equal(t, []string{"tailor CV for 100% match"}, history(domain.SessionFilter{Query: "%"}))
+	assert.Equal(t, []string{"cover_letter draft"}, history(domain.SessionFilter{Query: "_"}))
+}
+
+func TestHomeRepository_GetJobFocusTime(t *testing.T) {
+	pool := setupTestDB(t)
+	repo := NewHomeRepository(pool)
+	ctx := context.Background()
+	userID, jobID := seedFocusUser(t, pool, "jobtime@test.com")
+
+	four, two := int32(4), int32(2)
+	endSession(t, repo, userID, &domain.StartSessionRequest{JobID: &jobID}, 1500,
+		domain.SessionStatusCompleted, domain.SessionStop{FocusScore: &four})
+	endSession(t, repo, userID, &domain.StartSessionRequest{JobID: &jobID}, 900,
+		domain.SessionStatusCompleted, domain.SessionStop{FocusScore: &two})
+	// Neither an abandoned session nor one without a job counts.
+	endSession(t, repo, userID, &domain.StartSessionRequest{JobID: &jobID}, 600,
+		domain.SessionStatusAbandoned, domain.SessionStop{})
+	endSession(t, repo, userID, &domain.StartSessionRequest{}, 1200,
+		domain.SessionStatusCompleted, domain.SessionStop{})
+
+	totals, err := repo.GetJobFocusTime(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, totals, 1)
+	assert.Equal(t, jobID, totals[0].JobID)
+	assert.Equal(t, "Flutterwave", totals[0].CompanyName)
+	assert.Equal(t, int64(2400), totals[0].TotalFocusSeconds)
+	assert.Equal(t, int64(2), totals[0].Sessions)
+	require.NotNil(t, totals[0].AverageFocusScore)
+	assert.InDelta(t, 3.0, *totals[0].AverageFocusScore, 0.001)
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -19,17 +20,33 @@ type JobRepository interface {
 	Update(ctx context.Context, jobId int32, job *domain.Job) error
 	DeleteJob(ctx context.Context, jobId int32) error
 	GetJobByID(ctx context.Context, jobId int32) (*domain.Job, error)
-	GetAllJobs(ctx context.Context, userId int32) ([]db.Job, error)
+	GetAllJobs(ctx context.Context, userId int32, filter domain.JobFilter) ([]domain.Job, error)
+	GetUserTags(ctx context.Context, userId int32) ([]domain.TagCount, error)
+	MoveJob(ctx context.Context, userId, jobId, stageId int32, status string, position int32) error
+	ArchiveJob(ctx context.Context, userId, jobId int32) error
+	RestoreJob(ctx context.Context, userId, jobId int32) error
+	ListTrash(ctx context.Context, userId int32) ([]domain.Job, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error)
+	ListDuplicateCandidates(ctx context.Context, userId int32) ([]domain.Job, error)
+	MergeJobs(ctx context.Context, userId, targetId, sourceId int32, merged *domain.Job) error
 }
 
 type jobRepository struct {
-	db *db.Queries
+	pool *pgxpool.Pool
+	db   *db.Queries
 }
 
 func NewJobRepository(dbPool *pgxpool.Pool) JobRepository {
-	return &jobRepository{db: db.New(dbPool)}
+	return &jobRepository{pool: dbPool, db: db.New(dbPool)}
 }
 
+// jobColumns is shared by every query that returns full jobs; scanJob reads them in this order.
+const jobColumns = `
+	j.id, j.user_id, j.title, j.company_name, j.notes, j.link, j.location, j.platform,
+	j.date_applied, j.status, j.created_at, j.priority, j.stage_id, j.board_position,
+	j.archived_at, j.deleted_at, j.ghosted_at,
+	COALESCE((SELECT array_agg(t.tag ORDER BY t.tag) FROM job_tags t WHERE t.job_id = j.id), '{}') AS tags`
+
 func (jr *jobRepository) Create(ctx context.Context, job *domain.Job) (int32, error) {
 	var dateApplied pgtype.Timestamp
 	if job.DateApplied != "" {
@@ -40,25 +57,71 @@ func (jr *jobRepository) Create(ctx context.Context, job *domain.Job) (int32, er
 		dateApplied = PgTimeHelper(t)
 	}
 
-	newJob := db.CreateJobParams{
-		UserID:      job.UserId,
-		Title:       job.Title,
-		CompanyName: &job.CompanyName,
-		Notes:       &job.Notes,
-		Link:        nullableString(job.Link),
-		Location:    &job.Location,
-		Platform:    &job.Platform,
-		DateApplied: dateApplied,
-		Status:      job.Status,
+	tx, err := jr.pool.Begin(ctx)
+	if err != nil {
+		return 0, domain.ErrFailedToCreateJob
 	}
-	createdJob, err := jr.db.CreateJob(ctx, newJob)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// The stage is kept consistent with the status: a requested stage is used
+	// only if it matches, otherwise the first stage for the status is picked.
+	// New jobs go to the bottom of their board column.
+	const query = `
+		WITH target AS (
+			SELECT COALESCE(
+				(SELECT ps.id FROM pipeline_stages ps
+				 WHERE ps.id = $11::int AND ps.user_id = $1 AND ps.base_status = $9::text),
+				(SELECT ps.id FROM pipeline_stages ps
+				 WHERE ps.user_id = $1 AND ps.base_status = $9::text
+				 ORDER BY ps.position, ps.id LIMIT 1)
+			) AS stage_id
+		)
+		INSERT INTO jobs (
+			user_id, title, company_name, notes, link, location, platform,
+			date_applied, status, priority, stage_id, board_position
+		)
+		SELECT
+			$1, $2, $3, $4, $5, $6, $7, $8, $9::text, $10, target.stage_id,
+			COALESCE((
+				SELECT MAX(other.board_position) + 1 FROM jobs other
+				WHERE other.user_id = $1 AND other.stage_id IS NOT DISTINCT FROM target.stage_id
+			), 0)
+		FROM target
+		RETURNING id
+	`
+	var jobID int32
+	err = tx.QueryRow(ctx, query,
+		job.UserId,
+		job.Title,
+		&job.CompanyName,
+		&job.Notes,
+		nullableString(job.Link),
+		&job.Location,
+		&job.Platform,
+		dateApplied,
+		job.Status,
+		job.Priority,
+		job.StageID,
+	).Scan(&jobID)
 	if err != nil {
 		fmt.Println("failed to create job, error:", err)
 		return 0, domain.ErrFailedToCreateJob
 	}
-	return createdJob.ID, nil
+
+	if err := replaceJobTags(ctx, tx, job.UserId, jobID, job.Tags); err != nil {
+		fmt.Println("failed to save job tags, error:", err)
+		return 0, domain.ErrFailedToCreateJob
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, domain.ErrFailedToCreateJob
+	}
+	return jobID, nil
 }
 
+// Update overwrites the job's fields. Tags are only replaced when job.Tags is
+// non-nil. The stage follows the same rule as Create, and a job that changes
+// stage moves to the bottom of its new column.
 func (jr *jobRepository) Update(ctx context.Context, jobId int32, job *domain.Job) error {
 	var dateApplied pgtype.Timestamp
 	if job.DateApplied != "" {
@@ -68,58 +131,486 @@ func (jr *jobRepository) Update(ctx context.Context, jobId int32, job *domain.Jo
 		}
 		dateApplied = PgTimeHelper(t)
 	}
-	err := jr.db.UpdateJobByID(ctx, db.UpdateJobByIDParams{
-		ID:          jobId,
-		Title:       &job.Title,
-		CompanyName: &job.CompanyName,
-		Notes:       &job.Notes,
-		Link:        nullableString(job.Link),
-		Location:    &job.Location,
-		Platform:    &job.Platform,
-		DateApplied: dateApplied,
-		Status:      &job.Status,
-	})
+
+	tx, err := jr.pool.Begin(ctx)
+	if err != nil {
+		return domain.ErrFailedToUpdateJob
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	const query = `
+		WITH target AS (
+			SELECT COALESCE(
+				(SELECT ps.id FROM pipeline_stages ps JOIN jobs j ON j.user_id = ps.user_id
+				 WHERE j.id = $1 AND ps.id = $11::int AND ps.base_status = $9::text),
+				(SELECT ps.id FROM pipeline_stages ps JOIN jobs j ON j.user_id = ps.user_id
+				 WHERE j.id = $1 AND ps.base_status = $9::text
+				 ORDER BY ps.position, ps.id LIMIT 1)
+			) AS stage_id
+		)
+		UPDATE jobs AS cur
+		SET
+			title = COALESCE($2::text, title),
+			company_name = COALESCE($3::text, company_name),
+			notes = COALESCE($4::text, notes),
+			link = COALESCE($5::text, link),
+			location = COALESCE($6::text, location),
+			platform = COALESCE($7::text, platform),
+			date_applied = COALESCE($8::timestamp, date_applied),
+			status = COALESCE($9::text, status),
+			ghosted_at = CASE WHEN COALESCE($9::text, cur.status) = cur.status THEN cur.ghosted_at END,
+			priority = $10,
+			board_position = CASE
+				WHEN cur.stage_id IS DISTINCT FROM target.stage_id THEN COALESCE((
+					SELECT MAX(other.board_position) + 1 FROM jobs other
+					WHERE other.user_id = cur.user_id AND other.stage_id = target.stage_id
+				), 0)
+				ELSE cur.board_position
+			END,
+			stage_id = target.stage_id,
+			updated_at = NOW()
+		FROM target
+		WHERE cur.id = $1
+		RETURNING cur.user_id
+	`
+	var userID int32
+	err = tx.QueryRow(ctx, query,
+		jobId,
+		&job.Title,
+		&job.CompanyName,
+		&job.Notes,
+		nullableString(job.Link),
+		&job.Location,
+		&job.Platform,
+		dateApplied,
+		&job.Status,
+		job.Priority,
+		job.StageID,
+	).Scan(&userID)
 	if err != nil {
 		fmt.Println("failed to update job with id:", jobId, err)
 		return domain.ErrFailedToUpdateJob
 	}
+
+	if job.Tags != nil {
+		if err := replaceJobTags(ctx, tx, userID, jobId, job.Tags); err != nil {
+			fmt.Println("failed to update tags for job with id:", jobId, err)
+			return domain.ErrFailedToUpdateJob
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.ErrFailedToUpdateJob
+	}
 	return nil
 }
 
+// DeleteJob moves the job to the trash. It stays restorable until PurgeTrash
+// removes it.
 func (jr *jobRepository) DeleteJob(ctx context.Context, jobId int32) error {
-	err := jr.db.DeleteJobByID(ctx, jobId)
+	tag, err := jr.pool.Exec(ctx, `
+		UPDATE jobs SET deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+	`, jobId)
 	if err != nil {
 		fmt.Println("failed to delete job with id:", jobId, err)
 		return domain.ErrFailedToUpdateJob
 	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
+	}
 	return nil
 }
 
 func (jr *jobRepository) GetJobByID(ctx context.Context, jobId int32) (*domain.Job, error) {
-	job, err := jr.db.GetJobByID(ctx, jobId)
+	query := `SELECT ` + jobColumns + ` FROM jobs j WHERE j.id = $1 AND j.deleted_at IS NULL`
+	job, err := scanJob(jr.pool.QueryRow(ctx, query, jobId))
 	if err != nil {
 		return &domain.Job{}, domain.ErrInvalidJobID
 	}
-	out := &domain.Job{
-		ID:          jobId,
-		UserId:      job.UserID,
-		Title:       job.Title,
-		CompanyName: derefString(job.CompanyName),
-		Notes:       derefString(job.Notes),
-		Location:    derefString(job.Location),
-		Status:      job.Status,
-		Link:        derefString(job.Link),
-		Platform:    derefString(job.Platform),
-		CreatedAt:   job.CreatedAt.Time,
+	return job, nil
+}
+
+func (jr *jobRepository) GetAllJobs(ctx context.Context, userId int32, filter domain.JobFilter) ([]domain.Job, error) {
+	var tags []string
+	if len(filter.Tags) > 0 {
+		tags = domain.NormalizeTags(filter.Tags)
+	}
+
+	query := `SELECT ` + jobColumns + `
+		FROM jobs j
+		WHERE j.user_id = $1
+		  AND j.deleted_at IS NULL
+		  AND (j.archived_at IS NOT NULL) = $6::bool
+		  AND ($2::text[] IS NULL OR (
+			SELECT COUNT(*) FROM job_tags t
+			WHERE t.job_id = j.id AND t.tag = ANY($2::text[])
+		  ) = cardinality($2::text[]))
+		  AND ($3::int IS NULL OR j.priority = $3::int)
+		  AND ($4::int IS NULL OR j.stage_id = $4::int)
+		  AND ($5::text = '' OR j.status = $5::text)
+		ORDER BY j.id
+	`
+	rows, err := jr.pool.Query(ctx, query, userId, tags, filter.Priority, filter.StageID, filter.Status, filter.Archived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+func (jr *jobRepository) GetUserTags(ctx context.Context, userId int32) ([]domain.TagCount, error) {
+	const query = `
+		SELECT tag, COUNT(*)::int
+		FROM job_tags
+		WHERE user_id = $1
+		GROUP BY tag
+		ORDER BY COUNT(*) DESC, tag
+	`
+	rows, err := jr.pool.Query(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []domain.TagCount{}
+	for rows.Next() {
+		var tc domain.TagCount
+		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tc)
+	}
+	return tags, rows.Err()
+}
+
+// MoveJob places a job in a stage at the given zero-based position and
+// renumbers the destination column so the persisted order has no gaps.
+func (jr *jobRepository) MoveJob(ctx context.Context, userId, jobId, stageId int32, status string, position int32) error {
+	tx, err := jr.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the destination column so concurrent drags cannot interleave.
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM jobs
+		WHERE user_id = $1 AND stage_id = $2 AND id <> $3
+		  AND archived_at IS NULL AND deleted_at IS NULL
+		ORDER BY board_position, id
+		FOR UPDATE
+	`, userId, stageId, jobId)
+	if err != nil {
+		return err
+	}
+	var column []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		column = append(column, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if int(position) > len(column) {
+		position = int32(len(column))
+	}
+	ordered := make([]int32, 0, len(column)+1)
+	ordered = append(ordered, column[:position]...)
+	ordered = append(ordered, jobId)
+	ordered = append(ordered, column[position:]...)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE jobs
+		SET stage_id = $3,
+			ghosted_at = CASE WHEN status = $4::text THEN ghosted_at END,
+			status = $4::text,
+			date_applied = CASE WHEN $4::text = 'applied' AND date_applied IS NULL THEN NOW() ELSE date_applied END,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, jobId, userId, stageId, status)
+	if err != nil {
+		return err
 	}
-	if job.DateApplied.Valid {
-		out.DateApplied = job.DateApplied.Time.Format("2006-01-02")
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
 	}
-	return out, nil
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE jobs j
+		SET board_position = o.pos - 1
+		FROM unnest($1::int[]) WITH ORDINALITY AS o(id, pos)
+		WHERE j.id = o.id
+	`, ordered); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
-func (jr *jobRepository) GetAllJobs(ctx context.Context, userId int32) ([]db.Job, error) {
-	return jr.db.GetJobs(ctx, userId)
+func (jr *jobRepository) ArchiveJob(ctx context.Context, userId, jobId int32) error {
+	tag, err := jr.pool.Exec(ctx, `
+		UPDATE jobs SET archived_at = COALESCE(archived_at, NOW()), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+	`, jobId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
+	}
+	return nil
+}
+
+// RestoreJob undoes the last archive or delete: a trashed job comes back as it
+// was before (archived or not), an archived job becomes active again.
+func (jr *jobRepository) RestoreJob(ctx context.Context, userId, jobId int32) error {
+	tag, err := jr.pool.Exec(ctx, `
+		UPDATE jobs
+		SET archived_at = CASE WHEN deleted_at IS NULL THEN NULL ELSE archived_at END,
+			deleted_at = NULL,
+			updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+	`, jobId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
+	}
+	return nil
+}
+
+func (jr *jobRepository) ListTrash(ctx context.Context, userId int32) ([]domain.Job, error) {
+	query := `SELECT ` + jobColumns + `
+		FROM jobs j
+		WHERE j.user_id = $1 AND j.deleted_at IS NOT NULL
+		ORDER BY j.deleted_at DESC, j.id
+	`
+	rows, err := jr.pool.Query(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// PurgeTrash permanently deletes jobs trashed before deletedBefore.
+func (jr *jobRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	tag, err := jr.pool.Exec(ctx, `DELETE FROM jobs WHERE deleted_at < $1`, PgTimeHelper(deletedBefore))
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListDuplicateCandidates returns the user's active and archived jobs, the
+// set a new job is checked against for duplicates.
+func (jr *jobRepository) ListDuplicateCandidates(ctx context.Context, userId int32) ([]domain.Job, error) {
+	query := `SELECT ` + jobColumns + `
+		FROM jobs j
+		WHERE j.user_id = $1 AND j.deleted_at IS NULL
+		ORDER BY j.id
+	`
+	rows, err := jr.pool.Query(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// MergeJobs writes the merged fields to the target job, moves everything
+// hanging off the source job (tags, contacts, attachments, recommendation
+// links, status history, and the offer and snapshot if the target has none)
+// over to the target, then deletes the source. A target whose status changes
+// moves to the first stage for its new status.
+func (jr *jobRepository) MergeJobs(ctx context.Context, userId, targetId, sourceId int32, merged *domain.Job) error {
+	var dateApplied pgtype.Timestamp
+	if merged.DateApplied != "" {
+		t, err := time.Parse("2006-01-02", merged.DateApplied)
+		if err != nil {
+			return domain.ErrInvalidDateFormat
+		}
+		dateApplied = PgTimeHelper(t)
+	}
+
+	tx, err := jr.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE jobs AS cur
+		SET
+			company_name = $3,
+			location = $4,
+			link = $5,
+			platform = $6,
+			notes = $7,
+			date_applied = $8,
+			priority = $10,
+			stage_id = CASE
+				WHEN cur.status = $9::text THEN cur.stage_id
+				ELSE (SELECT ps.id FROM pipeline_stages ps
+				      WHERE ps.user_id = cur.user_id AND ps.base_status = $9::text
+				      ORDER BY ps.position, ps.id LIMIT 1)
+			END,
+			status = $9::text,
+			updated_at = NOW()
+		WHERE cur.id = $1 AND cur.user_id = $2 AND cur.deleted_at IS NULL
+	`,
+		targetId,
+		userId,
+		nullableString(merged.CompanyName),
+		nullableString(merged.Location),
+		nullableString(merged.Link),
+		nullableString(merged.Platform),
+		nullableString(merged.Notes),
+		dateApplied,
+		merged.Status,
+		merged.Priority,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
+	}
+
+	statements := []string{
+		`INSERT INTO job_tags (job_id, user_id, tag)
+		 SELECT $1, user_id, tag FROM job_tags WHERE job_id = $2
+		 ON CONFLICT DO NOTHING`,
+		`INSERT INTO contact_jobs (contact_id, job_id)
+		 SELECT contact_id, $1 FROM contact_jobs WHERE job_id = $2
+		 ON CONFLICT DO NOTHING`,
+		`UPDATE serp_job_cache SET tracker_job_id = $1 WHERE tracker_job_id = $2`,
+		`UPDATE job_attachments SET job_id = $1 WHERE job_id = $2`,
+		`UPDATE job_status_events SET job_id = $1 WHERE job_id = $2`,
+		`UPDATE job_offers SET job_id = $1
+		 WHERE job_id = $2 AND NOT EXISTS (SELECT 1 FROM job_offers WHERE job_id = $1)`,
+		`UPDATE job_description_snapshots SET job_id = $1
+		 WHERE job_id = $2 AND NOT EXISTS (SELECT 1 FROM job_description_snapshots WHERE job_id = $1)`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt, targetId, sourceId); err != nil {
+			return err
+		}
+	}
+
+	tag, err = tx.Exec(ctx, `DELETE FROM jobs WHERE id = $1 AND user_id = $2`, sourceId, userId)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrInvalidJobID
+	}
+
+	return tx.Commit(ctx)
+}
+
+func replaceJobTags(ctx context.Context, tx pgx.Tx, userID, jobID int32, tags []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM job_tags WHERE job_id = $1`, jobID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO job_tags (job_id, user_id, tag)
+		SELECT $1, $2, unnest($3::text[])
+		ON CONFLICT DO NOTHING
+	`, jobID, userID, tags)
+	return err
+}
+
+func scanJob(scanner rowScanner) (*domain.Job, error) {
+	var (
+		job         domain.Job
+		companyName *string
+		notes       *string
+		link        *string
+		location    *string
+		platform    *string
+		dateApplied pgtype.Timestamp
+		createdAt   pgtype.Timestamp
+		priority    int16
+		archivedAt  pgtype.Timestamp
+		deletedAt   pgtype.Timestamp
+		ghostedAt   pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&job.ID,
+		&job.UserId,
+		&job.Title,
+		&companyName,
+		&notes,
+		&link,
+		&location,
+		&platform,
+		&dateApplied,
+		&job.Status,
+		&createdAt,
+		&priority,
+		&job.StageID,
+		&job.BoardPosition,
+		&archivedAt,
+		&deletedAt,
+		&ghostedAt,
+		&job.Tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.CompanyName = derefString(companyName)
+	job.Notes = derefString(notes)
+	job.Link = derefString(link)
+	job.Location = derefString(location)
+	job.Platform = derefString(platform)
+	job.CreatedAt = createdAt.Time
+	job.Priority = int32(priority)
+	job.ArchivedAt = timestampPtr(archivedAt)
+	job.DeletedAt = timestampPtr(deletedAt)
+	job.GhostedAt = timestampPtr(ghostedAt)
+	if dateApplied.Valid {
+		job.DateApplied = dateApplied.Time.Format("2006-01-02")
+	}
+	return &job, nil
 }
 
 func PgTimeHelper(data time.Time) pgtype.Timestamp {
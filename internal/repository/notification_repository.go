@@ -5,6 +5,7 @@ import (
 	"aiki/internal/domain"
 	"context"
 
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,8 +21,12 @@ type NotificationRepository interface {
 	GetPreferences(ctx context.Context, userID int32) (*domain.NotificationPreferences, error)
 	UpsertPreferences(ctx context.Context, prefs domain.NotificationPreferences) (*domain.NotificationPreferences, error)
 	GetUsersWithNoSessionToday(ctx context.Context) ([]int32, error)
-	GetDailyReminderRecipients(ctx context.Context) ([]int32, error)
-	GetStreakWarningRecipients(ctx context.Context) ([]int32, error)
+	GetDailyReminderRecipients(ctx context.Context, localHour int) ([]int32, error)
+	GetStreakWarningRecipients(ctx context.Context, localHour int) ([]int32, error)
+	GetDueContactFollowUps(ctx context.Context) ([]domain.ContactFollowUp, error)
+	MarkContactFollowUpsSent(ctx context.Context, contactIDs []int32) error
+	GetUpcomingOfferDeadlines(ctx context.Context, withinDays int32) ([]domain.OfferDeadline, error)
+	MarkOfferDeadlinesNotified(ctx context.Context, jobIDs []int32) error
 }
 
 type notificationRepository struct {
@@ -118,6 +123,7 @@ func (r *notificationRepository) GetPreferences(ctx context.Context, userID int3
 			follow_up_reminder,
 			application_check_in,
 			interview_reminder,
+			offer_deadline,
 			updated_at
 		FROM notification_preferences
 		WHERE user_id = $1
@@ -141,9 +147,10 @@ func (r *notificationRepository) UpsertPreferences(ctx context.Context, prefs do
 			badge_earned,
 			follow_up_reminder,
 			application_check_in,
-			interview_reminder
+			interview_reminder,
+			offer_deadline
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		ON CONFLICT (user_id) DO UPDATE SET
 			in_app_enabled = EXCLUDED.in_app_enabled,
 			push_enabled = EXCLUDED.push_enabled,
@@ -157,6 +164,7 @@ func (r *notificationRepository) UpsertPreferences(ctx context.Context, prefs do
 			follow_up_reminder = EXCLUDED.follow_up_reminder,
 			application_check_in = EXCLUDED.application_check_in,
 			interview_reminder = EXCLUDED.interview_reminder,
+			offer_deadline = EXCLUDED.offer_deadline,
 			updated_at = NOW()
 		RETURNING
 			user_id,
@@ -172,6 +180,7 @@ func (r *notificationRepository) UpsertPreferences(ctx context.Context, prefs do
 			follow_up_reminder,
 			application_check_in,
 			interview_reminder,
+			offer_deadline,
 			updated_at
 	`
 
@@ -192,28 +201,33 @@ func (r *notificationRepository) UpsertPreferences(ctx context.Context, prefs do
 			prefs.FollowUpReminder,
 			prefs.ApplicationCheckIn,
 			prefs.InterviewReminder,
+			prefs.OfferDeadline,
 		),
 	)
 }
 
-func (r *notificationRepository) GetDailyReminderRecipients(ctx context.Context) ([]int32, error) {
+// GetDailyReminderRecipients returns the users for whom it is now localHour
+// o'clock and who have not completed a session today, both in their own
+// time zone.
+func (r *notificationRepository) GetDailyReminderRecipients(ctx context.Context, localHour int) ([]int32, error) {
 	const query = `
 		SELECT u.id
 		FROM users u
 		LEFT JOIN notification_preferences np ON np.user_id = u.id
 		WHERE u.is_active = TRUE
+		  AND EXTRACT(HOUR FROM NOW() AT TIME ZONE u.timezone) = $1
 		  AND NOT EXISTS (
 			SELECT 1
 			FROM focus_sessions fs
 			WHERE fs.user_id = u.id
 			  AND fs.status = 'completed'
-			  AND DATE(fs.ended_at) = CURRENT_DATE
+			  AND (fs.ended_at AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date = (NOW() AT TIME ZONE u.timezone)::date
 		  )
 		  AND COALESCE(np.in_app_enabled, TRUE) = TRUE
 		  AND COALESCE(np.daily_reminder, TRUE) = TRUE
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, localHour)
 	if err != nil {
 		return nil, err
 	}
@@ -231,25 +245,29 @@ func (r *notificationRepository) GetDailyReminderRecipients(ctx context.Context)
 	return userIDs, rows.Err()
 }
 
-func (r *notificationRepository) GetStreakWarningRecipients(ctx context.Context) ([]int32, error) {
+// GetStreakWarningRecipients returns the users with a streak for whom it is
+// now localHour o'clock and who have not completed a session today, both in
+// their own time zone.
+func (r *notificationRepository) GetStreakWarningRecipients(ctx context.Context, localHour int) ([]int32, error) {
 	const query = `
 		SELECT u.id
 		FROM users u
 		INNER JOIN streaks s ON s.user_id = u.id AND s.current_streak > 0
 		LEFT JOIN notification_preferences np ON np.user_id = u.id
 		WHERE u.is_active = TRUE
+		  AND EXTRACT(HOUR FROM NOW() AT TIME ZONE u.timezone) = $1
 		  AND NOT EXISTS (
 			SELECT 1
 			FROM focus_sessions fs
 			WHERE fs.user_id = u.id
 			  AND fs.status = 'completed'
-			  AND DATE(fs.ended_at) = CURRENT_DATE
+			  AND (fs.ended_at AT TIME ZONE 'UTC' AT TIME ZONE u.timezone)::date = (NOW() AT TIME ZONE u.timezone)::date
 		  )
 		  AND COALESCE(np.in_app_enabled, TRUE) = TRUE
 		  AND COALESCE(np.streak_warning, TRUE) = TRUE
 	`
 
-	rows, err := r.db.Query(ctx, query)
+	rows, err := r.db.Query(ctx, query, localHour)
 	if err != nil {
 		return nil, err
 	}
@@ -267,6 +285,93 @@ func (r *notificationRepository) GetStreakWarningRecipients(ctx context.Context)
 	return userIDs, rows.Err()
 }
 
+// GetDueContactFollowUps returns contacts whose next-touch date has arrived
+// and who have not been reminded about it yet.
+func (r *notificationRepository) GetDueContactFollowUps(ctx context.Context) ([]domain.ContactFollowUp, error) {
+	const query = `
+		SELECT c.id, c.user_id, c.name, COALESCE(c.company, ''), COALESCE(c.next_touch_note, '')
+		FROM contacts c
+		INNER JOIN users u ON u.id = c.user_id
+		WHERE u.is_active = TRUE
+		  AND c.next_touch_at IS NOT NULL
+		  AND c.next_touch_at <= CURRENT_DATE
+		  AND c.next_touch_notified = FALSE
+		ORDER BY c.user_id, c.next_touch_at
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var followUps []domain.ContactFollowUp
+	for rows.Next() {
+		var f domain.ContactFollowUp
+		if err := rows.Scan(&f.ContactID, &f.UserID, &f.Name, &f.Company, &f.Note); err != nil {
+			return nil, err
+		}
+		followUps = append(followUps, f)
+	}
+
+	return followUps, rows.Err()
+}
+
+func (r *notificationRepository) MarkContactFollowUpsSent(ctx context.Context, contactIDs []int32) error {
+	if len(contactIDs) == 0 {
+		return nil
+	}
+	const query = `UPDATE contacts SET next_touch_notified = TRUE WHERE id = ANY($1::int[])`
+	_, err := r.db.Exec(ctx, query, contactIDs)
+	return err
+}
+
+// GetUpcomingOfferDeadlines returns open offers (job still in the offer
+// status) whose response deadline falls within the next withinDays days and
+// that have not been reminded about yet.
+func (r *notificationRepository) GetUpcomingOfferDeadlines(ctx context.Context, withinDays int32) ([]domain.OfferDeadline, error) {
+	const query = `
+		SELECT o.job_id, o.user_id, j.title, COALESCE(j.company_name, ''), o.response_deadline
+		FROM job_offers o
+		INNER JOIN jobs j ON j.id = o.job_id
+		INNER JOIN users u ON u.id = o.user_id
+		WHERE u.is_active = TRUE
+		  AND j.status = 'offer'
+		  AND j.archived_at IS NULL AND j.deleted_at IS NULL
+		  AND o.deadline_notified = FALSE
+		  AND o.response_deadline BETWEEN CURRENT_DATE AND CURRENT_DATE + $1::int
+		ORDER BY o.response_deadline
+	`
+
+	rows, err := r.db.Query(ctx, query, withinDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deadlines []domain.OfferDeadline
+	for rows.Next() {
+		var d domain.OfferDeadline
+		var deadline pgtype.Date
+		if err := rows.Scan(&d.JobID, &d.UserID, &d.Title, &d.CompanyName, &deadline); err != nil {
+			return nil, err
+		}
+		d.Deadline = deadline.Time
+		deadlines = append(deadlines, d)
+	}
+
+	return deadlines, rows.Err()
+}
+
+func (r *notificationRepository) MarkOfferDeadlinesNotified(ctx context.Context, jobIDs []int32) error {
+	if len(jobIDs) == 0 {
+		return nil
+	}
+	const query = `UPDATE job_offers SET deadline_notified = TRUE WHERE job_id = ANY($1::int[])`
+	_, err := r.db.Exec(ctx, query, jobIDs)
+	return err
+}
+
 // ─────────────────────────────────────────
 // Mapper
 // ─────────────────────────────────────────
@@ -303,6 +408,7 @@ func scanNotificationPreferences(scanner rowScanner) (*domain.NotificationPrefer
 		&prefs.FollowUpReminder,
 		&prefs.ApplicationCheckIn,
 		&prefs.InterviewReminder,
+		&prefs.OfferDeadline,
 		&prefs.UpdatedAt,
 	)
 	if err != nil {
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OfferRepository interface {
+	Upsert(ctx context.Context, offer *domain.JobOffer) (*domain.JobOffer, error)
+	GetByJobID(ctx context.Context, jobID int32) (*domain.JobOffer, error)
+	Delete(ctx context.Context, jobID int32) error
+	ListForComparison(ctx context.Context, userID int32, jobIDs []int32) ([]domain.ComparedOffer, error)
+}
+
+type offerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOfferRepository(dbPool *pgxpool.Pool) OfferRepository {
+	return &offerRepository{db: dbPool}
+}
+
+// offerColumns is shared by every query that returns offers; scanOffer reads them in this order.
+const offerColumns = `
+	o.job_id, o.user_id, o.base_salary::float8, o.currency, o.pay_period, o.bonus::float8,
+	o.equity, o.equity_annual_value::float8, o.benefits, o.start_date, o.response_deadline,
+	o.remote_policy, o.created_at, o.updated_at`
+
+// Upsert records or replaces a job's offer. Changing the response deadline
+// re-arms its reminder.
+func (r *offerRepository) Upsert(ctx context.Context, offer *domain.JobOffer) (*domain.JobOffer, error) {
+	startDate, err := parseDate(offer.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	deadline, err := parseDate(offer.ResponseDeadline)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO job_offers AS o (
+			job_id, user_id, base_salary, currency, pay_period, bonus, equity,
+			equity_annual_value, benefits, start_date, response_deadline, remote_policy
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (job_id) DO UPDATE SET
+			base_salary = EXCLUDED.base_salary,
+			currency = EXCLUDED.currency,
+			pay_period = EXCLUDED.pay_period,
+			bonus = EXCLUDED.bonus,
+			equity = EXCLUDED.equity,
+			equity_annual_value = EXCLUDED.equity_annual_value,
+			benefits = EXCLUDED.benefits,
+			start_date = EXCLUDED.start_date,
+			deadline_notified = CASE
+				WHEN o.response_deadline IS DISTINCT FROM EXCLUDED.response_deadline THEN FALSE
+				ELSE o.deadline_notified
+			END,
+			response_deadline = EXCLUDED.response_deadline,
+			remote_policy = EXCLUDED.remote_policy
+		RETURNING ` + offerColumns
+	return scanOffer(r.db.QueryRow(ctx, query,
+		offer.JobID,
+		offer.UserID,
+		offer.BaseSalary,
+		strings.ToUpper(offer.Currency),
+		offer.PayPeriod,
+		offer.Bonus,
+		nullableString(offer.Equity),
+		offer.EquityAnnualValue,
+		nullableString(offer.Benefits),
+		startDate,
+		deadline,
+		nullableString(offer.RemotePolicy),
+	))
+}
+
+func (r *offerRepository) GetByJobID(ctx context.Context, jobID int32) (*domain.JobOffer, error) {
+	query := `SELECT ` + offerColumns + ` FROM job_offers o WHERE o.job_id = $1`
+	offer, err := scanOffer(r.db.QueryRow(ctx, query, jobID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOfferNotFound
+		}
+		return nil, err
+	}
+	return offer, nil
+}
+
+func (r *offerRepository) Delete(ctx context.Context, jobID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM job_offers WHERE job_id = $1`, jobID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrOfferNotFound
+	}
+	return nil
+}
+
+// ListForComparison returns the user's offers with their job details. An
+// empty jobIDs list returns every offer the user has recorded.
+func (r *offerRepository) ListForComparison(ctx context.Context, userID int32, jobIDs []int32) ([]domain.ComparedOffer, error) {
+	query := `SELECT j.title, COALESCE(j.company_name, ''), j.status, ` + offerColumns + `
+		FROM job_offers o
+		INNER JOIN jobs j ON j.id = o.job_id
+		WHERE o.user_id = $1
+		  AND j.archived_at IS NULL AND j.deleted_at IS NULL
+		  AND (cardinality($2::int[]) = 0 OR o.job_id = ANY($2::int[]))
+		ORDER BY o.job_id
+	`
+	if jobIDs == nil {
+		jobIDs = []int32{}
+	}
+	rows, err := r.db.Query(ctx, query, userID, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	offers := []domain.ComparedOffer{}
+	for rows.Next() {
+		var c domain.ComparedOffer
+		offer, err := scanOffer(prefixScanner{rows, []any{&c.Title, &c.CompanyName, &c.Status}})
+		if err != nil {
+			return nil, err
+		}
+		c.JobID = offer.JobID
+		c.Offer = *offer
+		offers = append(offers, c)
+	}
+	return offers, rows.Err()
+}
+
+// prefixScanner lets a row scanner fill extra leading columns before the
+// destinations passed to Scan.
+type prefixScanner struct {
+	rowScanner
+	prefix []any
+}
+
+func (p prefixScanner) Scan(dest ...any) error {
+	return p.rowScanner.Scan(append(p.prefix, dest...)...)
+}
+
+func scanOffer(scanner rowScanner) (*domain.JobOffer, error) {
+	var (
+		o            domain.JobOffer
+		equity       *string
+		benefits     *string
+		startDate    pgtype.Date
+		deadline     pgtype.Date
+		remotePolicy *string
+		createdAt    pgtype.Timestamp
+		updatedAt    pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&o.JobID,
+		&o.UserID,
+		&o.BaseSalary,
+		&o.Currency,
+		&o.PayPeriod,
+		&o.Bonus,
+		&equity,
+		&o.EquityAnnualValue,
+		&benefits,
+		&startDate,
+		&deadline,
+		&remotePolicy,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	o.Equity = derefString(equity)
+	o.Benefits = derefString(benefits)
+	o.RemotePolicy = derefString(remotePolicy)
+	o.CreatedAt = createdAt.Time
+	o.UpdatedAt = updatedAt.Time
+	if startDate.Valid {
+		o.StartDate = startDate.Time.Format("2006-01-02")
+	}
+	if deadline.Valid {
+		o.ResponseDeadline = deadline.Time.Format("2006-01-02")
+	}
+	return &o, nil
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PipelineRepository interface {
+	EnsureDefaultStages(ctx context.Context, userID int32) error
+	ListStages(ctx context.Context, userID int32) ([]domain.PipelineStage, error)
+	GetStage(ctx context.Context, stageID, userID int32) (*domain.PipelineStage, error)
+	CreateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error)
+	UpdateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error)
+	DeleteStage(ctx context.Context, stageID, userID int32) error
+	ReorderStages(ctx context.Context, userID int32, stageIDs []int32) error
+	CountStagesForStatus(ctx context.Context, userID int32, status string) (int32, error)
+}
+
+type pipelineRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPipelineRepository(dbPool *pgxpool.Pool) PipelineRepository {
+	return &pipelineRepository{db: dbPool}
+}
+
+const stageColumns = `id, user_id, name, base_status, position, COALESCE(color, ''), created_at, updated_at`
+
+// EnsureDefaultStages seeds the default pipeline for users who have no stages yet.
+func (r *pipelineRepository) EnsureDefaultStages(ctx context.Context, userID int32) error {
+	names := make([]string, len(domain.DefaultPipelineStages))
+	statuses := make([]string, len(domain.DefaultPipelineStages))
+	positions := make([]int32, len(domain.DefaultPipelineStages))
+	for i, st := range domain.DefaultPipelineStages {
+		names[i] = st.Name
+		statuses[i] = st.BaseStatus
+		positions[i] = st.Position
+	}
+
+	const query = `
+		INSERT INTO pipeline_stages (user_id, name, base_status, position)
+		SELECT $1, d.name, d.base_status, d.position
+		FROM unnest($2::text[], $3::text[], $4::int[]) AS d(name, base_status, position)
+		WHERE NOT EXISTS (SELECT 1 FROM pipeline_stages WHERE user_id = $1)
+		ON CONFLICT (user_id, name) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query, userID, names, statuses, positions)
+	return err
+}
+
+func (r *pipelineRepository) ListStages(ctx context.Context, userID int32) ([]domain.PipelineStage, error) {
+	query := `SELECT ` + stageColumns + ` FROM pipeline_stages WHERE user_id = $1 ORDER BY position, id`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stages := []domain.PipelineStage{}
+	for rows.Next() {
+		stage, err := scanStage(rows)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, *stage)
+	}
+	return stages, rows.Err()
+}
+
+func (r *pipelineRepository) GetStage(ctx context.Context, stageID, userID int32) (*domain.PipelineStage, error) {
+	query := `SELECT ` + stageColumns + ` FROM pipeline_stages WHERE id = $1 AND user_id = $2`
+	stage, err := scanStage(r.db.QueryRow(ctx, query, stageID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrStageNotFound
+		}
+		return nil, err
+	}
+	return stage, nil
+}
+
+func (r *pipelineRepository) CreateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error) {
+	query := `
+		INSERT INTO pipeline_stages (user_id, name, base_status, color, position)
+		VALUES (
+			$1, $2, $3, NULLIF($4, ''),
+			COALESCE((SELECT MAX(position) + 1 FROM pipeline_stages WHERE user_id = $1), 0)
+		)
+		RETURNING ` + stageColumns
+	created, err := scanStage(r.db.QueryRow(ctx, query, stage.UserID, stage.Name, stage.BaseStatus, stage.Color))
+	if err != nil {
+		return nil, mapStageWriteError(err)
+	}
+	return created, nil
+}
+
+// UpdateStage renames or re-maps a stage. Jobs in the stage follow a change of
+// base status so their status always matches their column.
+func (r *pipelineRepository) UpdateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE pipeline_stages
+		SET name = $3, base_status = $4, color = NULLIF($5, ''), updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING ` + stageColumns
+	updated, err := scanStage(tx.QueryRow(ctx, query, stage.ID, stage.UserID, stage.Name, stage.BaseStatus, stage.Color))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrStageNotFound
+		}
+		return nil, mapStageWriteError(err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE jobs SET status = $3, updated_at = NOW()
+		WHERE stage_id = $1 AND user_id = $2 AND status <> $3
+	`, stage.ID, stage.UserID, stage.BaseStatus); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteStage removes a stage and moves its jobs to the end of the first
+// remaining stage with the same base status.
+func (r *pipelineRepository) DeleteStage(ctx context.Context, stageID, userID int32) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var baseStatus string
+	err = tx.QueryRow(ctx, `
+		SELECT base_status FROM pipeline_stages
+		WHERE id = $1 AND user_id = $2
+		FOR UPDATE
+	`, stageID, userID).Scan(&baseStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrStageNotFound
+		}
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		WITH fallback AS (
+			SELECT id FROM pipeline_stages
+			WHERE user_id = $2 AND base_status = $3 AND id <> $1
+			ORDER BY position, id
+			LIMIT 1
+		), tail AS (
+			SELECT COALESCE(MAX(j.board_position) + 1, 0) AS start
+			FROM jobs j, fallback
+			WHERE j.stage_id = fallback.id
+		), moved AS (
+			SELECT id, ROW_NUMBER() OVER (ORDER BY board_position, id) - 1 AS offset_pos
+			FROM jobs
+			WHERE stage_id = $1 AND user_id = $2
+		)
+		UPDATE jobs j
+		SET stage_id = fallback.id,
+			board_position = tail.start + moved.offset_pos
+		FROM fallback, tail, moved
+		WHERE j.id = moved.id
+	`, stageID, userID, baseStatus); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM pipeline_stages WHERE id = $1 AND user_id = $2`, stageID, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE pipeline_stages ps
+		SET position = o.pos
+		FROM (
+			SELECT id, (ROW_NUMBER() OVER (ORDER BY position, id) - 1)::int AS pos
+			FROM pipeline_stages WHERE user_id = $1
+		) o
+		WHERE ps.id = o.id AND ps.position <> o.pos
+	`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *pipelineRepository) ReorderStages(ctx context.Context, userID int32, stageIDs []int32) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE pipeline_stages ps
+		SET position = (o.pos - 1)::int, updated_at = NOW()
+		FROM unnest($2::int[]) WITH ORDINALITY AS o(id, pos)
+		WHERE ps.id = o.id AND ps.user_id = $1
+	`, userID, stageIDs)
+	return err
+}
+
+func (r *pipelineRepository) CountStagesForStatus(ctx context.Context, userID int32, status string) (int32, error) {
+	var count int32
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*)::int FROM pipeline_stages WHERE user_id = $1 AND base_status = $2
+	`, userID, status).Scan(&count)
+	return count, err
+}
+
+func scanStage(scanner rowScanner) (*domain.PipelineStage, error) {
+	var s domain.PipelineStage
+	var createdAt, updatedAt pgtype.Timestamp
+	err := scanner.Scan(
+		&s.ID,
+		&s.UserID,
+		&s.Name,
+		&s.BaseStatus,
+		&s.Position,
+		&s.Color,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	s.CreatedAt = createdAt.Time
+	s.UpdatedAt = updatedAt.Time
+	return &s, nil
+}
+
+func mapStageWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return domain.ErrStageNameTaken
+	}
+	return err
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"aiki/internal/domain"
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SavedSearchRepository interface {
+	ListSearches(ctx context.Context, userID int32) ([]domain.SavedSearch, error)
+	GetSearch(ctx context.Context, searchID, userID int32) (*domain.SavedSearch, error)
+	CreateSearch(ctx context.Context, search *domain.SavedSearch) (*domain.SavedSearch, error)
+	UpdateSearch(ctx context.Context, search *domain.SavedSearch) (*domain.SavedSearch, error)
+	DeleteSearch(ctx context.Context, searchID, userID int32) error
+	ListDueSearches(ctx context.Context) ([]domain.SavedSearch, error)
+	RecordMatches(ctx context.Context, searchID int32, jobs []domain.SerpJob, alerted bool) ([]domain.SavedSearchMatch, error)
+	ListMatches(ctx context.Context, searchID int32, limit int32) ([]domain.SavedSearchMatch, error)
+	ListPendingDigests(ctx context.Context) ([]domain.SavedSearchDigest, error)
+	MarkDigestSent(ctx context.Context, digests []domain.SavedSearchDigest) error
+}
+
+type savedSearchRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSavedSearchRepository(dbPool *pgxpool.Pool) SavedSearchRepository {
+	return &savedSearchRepository{db: dbPool}
+}
+
+// savedSearchColumns is shared by every query that returns a saved search; scanSavedSearch reads them in this order.
+const savedSearchColumns = `s.id, s.user_id, s.name, s.query, s.location, s.remote_only, s.min_salary, s.excluded_companies, s.frequency, s.alert, s.last_run_at, s.created_at, s.updated_at`
+
+// savedSearchMatchColumns is shared by every query that returns a match; scanSavedSearchMatch reads them in this order.
+const savedSearchMatchColumns = `m.id, m.saved_search_id, m.external_id, m.title, m.company_name, m.location, m.link, m.platform, m.salary, m.posted_at, m.first_seen_at, m.alerted_at`
+
+func (r *savedSearchRepository) ListSearches(ctx context.Context, userID int32) ([]domain.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s WHERE s.user_id = $1 ORDER BY s.created_at, s.id`
+	return r.querySearches(ctx, query, userID)
+}
+
+func (r *savedSearchRepository) GetSearch(ctx context.Context, searchID, userID int32) (*domain.SavedSearch, error) {
+	query := `SELECT ` + savedSearchColumns + ` FROM saved_searches s WHERE s.id = $1 AND s.user_id = $2`
+	search, err := scanSavedSearch(r.db.QueryRow(ctx, query, searchID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+	return search, nil
+}
+
+func (r *savedSearchRepository) CreateSearch(ctx context.Context, search *domain.SavedSearch) (*domain.SavedSearch, error) {
+	query := `
+		INSERT INTO saved_searches AS s (user_id, name, query, location, remote_only, min_salary, excluded_companies, frequency, alert)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + savedSearchColumns
+	return scanSavedSearch(r.db.QueryRow(ctx, query,
+		search.UserID, search.Name, search.Query, nullableString(search.Location), search.RemoteOnly,
+		search.MinSalary, search.ExcludedCompanies, search.Frequency, search.Alert,
+	))
+}
+
+// UpdateSearch replaces the search's settings. Changing what it looks for
+// forgets the matches seen so far, so the next run sets a new baseline
+// instead of announcing every posting as new.
+func (r *savedSearchRepository) UpdateSearch(ctx context.Context, search *domain.SavedSearch) (*domain.SavedSearch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE saved_searches AS s
+		SET name = $3, query = $4, location = $5, remote_only = $6, min_salary = $7,
+		    excluded_companies = $8, frequency = $9, alert = $10,
+		    last_run_at = CASE
+		        WHEN (s.query, s.location, s.remote_only, s.min_salary, s.excluded_companies)
+		             IS NOT DISTINCT FROM ($4::text, $5::text, $6::bool, $7::int, $8::text[])
+		        THEN s.last_run_at
+		    END
+		WHERE s.id = $1 AND s.user_id = $2
+		RETURNING ` + savedSearchColumns
+	updated, err := scanSavedSearch(tx.QueryRow(ctx, query,
+		search.ID, search.UserID, search.Name, search.Query, nullableString(search.Location), search.RemoteOnly,
+		search.MinSalary, search.ExcludedCompanies, search.Frequency, search.Alert,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSavedSearchNotFound
+		}
+		return nil, err
+	}
+
+	if updated.LastRunAt == nil {
+		if _, err := tx.Exec(ctx, `DELETE FROM saved_search_matches WHERE saved_search_id = $1`, updated.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (r *savedSearchRepository) DeleteSearch(ctx context.Context, searchID, userID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`, searchID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+// ListDueSearches returns the searches of active users whose next run has
+// come round. An hour of slack keeps a daily search from slipping a day when
+// the previous run finished a little after the scheduled time. Searches with
+// alerts off are left to be run on demand.
+func (r *savedSearchRepository) ListDueSearches(ctx context.Context) ([]domain.SavedSearch, error) {
+	query := `
+		SELECT ` + savedSearchColumns + `
+		FROM saved_searches s
+		JOIN users u ON u.id = s.user_id
+		WHERE u.is_active = TRUE
+		  AND s.alert <> 'off'
+		  AND (s.last_run_at IS NULL OR s.last_run_at <= NOW() + INTERVAL '1 hour' -
+		       CASE s.frequency WHEN 'weekly' THEN INTERVAL '7 days' ELSE INTERVAL '1 day' END)
+		ORDER BY s.user_id, s.id`
+	return r.querySearches(ctx, query)
+}
+
+// RecordMatches stores the postings a run returned and stamps the run. Only
+// postings the search had not returned before are given back. alerted marks
+// them as already announced.
+func (r *savedSearchRepository) RecordMatches(ctx context.Context, searchID int32, jobs []domain.SerpJob, alerted bool) ([]domain.SavedSearchMatch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		INSERT INTO saved_search_matches AS m
+			(saved_search_id, external_id, title, company_name, location, link, platform, salary, posted_at, alerted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CASE WHEN $10::bool THEN NOW() END)
+		ON CONFLICT (saved_search_id, external_id) DO NOTHING
+		RETURNING ` + savedSearchMatchColumns
+
+	added := []domain.SavedSearchMatch{}
+	for _, job := range jobs {
+		match, err := scanSavedSearchMatch(tx.QueryRow(ctx, query,
+			searchID, job.ExternalID, job.Title, nullableString(job.CompanyName), nullableString(job.Location),
+			nullableString(job.Link), nullableString(job.Platform), nullableString(job.Salary), nullableString(job.PostedAt),
+			alerted,
+		))
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue // seen on an earlier run
+		}
+		if err != nil {
+			return nil, err
+		}
+		added = append(added, *match)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE saved_searches SET last_run_at = NOW() WHERE id = $1`, searchID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// ListMatches returns the search's matches, newest first.
+func (r *savedSearchRepository) ListMatches(ctx context.Context, searchID int32, limit int32) ([]domain.SavedSearchMatch, error) {
+	query := `
+		SELECT ` + savedSearchMatchColumns + `
+		FROM saved_search_matches m
+		WHERE m.saved_search_id = $1
+		ORDER BY m.first_seen_at DESC, m.id DESC
+		LIMIT $2`
+	rows, err := r.db.Query(ctx, query, searchID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	matches := []domain.SavedSearchMatch{}
+	for rows.Next() {
+		match, err := scanSavedSearchMatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, *match)
+	}
+	return matches, rows.Err()
+}
+
+// ListPendingDigests counts, per digest search of an active user, the
+// matches not announced yet. MaxMatchID bounds what MarkDigestSent marks, so
+// matches recorded while the digest is being sent wait for the next one.
+func (r *savedSearchRepository) ListPendingDigests(ctx context.Context) ([]domain.SavedSearchDigest, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.user_id, s.id, s.name, COUNT(*)::int, MAX(m.id)
+		FROM saved_search_matches m
+		JOIN saved_searches s ON s.id = m.saved_search_id
+		JOIN users u ON u.id = s.user_id
+		WHERE m.alerted_at IS NULL AND s.alert = 'digest' AND u.is_active = TRUE
+		GROUP BY s.user_id, s.id, s.name
+		ORDER BY s.user_id, s.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var digests []domain.SavedSearchDigest
+	for rows.Next() {
+		var d domain.SavedSearchDigest
+		if err := rows.Scan(&d.UserID, &d.SavedSearchID, &d.SearchName, &d.NewMatches, &d.MaxMatchID); err != nil {
+			return nil, err
+		}
+		digests = append(digests, d)
+	}
+	return digests, rows.Err()
+}
+
+func (r *savedSearchRepository) MarkDigestSent(ctx context.Context, digests []domain.SavedSearchDigest) error {
+	if len(digests) == 0 {
+		return nil
+	}
+	searchIDs := make([]int32, len(digests))
+	maxIDs := make([]int32, len(digests))
+	for i, d := range digests {
+		searchIDs[i] = d.SavedSearchID
+		maxIDs[i] = d.MaxMatchID
+	}
+	_, err := r.db.Exec(ctx, `
+		UPDATE saved_search_matches m
+		SET alerted_at = NOW()
+		FROM unnest($1::int[], $2::int[]) AS d(search_id, max_id)
+		WHERE m.saved_search_id = d.search_id AND m.id <= d.max_id AND m.alerted_at IS NULL`,
+		searchIDs, maxIDs,
+	)
+	return err
+}
+
+func (r *savedSearchRepository) querySearches(ctx context.Context, query string, args ...any) ([]domain.SavedSearch, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	searches := []domain.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, *search)
+	}
+	return searches, rows.Err()
+}
+
+func scanSavedSearch(scanner rowScanner) (*domain.SavedSearch, error) {
+	var (
+		search               domain.SavedSearch
+		location             *string
+		lastRunAt            pgtype.Timestamp
+		createdAt, updatedAt pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&search.Query,
+		&location,
+		&search.RemoteOnly,
+		&search.MinSalary,
+		&search.ExcludedCompanies,
+		&search.Frequency,
+		&search.Alert,
+		&lastRunAt,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	search.Location = derefString(location)
+	if search.ExcludedCompanies == nil {
+		search.ExcludedCompanies = []string{}
+	}
+	search.LastRunAt = timestampPtr(lastRunAt)
+	search.CreatedAt = createdAt.Time
+	search.UpdatedAt = updatedAt.Time
+	return &search, nil
+}
+
+func scanSavedSearchMatch(scanner rowScanner) (*domain.SavedSearchMatch, error) {
+	var (
+		match                                             domain.SavedSearchMatch
+		company, location, link, platform, salary, posted *string
+		firstSeenAt, alertedAt                            pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&match.ID,
+		&match.SavedSearchID,
+		&match.ExternalID,
+		&match.Title,
+		&company,
+		&location,
+		&link,
+		&platform,
+		&salary,
+		&posted,
+		&firstSeenAt,
+		&alertedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	match.CompanyName = derefString(company)
+	match.Location = derefString(location)
+	match.Link = derefString(link)
+	match.Platform = derefString(platform)
+	match.Salary = derefString(salary)
+	match.PostedAt = derefString(posted)
+	match.FirstSeenAt = firstSeenAt.Time
+	match.AlertedAt = timestampPtr(alertedAt)
+	return &match, nil
+}
@@ -3,21 +3,33 @@ package repository
 import (
 	"aiki/internal/database/db"
 	"aiki/internal/domain"
+	"aiki/internal/pkg/jobmatch"
 	"context"
 	"errors"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type SerpJobRepository interface {
-	UpsertJobs(ctx context.Context, userID int32, jobs []domain.SerpJob) ([]domain.SerpJobCache, error)
-	GetCachedJobs(ctx context.Context, userID int32, limit, offset int32) ([]domain.SerpJobCache, error)
+	ReplaceSearchResults(ctx context.Context, userID int32, searchKey string, params domain.SerpSearchParams, jobs []domain.SerpJob, nextPageToken string) (*domain.SerpSearchState, error)
+	AppendSearchResults(ctx context.Context, userID int32, searchKey string, jobs []domain.SerpJob, nextPageToken string) (*domain.SerpSearchState, error)
+	ListSearchResults(ctx context.Context, userID int32, filter domain.SerpCacheFilter, after *domain.SerpCacheCursor, limit int32) ([]domain.SerpJobCache, error)
+	GetSearchState(ctx context.Context, userID int32) (*domain.SerpSearchState, error)
+	TouchSearchState(ctx context.Context, userID int32) error
+	ListPrefetchCandidates(ctx context.Context, fetchedBefore, viewedSince time.Time, limit int32) ([]domain.SerpSearchState, error)
+	GetSearchPreferences(ctx context.Context, userID int32) (*domain.SearchPreferences, error)
+	UpsertSearchPreferences(ctx context.Context, userID int32, prefs domain.SearchPreferences) (*domain.SearchPreferences, error)
 	GetCachedJobByID(ctx context.Context, jobID, userID int32) (*domain.SerpJobCache, error)
-	GetLatestFetchTime(ctx context.Context, userID int32) (*time.Time, error)
 	MarkSavedToTracker(ctx context.Context, cacheID, userID, trackerJobID int32) error
 	DeleteOldCache(ctx context.Context, userID int32) error
+
+	// Dismissals
+	DismissJob(ctx context.Context, userID, cacheID int32, reason string) (*domain.JobDismissal, error)
+	ListDismissals(ctx context.Context, userID int32) ([]domain.JobDismissal, error)
+	DeleteDismissal(ctx context.Context, userID, dismissalID int32) error
 }
 
 type serpJobRepository struct {
@@ -29,54 +41,305 @@ func NewSerpJobRepository(dbPool *pgxpool.Pool) SerpJobRepository {
 	return &serpJobRepository{db: dbPool, queries: db.New(dbPool)}
 }
 
-func (r *serpJobRepository) UpsertJobs(ctx context.Context, userID int32, jobs []domain.SerpJob) ([]domain.SerpJobCache, error) {
+// serpJobCacheColumns is shared by the hand-written cache queries; scanSerpJobCache reads them in this order, followed by a sort value.
+const serpJobCacheColumns = `c.id, c.user_id, c.external_id, c.title, c.company_name, c.location, c.description, c.link, c.platform, c.posted_at, c.salary, c.saved_to_tracker, c.tracker_job_id, c.fetched_at, c.position,
+	c.salary_min::float8, c.salary_max::float8, c.salary_currency, c.salary_period, c.salary_annual_min::float8, c.salary_annual_max::float8, c.posted_time, c.remote`
+
+// serpSearchStateColumns are read by scanSerpSearchState in this order.
+const serpSearchStateColumns = `user_id, search_key, location, date_posted, employment_type, remote_only, locations, country, language, next_page_token, fetched_at, viewed_at`
+
+// serpSortValues are the values each sort orders results by, highest first.
+var serpSortValues = map[string]string{
+	domain.RecommendationSortNewest: `EXTRACT(EPOCH FROM c.posted_time)::float8`,
+	domain.RecommendationSortPay:    `c.salary_sort_value`,
+}
+
+// ReplaceSearchResults starts a search over: the user's previous results are
+// untagged, jobs become its first page and the search state is reset.
+func (r *serpJobRepository) ReplaceSearchResults(ctx context.Context, userID int32, searchKey string, params domain.SerpSearchParams, jobs []domain.SerpJob, nextPageToken string) (*domain.SerpSearchState, error) {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	qtx := r.queries.WithTx(tx)
-	cached := make([]domain.SerpJobCache, 0, len(jobs))
-	for _, job := range jobs {
-		row, err := qtx.UpsertSerpJobCache(ctx, db.UpsertSerpJobCacheParams{
-			UserID:      userID,
-			ExternalID:  job.ExternalID,
-			Title:       job.Title,
-			CompanyName: nullableString(job.CompanyName),
-			Location:    nullableString(job.Location),
-			Description: nullableString(job.Description),
-			Link:        nullableString(job.Link),
-			Platform:    nullableString(job.Platform),
-			PostedAt:    nullableString(job.PostedAt),
-			Salary:      nullableString(job.Salary),
-		})
-		if err != nil {
-			return nil, err
+	if _, err := tx.Exec(ctx, `UPDATE serp_job_cache SET search_key = '' WHERE user_id = $1 AND search_key <> ''`, userID); err != nil {
+		return nil, err
+	}
+	if err := upsertSearchResults(ctx, tx, userID, searchKey, jobs, 0); err != nil {
+		return nil, err
+	}
+
+	state, err := scanSerpSearchState(tx.QueryRow(ctx, `
+		INSERT INTO serp_search_state (
+			user_id, search_key, location, date_posted, employment_type, remote_only,
+			locations, country, language, next_page_token, fetched_at
+		) VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::text[], '{}'), $8, $9, $10, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			search_key      = EXCLUDED.search_key,
+			location        = EXCLUDED.location,
+			date_posted     = EXCLUDED.date_posted,
+			employment_type = EXCLUDED.employment_type,
+			remote_only     = EXCLUDED.remote_only,
+			locations       = EXCLUDED.locations,
+			country         = EXCLUDED.country,
+			language        = EXCLUDED.language,
+			next_page_token = EXCLUDED.next_page_token,
+			fetched_at      = EXCLUDED.fetched_at
+		RETURNING `+serpSearchStateColumns,
+		userID, searchKey, params.Location, params.DatePosted, params.EmploymentType, params.RemoteOnly,
+		params.Locations, params.Country, params.Language, nullableString(nextPageToken),
+	))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// AppendSearchResults adds the next upstream page to the current search. It
+// returns domain.ErrInvalidCursor if the user has since started another one.
+func (r *serpJobRepository) AppendSearchResults(ctx context.Context, userID int32, searchKey string, jobs []domain.SerpJob, nextPageToken string) (*domain.SerpSearchState, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	state, err := scanSerpSearchState(tx.QueryRow(ctx, `
+		UPDATE serp_search_state
+		SET next_page_token = $3
+		WHERE user_id = $1 AND search_key = $2
+		RETURNING `+serpSearchStateColumns,
+		userID, searchKey, nullableString(nextPageToken),
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvalidCursor
 		}
-		cached = append(cached, mapSerpJob(row))
+		return nil, err
+	}
+
+	var last int32
+	err = tx.QueryRow(ctx,
+		`SELECT COALESCE(MAX(position), 0) FROM serp_job_cache WHERE user_id = $1 AND search_key = $2`,
+		userID, searchKey,
+	).Scan(&last)
+	if err != nil {
+		return nil, err
+	}
+	if err := upsertSearchResults(ctx, tx, userID, searchKey, jobs, last); err != nil {
+		return nil, err
 	}
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
-	return cached, nil
+	return state, nil
 }
 
-func (r *serpJobRepository) GetCachedJobs(ctx context.Context, userID int32, limit, offset int32) ([]domain.SerpJobCache, error) {
-	rows, err := r.queries.GetCachedJobsByUserID(ctx, db.GetCachedJobsByUserIDParams{
-		UserID: userID,
-		Limit:  limit,
-		Offset: offset,
-	})
+// upsertSearchResults numbers jobs from after onwards. A job the search
+// already returned keeps its place, so a cursor never skips or repeats it.
+func upsertSearchResults(ctx context.Context, tx pgx.Tx, userID int32, searchKey string, jobs []domain.SerpJob, after int32) error {
+	const query = `
+		INSERT INTO serp_job_cache (
+			user_id, external_id, title, company_name, location, description,
+			link, platform, posted_at, salary, search_key, position, company_key,
+			salary_min, salary_max, salary_currency, salary_period,
+			salary_annual_min, salary_annual_max, salary_sort_value, posted_time, remote
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+		ON CONFLICT (user_id, external_id) DO UPDATE SET
+			title             = EXCLUDED.title,
+			company_name      = EXCLUDED.company_name,
+			company_key       = EXCLUDED.company_key,
+			location          = EXCLUDED.location,
+			description       = EXCLUDED.description,
+			link              = EXCLUDED.link,
+			platform          = EXCLUDED.platform,
+			posted_at         = EXCLUDED.posted_at,
+			salary            = EXCLUDED.salary,
+			salary_min        = EXCLUDED.salary_min,
+			salary_max        = EXCLUDED.salary_max,
+			salary_currency   = EXCLUDED.salary_currency,
+			salary_period     = EXCLUDED.salary_period,
+			salary_annual_min = EXCLUDED.salary_annual_min,
+			salary_annual_max = EXCLUDED.salary_annual_max,
+			salary_sort_value = EXCLUDED.salary_sort_value,
+			posted_time       = EXCLUDED.posted_time,
+			remote            = EXCLUDED.remote,
+			fetched_at        = NOW(),
+			position          = CASE WHEN serp_job_cache.search_key = EXCLUDED.search_key
+			                         THEN serp_job_cache.position ELSE EXCLUDED.position END,
+			search_key        = EXCLUDED.search_key`
+	for i, job := range jobs {
+		var (
+			salaryMin, salaryMax, annualMin, annualMax *float64
+			currency, period                           *string
+			postedTime                                 pgtype.Timestamp
+		)
+		if job.PostedTime != nil {
+			postedTime = pgtype.Timestamp{Time: job.PostedTime.UTC(), Valid: true}
+		}
+		if r := job.SalaryRange; r != nil {
+			salaryMin, salaryMax = &r.Min, &r.Max
+			currency, period = nullableString(r.Currency), nullableString(r.Period)
+			if r.Period != "" {
+				annualMin, annualMax = &r.AnnualMin, &r.AnnualMax
+			}
+		}
+		_, err := tx.Exec(ctx, query,
+			userID, job.ExternalID, job.Title,
+			nullableString(job.CompanyName), nullableString(job.Location), nullableString(job.Description),
+			nullableString(job.Link), nullableString(job.Platform), nullableString(job.PostedAt), nullableString(job.Salary),
+			searchKey, after+int32(i)+1, jobmatch.CompanyKey(job.CompanyName),
+			salaryMin, salaryMax, currency, period,
+			annualMin, annualMax, job.SalarySortValue, postedTime, job.Remote,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSearchResults returns up to limit results of the search that come
+// after the cursor, or from the start when it is nil. They are in the order
+// they were fetched unless filter.Sort asks for newest or best paid first;
+// jobs without a date or salary then come last. Dismissed jobs, and jobs
+// from dismissed companies, are left out.
+func (r *serpJobRepository) ListSearchResults(ctx context.Context, userID int32, filter domain.SerpCacheFilter, after *domain.SerpCacheCursor, limit int32) ([]domain.SerpJobCache, error) {
+	sortValue, sorted := serpSortValues[filter.Sort]
+	orderBy := `c.position`
+	if sorted {
+		orderBy = `sort_value DESC NULLS LAST, c.position`
+	} else {
+		sortValue = `NULL::float8`
+	}
+
+	var afterCond string
+	args := []any{userID, filter.SearchKey, filter.HasSalary, filter.Platform, limit}
+	switch {
+	case after == nil:
+	case !sorted:
+		afterCond = `AND c.position > $6`
+		args = append(args, after.Position)
+	case after.Value == nil:
+		afterCond = `AND ` + sortValue + ` IS NULL AND c.position > $6`
+		args = append(args, after.Position)
+	default:
+		afterCond = `AND (` + sortValue + ` < $7 OR (` + sortValue + ` = $7 AND c.position > $6) OR ` + sortValue + ` IS NULL)`
+		args = append(args, after.Position, *after.Value)
+	}
+
+	query := `
+		SELECT ` + serpJobCacheColumns + `, ` + sortValue + ` AS sort_value
+		FROM serp_job_cache c
+		WHERE c.user_id = $1 AND c.search_key = $2
+		  AND (NOT $3::bool OR COALESCE(c.salary, '') <> '')
+		  AND ($4::text = '' OR LOWER(c.platform) = LOWER($4::text))
+		  AND NOT EXISTS (
+			SELECT 1 FROM job_dismissals d
+			WHERE d.user_id = c.user_id
+			  AND (d.external_id = c.external_id
+			       OR (d.reason = 'company' AND d.company_key <> '' AND d.company_key = c.company_key))
+		  )
+		  ` + afterCond + `
+		ORDER BY ` + orderBy + `
+		LIMIT $5`
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.SerpJobCache{}
+	for rows.Next() {
+		job, err := scanSerpJobCache(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+	return jobs, rows.Err()
+}
+
+// GetSearchState returns nil when the user has not searched yet.
+func (r *serpJobRepository) GetSearchState(ctx context.Context, userID int32) (*domain.SerpSearchState, error) {
+	state, err := scanSerpSearchState(r.db.QueryRow(ctx,
+		`SELECT `+serpSearchStateColumns+` FROM serp_search_state WHERE user_id = $1`,
+		userID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return state, err
+}
+
+// TouchSearchState records that the user has just looked at their
+// recommendations.
+func (r *serpJobRepository) TouchSearchState(ctx context.Context, userID int32) error {
+	_, err := r.db.Exec(ctx, `UPDATE serp_search_state SET viewed_at = NOW() WHERE user_id = $1`, userID)
+	return err
+}
+
+// ListPrefetchCandidates returns up to limit searches of active users that
+// were fetched before fetchedBefore and looked at since viewedSince, the
+// stalest first.
+func (r *serpJobRepository) ListPrefetchCandidates(ctx context.Context, fetchedBefore, viewedSince time.Time, limit int32) ([]domain.SerpSearchState, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT s.user_id, s.search_key, s.location, s.date_posted, s.employment_type, s.remote_only,
+		       s.locations, s.country, s.language, s.next_page_token, s.fetched_at, s.viewed_at
+		FROM serp_search_state s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.fetched_at < $1 AND s.viewed_at >= $2
+		  AND COALESCE(u.is_active, TRUE)
+		ORDER BY s.fetched_at
+		LIMIT $3`,
+		PgTimeHelper(fetchedBefore), PgTimeHelper(viewedSince), limit,
+	)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	var states []domain.SerpSearchState
+	for rows.Next() {
+		state, err := scanSerpSearchState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, *state)
+	}
+	return states, rows.Err()
+}
 
-	jobs := make([]domain.SerpJobCache, len(rows))
-	for i, row := range rows {
-		jobs[i] = mapSerpJob(row)
+// GetSearchPreferences returns the user's search preferences, all empty
+// when they have not set any.
+func (r *serpJobRepository) GetSearchPreferences(ctx context.Context, userID int32) (*domain.SearchPreferences, error) {
+	prefs, err := scanSearchPreferences(r.db.QueryRow(ctx,
+		`SELECT `+searchPreferencesColumns+` FROM search_preferences WHERE user_id = $1`,
+		userID,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.SearchPreferences{Locations: []string{}}, nil
 	}
-	return jobs, nil
+	return prefs, err
+}
+
+func (r *serpJobRepository) UpsertSearchPreferences(ctx context.Context, userID int32, prefs domain.SearchPreferences) (*domain.SearchPreferences, error) {
+	return scanSearchPreferences(r.db.QueryRow(ctx, `
+		INSERT INTO search_preferences (user_id, country, language, locations, open_to_remote, open_to_relocation)
+		VALUES ($1, $2, $3, COALESCE($4::text[], '{}'), $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			country            = EXCLUDED.country,
+			language           = EXCLUDED.language,
+			locations          = EXCLUDED.locations,
+			open_to_remote     = EXCLUDED.open_to_remote,
+			open_to_relocation = EXCLUDED.open_to_relocation
+		RETURNING `+searchPreferencesColumns,
+		userID, prefs.Country, prefs.Language, prefs.Locations, prefs.OpenToRemote, prefs.OpenToRelocation,
+	))
 }
 
 func (r *serpJobRepository) GetCachedJobByID(ctx context.Context, jobID, userID int32) (*domain.SerpJobCache, error) {
@@ -94,17 +357,6 @@ func (r *serpJobRepository) GetCachedJobByID(ctx context.Context, jobID, userID
 	return &c, nil
 }
 
-func (r *serpJobRepository) GetLatestFetchTime(ctx context.Context, userID int32) (*time.Time, error) {
-	ts, err := r.queries.GetLatestCacheFetchTime(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-	if !ts.Valid {
-		return nil, nil
-	}
-	return &ts.Time, nil
-}
-
 func (r *serpJobRepository) MarkSavedToTracker(ctx context.Context, cacheID, userID, trackerJobID int32) error {
 	tid := trackerJobID
 	return r.queries.MarkJobSavedToTracker(ctx, db.MarkJobSavedToTrackerParams{
@@ -118,6 +370,62 @@ func (r *serpJobRepository) DeleteOldCache(ctx context.Context, userID int32) er
 	return r.queries.DeleteOldCacheForUser(ctx, userID)
 }
 
+// DismissJob records that the user is not interested in a cached job.
+// Dismissing it again replaces the reason.
+func (r *serpJobRepository) DismissJob(ctx context.Context, userID, cacheID int32, reason string) (*domain.JobDismissal, error) {
+	job, err := r.GetCachedJobByID(ctx, cacheID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO job_dismissals (user_id, external_id, title, company_name, company_key, location, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, external_id) DO UPDATE SET
+			reason     = EXCLUDED.reason,
+			created_at = NOW()
+		RETURNING ` + jobDismissalColumns
+	return scanJobDismissal(r.db.QueryRow(ctx, query,
+		userID, job.ExternalID, job.Title, nullableString(job.CompanyName),
+		jobmatch.CompanyKey(job.CompanyName), nullableString(job.Location), reason,
+	))
+}
+
+func (r *serpJobRepository) ListDismissals(ctx context.Context, userID int32) ([]domain.JobDismissal, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+jobDismissalColumns+`
+		FROM job_dismissals
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dismissals := []domain.JobDismissal{}
+	for rows.Next() {
+		d, err := scanJobDismissal(rows)
+		if err != nil {
+			return nil, err
+		}
+		dismissals = append(dismissals, *d)
+	}
+	return dismissals, rows.Err()
+}
+
+func (r *serpJobRepository) DeleteDismissal(ctx context.Context, userID, dismissalID int32) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM job_dismissals WHERE id = $1 AND user_id = $2`, dismissalID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDismissalNotFound
+	}
+	return nil
+}
+
 // ─────────────────────────────────────────
 // Mappers & Helpers
 // ─────────────────────────────────────────
@@ -147,3 +455,128 @@ func nullableString(s string) *string {
 	}
 	return &s
 }
+
+func scanSerpJobCache(scanner rowScanner) (*domain.SerpJobCache, error) {
+	var (
+		job                                                    domain.SerpJobCache
+		company, location, description, link, platform, posted *string
+		salary, salaryCurrency, salaryPeriod                   *string
+		salaryMin, salaryMax, annualMin, annualMax             *float64
+		fetchedAt, postedTime                                  pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&job.ID,
+		&job.UserID,
+		&job.ExternalID,
+		&job.Title,
+		&company,
+		&location,
+		&description,
+		&link,
+		&platform,
+		&posted,
+		&salary,
+		&job.SavedToTracker,
+		&job.TrackerJobID,
+		&fetchedAt,
+		&job.Position,
+		&salaryMin,
+		&salaryMax,
+		&salaryCurrency,
+		&salaryPeriod,
+		&annualMin,
+		&annualMax,
+		&postedTime,
+		&job.Remote,
+		&job.SortValue,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.CompanyName = derefString(company)
+	job.Location = derefString(location)
+	job.Description = derefString(description)
+	job.Link = derefString(link)
+	job.Platform = derefString(platform)
+	job.PostedAt = derefString(posted)
+	job.Salary = derefString(salary)
+	job.FetchedAt = fetchedAt.Time
+	job.PostedTime = timestampPtr(postedTime)
+	if salaryMin != nil && salaryMax != nil {
+		job.SalaryRange = &domain.SalaryRange{
+			Min:      *salaryMin,
+			Max:      *salaryMax,
+			Currency: derefString(salaryCurrency),
+			Period:   derefString(salaryPeriod),
+		}
+		if annualMin != nil && annualMax != nil {
+			job.SalaryRange.AnnualMin, job.SalaryRange.AnnualMax = *annualMin, *annualMax
+		}
+	}
+	return &job, nil
+}
+
+const jobDismissalColumns = `id, user_id, external_id, title, company_name, location, reason, created_at`
+
+func scanJobDismissal(scanner rowScanner) (*domain.JobDismissal, error) {
+	var (
+		d                 domain.JobDismissal
+		company, location *string
+		createdAt         pgtype.Timestamp
+	)
+	err := scanner.Scan(&d.ID, &d.UserID, &d.ExternalID, &d.Title, &company, &location, &d.Reason, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	d.CompanyName = derefString(company)
+	d.Location = derefString(location)
+	d.CreatedAt = createdAt.Time
+	return &d, nil
+}
+
+const searchPreferencesColumns = `country, language, locations, open_to_remote, open_to_relocation, updated_at`
+
+func scanSearchPreferences(scanner rowScanner) (*domain.SearchPreferences, error) {
+	var (
+		prefs     domain.SearchPreferences
+		updatedAt pgtype.Timestamp
+	)
+	err := scanner.Scan(&prefs.Country, &prefs.Language, &prefs.Locations, &prefs.OpenToRemote, &prefs.OpenToRelocation, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if prefs.Locations == nil {
+		prefs.Locations = []string{}
+	}
+	prefs.UpdatedAt = timestampPtr(updatedAt)
+	return &prefs, nil
+}
+
+func scanSerpSearchState(scanner rowScanner) (*domain.SerpSearchState, error) {
+	var (
+		state               domain.SerpSearchState
+		next                *string
+		fetchedAt, viewedAt pgtype.Timestamp
+	)
+	err := scanner.Scan(
+		&state.UserID,
+		&state.SearchKey,
+		&state.Params.Location,
+		&state.Params.DatePosted,
+		&state.Params.EmploymentType,
+		&state.Params.RemoteOnly,
+		&state.Params.Locations,
+		&state.Params.Country,
+		&state.Params.Language,
+		&next,
+		&fetchedAt,
+		&viewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	state.NextPageToken = derefString(next)
+	state.FetchedAt = fetchedAt.Time
+	state.ViewedAt = viewedAt.Time
+	return &state, nil
+}
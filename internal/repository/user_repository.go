@@ -33,6 +33,8 @@ type UserRepository interface {
 	UpdateUserProfile(ctx context.Context, userId int32, fullName, currentJob, experienceLevel *string, goals []string) (*domain.UserProfile, error)
 	GetUserProfileByID(ctx context.Context, userId int32) (*domain.UserProfile, error)
 	UpdateUserJobSearchLocation(ctx context.Context, userID int32, jobSearchLocation string) error
+	GetTimezone(ctx context.Context, userID int32) (string, error)
+	UpdateTimezone(ctx context.Context, userID int32, timezone string) error
 	UploadCV(ctx context.Context, userId int32, data []byte) error
 	GetUserCV(ctx context.Context, userID int32) ([]byte, error)
 	GetByLinkedInID(ctx context.Context, linkedInID string) (*domain.User, error)
@@ -357,6 +359,69 @@ func (r *userRepository) UpdateUserJobSearchLocation(ctx context.Context, userID
 	})
 }
 
+func (r *userRepository) GetTimezone(ctx context.Context, userID int32) (string, error) {
+	var timezone string
+	err := r.db.QueryRow(ctx, `SELECT timezone FROM users WHERE id = $1`, userID).Scan(&timezone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", domain.ErrUserNotFound
+		}
+		return "", err
+	}
+	return timezone, nil
+}
+
+// UpdateTimezone moves the user to another time zone, which must be one the
+// database knows, and re-dates their streak's last session day in it so the
+// move neither breaks nor stretches the streak.
+func (r *userRepository) UpdateTimezone(ctx context.Context, userID int32, timezone string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var current string
+	err = tx.QueryRow(ctx, `SELECT timezone FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return err
+	}
+	if current == timezone {
+		return nil
+	}
+
+	var known bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = $1)`, timezone).Scan(&known)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return domain.ErrUnknownTimezone
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE users SET timezone = $2, updated_at = NOW() WHERE id = $1`, userID, timezone); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE streaks s
+		SET last_session_date = COALESCE((
+		        SELECT (MAX(fs.ended_at) AT TIME ZONE 'UTC' AT TIME ZONE $2)::date
+		        FROM focus_sessions fs
+		        WHERE fs.user_id = s.user_id AND fs.status = 'completed' AND fs.elapsed_seconds > 0
+		    ), s.last_session_date),
+		    updated_at = NOW()
+		WHERE s.user_id = $1 AND s.last_session_date IS NOT NULL`,
+		userID, timezone,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
 func (r *userRepository) UpdateUserProfile(ctx context.Context, userId int32, fullName, currentJob, experienceLevel *string, goals []string) (*domain.UserProfile, error) {
 	profile, err := r.queries.UpdateUserProfile(ctx, db.UpdateUserProfileParams{
 		UserID:          userId,
@@ -4,6 +4,7 @@ import (
 	"aiki/internal/handler"
 	"aiki/internal/middleware"
 	"aiki/internal/pkg/jwt"
+	"expvar"
 
 	"github.com/labstack/echo/v4"
 )
@@ -13,11 +14,21 @@ func Setup(
 	authHandler *handler.AuthHandler,
 	userHandler *handler.UserHandler,
 	jobHandler *handler.JobHandler,
+	jobImportHandler *handler.JobImportHandler,
+	pipelineHandler *handler.PipelineHandler,
+	contactHandler *handler.ContactHandler,
+	offerHandler *handler.OfferHandler,
+	attachmentHandler *handler.AttachmentHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	automationHandler *handler.AutomationHandler,
+	goalHandler *handler.GoalHandler,
+	savedSearchHandler *handler.SavedSearchHandler,
 	homeHandler *handler.HomeHandler,
 	notifHandler *handler.NotificationHandler,
 	serpHandler *handler.SerpJobHandler,
 	chatHandler *handler.ChatHandler,
 	jwtManager *jwt.Manager,
+	adminEmails []string,
 ) {
 	api := e.Group("/api/v1")
 
@@ -49,8 +60,11 @@ func Setup(
 	{
 		users.GET("/me", userHandler.GetMe)
 		users.PUT("/me", userHandler.UpdateMe)
+		users.GET("/me/timezone", userHandler.GetTimezone)
+		users.PUT("/me/timezone", userHandler.UpdateTimezone)
 		users.POST("/profile", userHandler.CreateProfile)
 		users.GET("/profile", userHandler.GetProfile)
+		users.GET("/profile/title-suggestions", userHandler.SuggestTitles)
 		users.PATCH("/profile", userHandler.UpdateProfile)
 		users.POST("/upload/cv", userHandler.UploadCV)
 		users.GET("/cv", userHandler.GetCV)
@@ -62,14 +76,107 @@ func Setup(
 	{
 		// Serp routes (static paths before /:id so "recommended" is not parsed as an id)
 		jobs.GET("/recommended", serpHandler.GetRecommendedJobs)
+		jobs.GET("/recommended/dismissals", serpHandler.ListDismissals)
+		jobs.GET("/recommended/preferences", serpHandler.GetSearchPreferences)
+		jobs.PUT("/recommended/preferences", serpHandler.UpdateSearchPreferences)
+		jobs.DELETE("/recommended/dismissals/:id", serpHandler.DeleteDismissal)
+		jobs.POST("/recommended/:id/dismiss", serpHandler.DismissJob)
 		jobs.POST("/recommended/:id/save", serpHandler.SaveJobToTracker)
 		jobs.POST("/recommended/:id/apply", serpHandler.ApplyRecommendedJob)
+		jobs.POST("/from-url", jobImportHandler.ImportJob)
+		jobs.GET("/board", pipelineHandler.GetBoard)
+		jobs.GET("/tags", jobHandler.GetTags)
+		jobs.GET("/trash", jobHandler.GetTrash)
+		jobs.GET("/offers/compare", offerHandler.CompareOffers)
+		jobs.GET("/attachments/usage", attachmentHandler.GetStorageUsage)
 
 		jobs.POST("", jobHandler.CreateJob)
 		jobs.GET("", jobHandler.GetAllJobs)
 		jobs.GET("/:id", jobHandler.GetJob)
 		jobs.PUT("/:id", jobHandler.UpdateJob)
 		jobs.DELETE("/:id", jobHandler.DeleteJob)
+		jobs.PATCH("/:id/move", pipelineHandler.MoveJob)
+		jobs.POST("/:id/archive", jobHandler.ArchiveJob)
+		jobs.POST("/:id/restore", jobHandler.RestoreJob)
+		jobs.POST("/:id/merge", jobHandler.MergeJob)
+		jobs.GET("/:id/timeline", automationHandler.GetJobTimeline)
+		jobs.GET("/:id/offer", offerHandler.GetOffer)
+		jobs.PUT("/:id/offer", offerHandler.SaveOffer)
+		jobs.DELETE("/:id/offer", offerHandler.DeleteOffer)
+		jobs.GET("/:id/snapshot", attachmentHandler.GetSnapshot)
+		jobs.GET("/:id/attachments", attachmentHandler.ListAttachments)
+		jobs.POST("/:id/attachments", attachmentHandler.UploadAttachment)
+		jobs.GET("/:id/attachments/:attachment_id", attachmentHandler.DownloadAttachment)
+		jobs.DELETE("/:id/attachments/:attachment_id", attachmentHandler.DeleteAttachment)
+	}
+
+	// Pipeline stages (Kanban columns)
+	pipeline := api.Group("/pipeline")
+	pipeline.Use(middleware.Auth(jwtManager))
+	{
+		pipeline.GET("/stages", pipelineHandler.ListStages)
+		pipeline.POST("/stages", pipelineHandler.CreateStage)
+		pipeline.PUT("/stages/order", pipelineHandler.ReorderStages)
+		pipeline.PUT("/stages/:id", pipelineHandler.UpdateStage)
+		pipeline.DELETE("/stages/:id", pipelineHandler.DeleteStage)
+	}
+
+	// Contacts (recruiter CRM)
+	contacts := api.Group("/contacts")
+	contacts.Use(middleware.Auth(jwtManager))
+	{
+		contacts.GET("", contactHandler.ListContacts)
+		contacts.POST("", contactHandler.CreateContact)
+		contacts.GET("/:id", contactHandler.GetContact)
+		contacts.PUT("/:id", contactHandler.UpdateContact)
+		contacts.DELETE("/:id", contactHandler.DeleteContact)
+		contacts.POST("/:id/jobs/:job_id", contactHandler.LinkJob)
+		contacts.DELETE("/:id/jobs/:job_id", contactHandler.UnlinkJob)
+		contacts.POST("/:id/interactions", contactHandler.AddInteraction)
+		contacts.DELETE("/:id/interactions/:interaction_id", contactHandler.DeleteInteraction)
+	}
+
+	// Automation rules (ghosting detection, nudges, auto-archive)
+	automation := api.Group("/automation")
+	automation.Use(middleware.Auth(jwtManager))
+	{
+		automation.GET("/rules", automationHandler.ListRules)
+		automation.POST("/rules", automationHandler.CreateRule)
+		automation.GET("/rules/templates", automationHandler.ListTemplates)
+		automation.POST("/rules/preview", automationHandler.PreviewDraftRule)
+		automation.PUT("/rules/:id", automationHandler.UpdateRule)
+		automation.DELETE("/rules/:id", automationHandler.DeleteRule)
+		automation.GET("/rules/:id/preview", automationHandler.PreviewRule)
+	}
+
+	// Goals (weekly / monthly targets)
+	goals := api.Group("/goals")
+	goals.Use(middleware.Auth(jwtManager))
+	{
+		goals.GET("", goalHandler.ListGoals)
+		goals.POST("", goalHandler.CreateGoal)
+		goals.PUT("/:id", goalHandler.UpdateGoal)
+		goals.DELETE("/:id", goalHandler.DeleteGoal)
+		goals.POST("/:id/check-ins", goalHandler.CheckIn)
+	}
+
+	// Saved job searches and new-match alerts
+	savedSearches := api.Group("/saved-searches")
+	savedSearches.Use(middleware.Auth(jwtManager))
+	{
+		savedSearches.GET("", savedSearchHandler.ListSearches)
+		savedSearches.POST("", savedSearchHandler.CreateSearch)
+		savedSearches.PUT("/:id", savedSearchHandler.UpdateSearch)
+		savedSearches.DELETE("/:id", savedSearchHandler.DeleteSearch)
+		savedSearches.GET("/:id/matches", savedSearchHandler.ListMatches)
+		savedSearches.POST("/:id/run", savedSearchHandler.RunSearch)
+	}
+
+	// Analytics
+	analytics := api.Group("/analytics")
+	analytics.Use(middleware.Auth(jwtManager))
+	{
+		analytics.GET("/applications", analyticsHandler.GetApplicationAnalytics)
 	}
 
 	// Home screen
@@ -86,6 +193,11 @@ func Setup(
 		sessions.POST("", homeHandler.StartSession)
 		sessions.GET("", homeHandler.GetSessionHistory)
 		sessions.GET("/active", homeHandler.GetActiveSession)
+		sessions.GET("/jobs", homeHandler.GetJobFocusTime)
+		sessions.GET("/presets", homeHandler.ListSessionPresets)
+		sessions.POST("/presets", homeHandler.CreateSessionPreset)
+		sessions.PUT("/presets/:id", homeHandler.UpdateSessionPreset)
+		sessions.DELETE("/presets/:id", homeHandler.DeleteSessionPreset)
 		sessions.PATCH("/:id/pause", homeHandler.PauseSession)
 		sessions.PATCH("/:id/resume", homeHandler.ResumeSession)
 		sessions.PATCH("/:id/end", homeHandler.EndSession)
@@ -133,4 +245,13 @@ func Setup(
 		chat.POST("", chatHandler.Chat)
 		chat.GET("/providers", chatHandler.GetProviders)
 	}
+
+	// Admin
+	admin := api.Group("/admin")
+	admin.Use(middleware.Auth(jwtManager), middleware.Admin(adminEmails))
+	{
+		admin.GET("/search-quota", serpHandler.GetSearchQuota)
+		admin.GET("/search-stats", serpHandler.GetSearchStats)
+		admin.GET("/debug/vars", echo.WrapHandler(expvar.Handler()))
+	}
 }
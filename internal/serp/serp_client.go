@@ -2,6 +2,9 @@ package serp
 
 import (
 	"aiki/internal/domain"
+	"aiki/internal/jobsource"
+	"aiki/internal/pkg/jobtitle"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -13,20 +16,27 @@ import (
 const serpAPIBaseURL = "https://serpapi.com/search"
 const cacheTTL = 24 * time.Hour
 
+// Client is the Google Jobs source, searched through SerpApi.
 type Client struct {
 	apiKey     string
+	baseURL    string
 	httpClient *http.Client
 }
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		apiKey: apiKey,
+		apiKey:  apiKey,
+		baseURL: serpAPIBaseURL,
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
 	}
 }
 
+func (c *Client) Name() string {
+	return "serpapi"
+}
+
 // serpAPIResponse is the raw response from SerpApi Google Jobs
 type serpAPIResponse struct {
 	JobsResults []struct {
@@ -41,41 +51,86 @@ type serpAPIResponse struct {
 			Link  string `json:"link"`
 		} `json:"apply_options"`
 		DetectedExtensions struct {
-			PostedAt string `json:"posted_at"`
-			Salary   string `json:"salary"`
+			PostedAt     string `json:"posted_at"`
+			Salary       string `json:"salary"`
+			WorkFromHome bool   `json:"work_from_home"`
 		} `json:"detected_extensions"`
 		ViaText string `json:"via"`
 	} `json:"jobs_results"`
+	Pagination struct {
+		NextPageToken string `json:"next_page_token"`
+	} `json:"serpapi_pagination"`
+}
+
+// Search calls SerpApi Google Jobs search based on job title and experience
+// level. Its external IDs are Google's job IDs, unprefixed, as they were
+// before other sources existed.
+func (c *Client) Search(ctx context.Context, q jobsource.Query) ([]domain.SerpJob, error) {
+	jobs, _, err := c.SearchPage(ctx, q, "")
+	return jobs, err
+}
+
+// employmentChips maps the employment types onto Google Jobs' chip values.
+var employmentChips = map[string]string{
+	domain.EmploymentFullTime:   "FULLTIME",
+	domain.EmploymentPartTime:   "PARTTIME",
+	domain.EmploymentContract:   "CONTRACTOR",
+	domain.EmploymentInternship: "INTERN",
 }
 
-// FetchJobs calls SerpApi Google Jobs search based on job title and experience level
-func (c *Client) FetchJobs(jobTitle, experienceLevel, location string) ([]domain.SerpJob, error) {
-	query := buildQuery(jobTitle, experienceLevel)
+// SearchPage follows SerpApi's next_page_token, which is the token here.
+// Date posted and employment type go upstream as chips, remote only as ltype,
+// country as gl and language as hl. Without a location the search covers
+// the whole country.
+func (c *Client) SearchPage(ctx context.Context, q jobsource.Query, token string) ([]domain.SerpJob, string, error) {
+	query := buildQuery(q.Title, q.ExperienceLevel, q.RelatedTitles)
+	for _, term := range q.ExcludeTerms {
+		query += " -" + term
+	}
+	location := q.Location
 
 	params := url.Values{}
 	params.Set("engine", "google_jobs")
 	params.Set("q", query)
 	params.Set("api_key", c.apiKey)
-	params.Set("num", "20")
 	if location != "" {
 		params.Set("location", location)
 	}
+	if q.Country != "" {
+		params.Set("gl", q.Country)
+	}
+	if q.Language != "" {
+		params.Set("hl", q.Language)
+	}
+	if chips := buildChips(q); chips != "" {
+		params.Set("chips", chips)
+	}
+	if q.RemoteOnly {
+		params.Set("ltype", "1")
+	}
+	if token != "" {
+		params.Set("next_page_token", token)
+	}
 
-	reqURL := fmt.Sprintf("%s?%s", serpAPIBaseURL, params.Encode())
+	reqURL := fmt.Sprintf("%s?%s", c.baseURL, params.Encode())
 
-	resp, err := c.httpClient.Get(reqURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("serp api request failed: %w", err)
+		return nil, "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("serp api request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("serp api returned status %d", resp.StatusCode)
+		return nil, "", fmt.Errorf("serp api returned status %d", resp.StatusCode)
 	}
 
 	var result serpAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode serp api response: %w", err)
+		return nil, "", fmt.Errorf("failed to decode serp api response: %w", err)
 	}
 
 	now := time.Now()
@@ -91,35 +146,43 @@ func (c *Client) FetchJobs(jobTitle, experienceLevel, location string) ([]domain
 			Platform:    j.ViaText,
 			PostedAt:    j.DetectedExtensions.PostedAt,
 			Salary:      j.DetectedExtensions.Salary,
+			Remote:      j.DetectedExtensions.WorkFromHome,
 			FetchedAt:   now,
 		})
 	}
 
-	return jobs, nil
+	return jobs, result.Pagination.NextPageToken, nil
 }
 
-// buildQuery constructs a natural language search query from profile data
-func buildQuery(jobTitle, experienceLevel string) string {
-	if experienceLevel == "" {
-		return jobTitle
-	}
+// CacheKey is the phrase sent to Google Jobs, so profiles that differ only in
+// wording of the same search share a cache entry.
+func (c *Client) CacheKey(q jobsource.Query) string {
+	return buildQuery(q.Title, q.ExperienceLevel, q.RelatedTitles)
+}
 
-	levelMap := map[string]string{
-		"beginner":  "entry level",
-		"junior":    "junior",
-		"mid":       "mid level",
-		"senior":    "senior",
-		"lead":      "lead",
-		"manager":   "manager",
-		"executive": "executive",
+// buildChips encodes the date and employment filters as Google Jobs chips.
+func buildChips(q jobsource.Query) string {
+	var chips []string
+	if q.DatePosted != "" {
+		chips = append(chips, "date_posted:"+q.DatePosted)
 	}
-
-	level, ok := levelMap[experienceLevel]
-	if !ok {
-		level = experienceLevel
+	if t, ok := employmentChips[q.EmploymentType]; ok {
+		chips = append(chips, "employment_type:"+t)
 	}
+	return strings.Join(chips, ",")
+}
 
-	return fmt.Sprintf("%s %s jobs", level, jobTitle)
+// buildQuery constructs a natural language search query from profile data.
+// Related titles are searched as alternatives, e.g. "senior (Backend Engineer
+// OR Go Engineer) jobs".
+func buildQuery(jobTitle, experienceLevel string, related []string) string {
+	if len(related) > 0 {
+		jobTitle = "(" + strings.Join(append([]string{jobTitle}, related...), " OR ") + ")"
+	}
+	if experienceLevel == "" {
+		return jobTitle
+	}
+	return fmt.Sprintf("%s %s jobs", jobtitle.LevelQuery(experienceLevel), jobTitle)
 }
 
 // pickApplyLink prefers employer/board URLs from apply_options; share_link is only a Google Jobs shell URL.
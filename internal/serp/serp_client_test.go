@@ -1,6 +1,15 @@
 package serp
 
-import "testing"
+import (
+	"aiki/internal/jobsource"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestPickApplyLink(t *testing.T) {
 	google := "https://www.google.com/search?ibp=htl;jobs&q=test"
@@ -46,3 +55,102 @@ func TestIsGoogleJobsShellURL(t *testing.T) {
 		}
 	}
 }
+
+func TestSearch(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		b, err := os.ReadFile(filepath.Join("testdata", "google_jobs.json"))
+		if err != nil {
+			t.Errorf("read fixture: %v", err)
+		}
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key")
+	c.baseURL = srv.URL
+	jobs, err := c.Search(context.Background(), jobsource.Query{
+		Title:           "Backend Engineer",
+		ExperienceLevel: "senior",
+		Location:        "Lagos, Nigeria",
+	})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if got.Get("q") != "senior Backend Engineer jobs" || got.Get("location") != "Lagos, Nigeria" || got.Get("api_key") != "test-key" {
+		t.Errorf("unexpected params: %v", got)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+
+	j := jobs[0]
+	// Google job IDs stay unprefixed so existing cache rows still match.
+	if j.ExternalID != "eyJqb2JfdGl0bGUiOiJTZW5pb3IgQmFja2VuZCBFbmdpbmVlciJ9" {
+		t.Errorf("external id = %q", j.ExternalID)
+	}
+	if j.Link != "https://www.linkedin.com/jobs/view/3912345678?utm_campaign=google_jobs_apply" {
+		t.Errorf("link = %q", j.Link)
+	}
+	if j.Platform != "LinkedIn" || j.PostedAt != "3 days ago" || j.Salary == "" {
+		t.Errorf("unexpected job: %+v", j)
+	}
+	if jobs[1].Link != "https://www.google.com/search?ibp=htl;jobs&q=backend#htidocid=def" {
+		t.Errorf("expected share link fallback, got %q", jobs[1].Link)
+	}
+}
+
+func TestSearchPage_FiltersAndToken(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		b, _ := os.ReadFile(filepath.Join("testdata", "google_jobs.json"))
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	c := NewClient("test-key")
+	c.baseURL = srv.URL
+	_, next, err := c.SearchPage(context.Background(), jobsource.Query{
+		Title:          "Backend Engineer",
+		DatePosted:     "week",
+		EmploymentType: "full_time",
+		RemoteOnly:     true,
+		Country:        "ng",
+		Language:       "en",
+		ExcludeTerms:   []string{"senior", "lead"},
+	}, "prev-token")
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if next != "eyJmYyI6IkVvd0RDc3dDIn0" {
+		t.Errorf("next = %q", next)
+	}
+	if got.Get("q") != "Backend Engineer -senior -lead" {
+		t.Errorf("q = %q", got.Get("q"))
+	}
+	if got.Get("chips") != "date_posted:week,employment_type:FULLTIME" || got.Get("ltype") != "1" || got.Get("next_page_token") != "prev-token" {
+		t.Errorf("unexpected params: %v", got)
+	}
+	if got.Get("gl") != "ng" || got.Get("hl") != "en" {
+		t.Errorf("gl = %q, hl = %q", got.Get("gl"), got.Get("hl"))
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	cases := []struct {
+		title, level string
+		related      []string
+		want         string
+	}{
+		{"Backend Engineer", "", nil, "Backend Engineer"},
+		{"Backend Engineer", "beginner", nil, "entry level Backend Engineer jobs"},
+		{"Backend Engineer", "senior", []string{"Go Engineer"}, "senior (Backend Engineer OR Go Engineer) jobs"},
+	}
+	for _, c := range cases {
+		if got := buildQuery(c.title, c.level, c.related); got != c.want {
+			t.Errorf("buildQuery(%q, %q, %v) = %q, want %q", c.title, c.level, c.related, got, c.want)
+		}
+	}
+}
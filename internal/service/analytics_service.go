@@ -0,0 +1,206 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	defaultAnalyticsWeeks = 12
+	maxAnalyticsWeeks     = 52
+)
+
+type AnalyticsService interface {
+	GetApplicationAnalytics(ctx context.Context, userID int32, weeks int) (*domain.ApplicationAnalytics, error)
+}
+
+type analyticsService struct {
+	analyticsRepo repository.AnalyticsRepository
+	userRepo      repository.UserRepository
+}
+
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, userRepo repository.UserRepository) AnalyticsService {
+	return &analyticsService{analyticsRepo: analyticsRepo, userRepo: userRepo}
+}
+
+// GetApplicationAnalytics reports on the last `weeks` calendar weeks, the
+// current one included. Weeks start on Monday in the user's time zone.
+func (s *analyticsService) GetApplicationAnalytics(ctx context.Context, userID int32, weeks int) (*domain.ApplicationAnalytics, error) {
+	if weeks <= 0 || weeks > maxAnalyticsWeeks {
+		weeks = defaultAnalyticsWeeks
+	}
+
+	now := time.Now().In(userLocation(ctx, s.userRepo, userID))
+	weekday := int(now.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	from := normalizeDate(now.AddDate(0, 0, -(weekday-1)-7*(weeks-1)))
+
+	rows, err := s.analyticsRepo.GetApplicationFunnel(ctx, userID, from, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildApplicationAnalytics(rows, from, now, weeks), nil
+}
+
+// buildApplicationAnalytics buckets the rows into weeks of from's location.
+func buildApplicationAnalytics(rows []domain.ApplicationFunnelRow, from, to time.Time, weeks int) *domain.ApplicationAnalytics {
+	start := calendarDay(from)
+	end := calendarDay(to)
+	weekIndex := func(t *time.Time) int {
+		if t == nil {
+			return -1
+		}
+		day := calendarDay(t.In(from.Location()))
+		if day.Before(start) || day.After(end) {
+			return -1
+		}
+		idx := int(day.Sub(start).Hours()/24) / 7
+		if idx >= weeks {
+			return -1
+		}
+		return idx
+	}
+
+	points := make([]domain.ApplicationChartPoint, weeks)
+	for i := range points {
+		weekStart := start.AddDate(0, 0, 7*i)
+		year, week := weekStart.ISOWeek()
+		points[i] = domain.ApplicationChartPoint{
+			Label:     weekStart.Format("Jan 2"),
+			BucketKey: fmt.Sprintf("%04d-W%02d", year, week),
+		}
+	}
+
+	var total domain.FunnelConversion
+	byPlatform := map[string]*domain.FunnelConversion{}
+	bySource := map[string]*domain.FunnelConversion{
+		domain.JobSourceManual:      {},
+		domain.JobSourceRecommended: {},
+	}
+	var responseDays []float64
+
+	for _, row := range rows {
+		if idx := weekIndex(row.InterviewAt); idx >= 0 {
+			points[idx].Interviews++
+		}
+		if idx := weekIndex(row.OfferAt); idx >= 0 {
+			points[idx].Offers++
+		}
+		if idx := weekIndex(row.RejectedAt); idx >= 0 {
+			points[idx].Rejections++
+		}
+
+		// Conversion figures follow the cohort of applications sent in the period.
+		idx := weekIndex(row.AppliedAt)
+		if idx < 0 {
+			continue
+		}
+		points[idx].Applications++
+
+		if byPlatform[row.Platform] == nil {
+			byPlatform[row.Platform] = &domain.FunnelConversion{}
+		}
+		if bySource[row.Source] == nil {
+			bySource[row.Source] = &domain.FunnelConversion{}
+		}
+		for _, f := range []*domain.FunnelConversion{&total, byPlatform[row.Platform], bySource[row.Source]} {
+			countFunnelRow(f, row)
+		}
+
+		if row.FirstResponseAt != nil {
+			responseDays = append(responseDays, row.FirstResponseAt.Sub(*row.AppliedAt).Hours()/24)
+		}
+	}
+
+	finishFunnel(&total)
+	analytics := &domain.ApplicationAnalytics{
+		From:                      start.Format("2006-01-02"),
+		To:                        end.Format("2006-01-02"),
+		Weeks:                     weeks,
+		TotalApplications:         total.Applied,
+		ApplicationsPerWeek:       roundTo(float64(total.Applied)/float64(weeks), 2),
+		Funnel:                    total,
+		ResponseRate:              ratio(int64(len(responseDays)), total.Applied),
+		MedianDaysToFirstResponse: median(responseDays),
+		ByPlatform:                breakdown(byPlatform),
+		BySource:                  breakdown(bySource),
+		ChartPoints:               points,
+	}
+	return analytics
+}
+
+func countFunnelRow(f *domain.FunnelConversion, row domain.ApplicationFunnelRow) {
+	f.Applied++
+	if row.InterviewAt != nil {
+		f.Interviews++
+	}
+	if row.OfferAt != nil {
+		f.Offers++
+	}
+	if row.RejectedAt != nil {
+		f.Rejections++
+	}
+}
+
+func finishFunnel(f *domain.FunnelConversion) {
+	f.AppliedToInterview = ratio(f.Interviews, f.Applied)
+	f.InterviewToOffer = ratio(f.Offers, f.Interviews)
+	f.AppliedToOffer = ratio(f.Offers, f.Applied)
+}
+
+// breakdown orders groups by applications sent, most first.
+func breakdown(groups map[string]*domain.FunnelConversion) []domain.FunnelBreakdown {
+	out := make([]domain.FunnelBreakdown, 0, len(groups))
+	for key, f := range groups {
+		finishFunnel(f)
+		out = append(out, domain.FunnelBreakdown{Key: key, FunnelConversion: *f})
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Applied != out[b].Applied {
+			return out[a].Applied > out[b].Applied
+		}
+		return out[a].Key < out[b].Key
+	})
+	return out
+}
+
+func median(values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	m := sorted[mid]
+	if len(sorted)%2 == 0 {
+		m = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	m = roundTo(m, 1)
+	return &m
+}
+
+func ratio(part, whole int64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return roundTo(float64(part)/float64(whole), 4)
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+// calendarDay drops the time and location so dates read from the database and
+// dates computed from the clock compare by their calendar day.
+func calendarDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
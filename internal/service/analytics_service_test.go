@@ -0,0 +1,102 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildApplicationAnalytics(t *testing.T) {
+	at := func(month time.Month, day int) *time.Time {
+		v := time.Date(2026, month, day, 10, 0, 0, 0, time.UTC)
+		return &v
+	}
+
+	// Two weeks starting Monday 2 March.
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 13, 18, 0, 0, 0, time.UTC)
+
+	rows := []domain.ApplicationFunnelRow{
+		// Applied before the window; only the interview counts towards the chart.
+		{JobID: 1, Platform: "linkedin", Source: domain.JobSourceManual, AppliedAt: at(2, 20), InterviewAt: at(3, 3), FirstResponseAt: at(3, 3)},
+		{JobID: 2, Platform: "linkedin", Source: domain.JobSourceRecommended, AppliedAt: at(3, 2), InterviewAt: at(3, 4), OfferAt: at(3, 10), FirstResponseAt: at(3, 4)},
+		{JobID: 3, Platform: "indeed", Source: domain.JobSourceManual, AppliedAt: at(3, 3), RejectedAt: at(3, 9), FirstResponseAt: at(3, 9)},
+		{JobID: 4, Platform: "linkedin", Source: domain.JobSourceManual, AppliedAt: at(3, 10)},
+	}
+
+	got := buildApplicationAnalytics(rows, from, to, 2)
+
+	assert.Equal(t, "2026-03-02", got.From)
+	assert.Equal(t, "2026-03-13", got.To)
+	assert.Equal(t, int64(3), got.TotalApplications)
+	assert.Equal(t, 1.5, got.ApplicationsPerWeek)
+
+	assert.Equal(t, int64(1), got.Funnel.Interviews)
+	assert.Equal(t, int64(1), got.Funnel.Offers)
+	assert.Equal(t, int64(1), got.Funnel.Rejections)
+	assert.Equal(t, 0.3333, got.Funnel.AppliedToInterview)
+	assert.Equal(t, 1.0, got.Funnel.InterviewToOffer)
+	assert.Equal(t, 0.6667, got.ResponseRate)
+
+	// Responses after 2 and 6 days.
+	require.NotNil(t, got.MedianDaysToFirstResponse)
+	assert.Equal(t, 4.0, *got.MedianDaysToFirstResponse)
+
+	require.Len(t, got.ByPlatform, 2)
+	assert.Equal(t, "linkedin", got.ByPlatform[0].Key)
+	assert.Equal(t, int64(2), got.ByPlatform[0].Applied)
+	assert.Equal(t, 0.5, got.ByPlatform[0].AppliedToOffer)
+
+	require.Len(t, got.BySource, 2)
+	assert.Equal(t, domain.JobSourceManual, got.BySource[0].Key)
+	assert.Equal(t, int64(2), got.BySource[0].Applied)
+	assert.Equal(t, int64(1), got.BySource[1].Offers)
+
+	require.Len(t, got.ChartPoints, 2)
+	assert.Equal(t, "Mar 2", got.ChartPoints[0].Label)
+	assert.Equal(t, "2026-W10", got.ChartPoints[0].BucketKey)
+	assert.Equal(t, int64(2), got.ChartPoints[0].Applications)
+	assert.Equal(t, int64(2), got.ChartPoints[0].Interviews)
+	assert.Equal(t, int64(1), got.ChartPoints[1].Applications)
+	assert.Equal(t, int64(1), got.ChartPoints[1].Offers)
+	assert.Equal(t, int64(1), got.ChartPoints[1].Rejections)
+}
+
+func TestBuildApplicationAnalyticsInUserTimeZone(t *testing.T) {
+	nairobi, err := time.LoadLocation("Africa/Nairobi")
+	require.NoError(t, err)
+
+	// Two weeks starting Monday 2 March in Nairobi.
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, nairobi)
+	to := time.Date(2026, 3, 13, 18, 0, 0, 0, nairobi)
+	// Times come back from the database in UTC. Sunday 8 March 22:30 UTC is
+	// Monday 9 March in Nairobi, the second week.
+	applied := time.Date(2026, 3, 8, 22, 30, 0, 0, time.UTC)
+	rows := []domain.ApplicationFunnelRow{
+		{JobID: 1, Platform: "linkedin", Source: domain.JobSourceManual, AppliedAt: &applied},
+	}
+
+	got := buildApplicationAnalytics(rows, from, to, 2)
+
+	assert.Equal(t, "2026-03-02", got.From)
+	require.Len(t, got.ChartPoints, 2)
+	assert.Equal(t, int64(0), got.ChartPoints[0].Applications)
+	assert.Equal(t, int64(1), got.ChartPoints[1].Applications)
+}
+
+func TestBuildApplicationAnalyticsEmpty(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	got := buildApplicationAnalytics(nil, from, from.AddDate(0, 0, 3), 1)
+
+	assert.Zero(t, got.TotalApplications)
+	assert.Zero(t, got.Funnel.AppliedToInterview)
+	assert.Nil(t, got.MedianDaysToFirstResponse)
+	assert.Empty(t, got.ByPlatform)
+	assert.Len(t, got.BySource, 2)
+	assert.Len(t, got.ChartPoints, 1)
+}
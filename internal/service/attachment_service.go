@@ -0,0 +1,182 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+type AttachmentService interface {
+	Upload(ctx context.Context, userID, jobID int32, fileName, kind string, data []byte) (*domain.JobAttachment, error)
+	List(ctx context.Context, userID, jobID int32) ([]domain.JobAttachment, error)
+	Download(ctx context.Context, userID, jobID, attachmentID int32) (*domain.JobAttachment, error)
+	Delete(ctx context.Context, userID, jobID, attachmentID int32) error
+	GetUsage(ctx context.Context, userID int32) (*domain.StorageUsage, error)
+	GetSnapshot(ctx context.Context, userID, jobID int32) (*domain.JobDescriptionSnapshot, error)
+}
+
+type attachmentService struct {
+	attachmentRepo repository.AttachmentRepository
+	jobRepo        repository.JobRepository
+	maxFileBytes   int64
+	quotaBytes     int64
+}
+
+func NewAttachmentService(
+	attachmentRepo repository.AttachmentRepository,
+	jobRepo repository.JobRepository,
+	maxFileBytes, quotaBytes int64,
+) AttachmentService {
+	return &attachmentService{
+		attachmentRepo: attachmentRepo,
+		jobRepo:        jobRepo,
+		maxFileBytes:   maxFileBytes,
+		quotaBytes:     quotaBytes,
+	}
+}
+
+// attachmentType is an allowed file extension, the content type it is served
+// with and what http.DetectContentType must report for the contents to match.
+type attachmentType struct {
+	contentType string
+	sniffed     string
+}
+
+var attachmentTypes = map[string]attachmentType{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".doc":  {"application/msword", "application/octet-stream"},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".txt":  {"text/plain; charset=utf-8", "text/plain"},
+	".md":   {"text/markdown; charset=utf-8", "text/plain"},
+	".png":  {"image/png", "image/png"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+}
+
+func (s *attachmentService) Upload(ctx context.Context, userID, jobID int32, fileName, kind string, data []byte) (*domain.JobAttachment, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	if int64(len(data)) > s.maxFileBytes {
+		return nil, domain.ErrFileSizeExceedsLimit
+	}
+
+	name := cleanFileName(fileName)
+	contentType, err := detectAttachmentType(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		kind = domain.AttachmentKindOther
+	}
+
+	return s.attachmentRepo.Create(ctx, &domain.JobAttachment{
+		JobID:       jobID,
+		UserID:      userID,
+		FileName:    name,
+		ContentType: contentType,
+		Kind:        kind,
+		SizeBytes:   int64(len(data)),
+		Data:        data,
+	}, s.quotaBytes)
+}
+
+func (s *attachmentService) List(ctx context.Context, userID, jobID int32) ([]domain.JobAttachment, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.ListByJobID(ctx, jobID)
+}
+
+func (s *attachmentService) Download(ctx context.Context, userID, jobID, attachmentID int32) (*domain.JobAttachment, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.Get(ctx, jobID, attachmentID)
+}
+
+func (s *attachmentService) Delete(ctx context.Context, userID, jobID, attachmentID int32) error {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return err
+	}
+	return s.attachmentRepo.Delete(ctx, jobID, attachmentID)
+}
+
+func (s *attachmentService) GetUsage(ctx context.Context, userID int32) (*domain.StorageUsage, error) {
+	used, err := s.attachmentRepo.GetUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StorageUsage{UsedBytes: used, QuotaBytes: s.quotaBytes, MaxFileBytes: s.maxFileBytes}, nil
+}
+
+func (s *attachmentService) GetSnapshot(ctx context.Context, userID, jobID int32) (*domain.JobDescriptionSnapshot, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetSnapshot(ctx, jobID)
+}
+
+func (s *attachmentService) verifyJob(ctx context.Context, userID, jobID int32) error {
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.UserId != userID {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+// detectAttachmentType checks the extension against the allow-list and the
+// file's leading bytes against the extension, so a renamed executable is
+// rejected. It returns the content type to store.
+func detectAttachmentType(fileName string, data []byte) (string, error) {
+	allowed, ok := attachmentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return "", domain.ErrUnsupportedFileType
+	}
+
+	sniffed := http.DetectContentType(data)
+	if i := strings.IndexByte(sniffed, ';'); i >= 0 {
+		sniffed = sniffed[:i]
+	}
+	if sniffed != allowed.sniffed {
+		return "", domain.ErrUnsupportedFileType
+	}
+	return allowed.contentType, nil
+}
+
+// cleanFileName drops any directory part and control characters and caps the
+// length, keeping the extension.
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		name = "attachment"
+	}
+
+	const maxLen = 255
+	if len(name) > maxLen {
+		ext := filepath.Ext(name)
+		if len(ext) > 16 {
+			ext = ""
+		}
+		name = strings.ToValidUTF8(name[:maxLen-len(ext)], "") + ext
+	}
+	return name
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAttachmentRepository is a mock implementation of AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *domain.JobAttachment, quotaBytes int64) (*domain.JobAttachment, error) {
+	args := m.Called(ctx, attachment, quotaBytes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.JobAttachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) ListByJobID(ctx context.Context, jobID int32) ([]domain.JobAttachment, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.JobAttachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Get(ctx context.Context, jobID, attachmentID int32) (*domain.JobAttachment, error) {
+	args := m.Called(ctx, jobID, attachmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.JobAttachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, jobID, attachmentID int32) error {
+	return m.Called(ctx, jobID, attachmentID).Error(0)
+}
+
+func (m *MockAttachmentRepository) GetUsage(ctx context.Context, userID int32) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) SaveSnapshot(ctx context.Context, snapshot *domain.JobDescriptionSnapshot) error {
+	return m.Called(ctx, snapshot).Error(0)
+}
+
+func (m *MockAttachmentRepository) GetSnapshot(ctx context.Context, jobID int32) (*domain.JobDescriptionSnapshot, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.JobDescriptionSnapshot), args.Error(1)
+}
+
+var pdfBytes = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n1 0 obj\n")
+
+func TestAttachmentService_Upload(t *testing.T) {
+	attachmentRepo := new(MockAttachmentRepository)
+	jobRepo := new(MockJobRepository)
+	svc := NewAttachmentService(attachmentRepo, jobRepo, 1024, 4096)
+
+	jobRepo.On("GetJobByID", mock.Anything, int32(7)).Return(&domain.Job{ID: 7, UserId: 1}, nil)
+	attachmentRepo.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.JobAttachment) bool {
+		return a.FileName == "offer.pdf" && a.ContentType == "application/pdf" &&
+			a.Kind == domain.AttachmentKindOther && a.SizeBytes == int64(len(pdfBytes))
+	}), int64(4096)).Return(&domain.JobAttachment{ID: 3, JobID: 7}, nil)
+
+	got, err := svc.Upload(context.Background(), 1, 7, "../../offer.pdf", "", pdfBytes)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), got.ID)
+	attachmentRepo.AssertExpectations(t)
+}
+
+func TestAttachmentService_UploadRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   int32
+		fileName string
+		data     []byte
+		wantErr  error
+	}{
+		{"someone else's job", 2, "offer.pdf", pdfBytes, domain.ErrUnauthorized},
+		{"too large", 1, "offer.pdf", make([]byte, 2048), domain.ErrFileSizeExceedsLimit},
+		{"extension not allowed", 1, "setup.exe", pdfBytes, domain.ErrUnsupportedFileType},
+		{"contents do not match extension", 1, "photo.png", pdfBytes, domain.ErrUnsupportedFileType},
+		{"empty", 1, "notes.txt", nil, domain.ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentRepo := new(MockAttachmentRepository)
+			jobRepo := new(MockJobRepository)
+			svc := NewAttachmentService(attachmentRepo, jobRepo, 1024, 4096)
+			jobRepo.On("GetJobByID", mock.Anything, int32(7)).Return(&domain.Job{ID: 7, UserId: 1}, nil)
+
+			_, err := svc.Upload(context.Background(), tt.userID, 7, tt.fileName, "", tt.data)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			attachmentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDetectAttachmentType(t *testing.T) {
+	docx := append([]byte("PK\x03\x04"), make([]byte, 40)...)
+
+	tests := []struct {
+		fileName string
+		data     []byte
+		want     string
+		wantErr  bool
+	}{
+		{"CV.PDF", pdfBytes, "application/pdf", false},
+		{"assignment.docx", docx, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", false},
+		{"notes.md", []byte("# Take-home\n\nBuild a CLI."), "text/markdown; charset=utf-8", false},
+		{"notes.txt", []byte("<script>alert(1)</script>"), "", true},
+		{"archive.zip", docx, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := detectAttachmentType(tt.fileName, tt.data)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, domain.ErrUnsupportedFileType, tt.fileName)
+			continue
+		}
+		require.NoError(t, err, tt.fileName)
+		assert.Equal(t, tt.want, got, tt.fileName)
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	assert.Equal(t, "offer.pdf", cleanFileName(`C:\Users\me\offer.pdf`))
+	assert.Equal(t, "my offer.pdf", cleanFileName("my \"offer\x00\".pdf"))
+	assert.Equal(t, "attachment", cleanFileName("  "))
+}
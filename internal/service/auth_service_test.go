@@ -50,6 +50,16 @@ func (m *MockUserRepository) UpdateUserJobSearchLocation(ctx context.Context, us
 	return nil
 }
 
+func (m *MockUserRepository) GetTimezone(ctx context.Context, userID int32) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateTimezone(ctx context.Context, userID int32, timezone string) error {
+	args := m.Called(ctx, userID, timezone)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) Create(ctx context.Context, user *domain.User, passwordHash string) (*domain.User, error) {
 	args := m.Called(ctx, user, passwordHash)
 	if args.Get(0) == nil {
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+type AutomationService interface {
+	ListRules(ctx context.Context, userID int32) ([]domain.AutomationRule, error)
+	ListTemplates() []domain.AutomationRule
+	CreateRule(ctx context.Context, userID int32, req *domain.AutomationRuleRequest) (*domain.AutomationRule, error)
+	UpdateRule(ctx context.Context, userID, ruleID int32, req *domain.AutomationRuleRequest) (*domain.AutomationRule, error)
+	DeleteRule(ctx context.Context, userID, ruleID int32) error
+	PreviewRule(ctx context.Context, userID, ruleID int32) (*domain.RulePreview, error)
+	PreviewDraft(ctx context.Context, userID int32, req *domain.AutomationRuleRequest) (*domain.RulePreview, error)
+	GetTimeline(ctx context.Context, userID, jobID int32) ([]domain.JobTimelineEvent, error)
+
+	// Scheduled jobs
+	RunRules(ctx context.Context)
+}
+
+type automationService struct {
+	automationRepo repository.AutomationRepository
+	jobRepo        repository.JobRepository
+	notifService   NotificationService
+}
+
+func NewAutomationService(
+	automationRepo repository.AutomationRepository,
+	jobRepo repository.JobRepository,
+	notifService NotificationService,
+) AutomationService {
+	return &automationService{
+		automationRepo: automationRepo,
+		jobRepo:        jobRepo,
+		notifService:   notifService,
+	}
+}
+
+func (s *automationService) ListRules(ctx context.Context, userID int32) ([]domain.AutomationRule, error) {
+	return s.automationRepo.ListRules(ctx, userID)
+}
+
+func (s *automationService) ListTemplates() []domain.AutomationRule {
+	return domain.AutomationRuleTemplates
+}
+
+// CreateRule saves a new rule. Rules are disabled unless the request enables
+// them, so they can be previewed first.
+func (s *automationService) CreateRule(ctx context.Context, userID int32, req *domain.AutomationRuleRequest) (*domain.AutomationRule, error) {
+	rule := req.ToDomain(userID)
+	if err := validateRule(&rule); err != nil {
+		return nil, err
+	}
+	return s.automationRepo.CreateRule(ctx, &rule)
+}
+
+// UpdateRule replaces the rule's settings. Enabled is left as it was when the
+// request omits it.
+func (s *automationService) UpdateRule(ctx context.Context, userID, ruleID int32, req *domain.AutomationRuleRequest) (*domain.AutomationRule, error) {
+	existing, err := s.automationRepo.GetRule(ctx, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := req.ToDomain(userID)
+	rule.ID = ruleID
+	if req.Enabled == nil {
+		rule.Enabled = existing.Enabled
+	}
+	if err := validateRule(&rule); err != nil {
+		return nil, err
+	}
+	return s.automationRepo.UpdateRule(ctx, &rule)
+}
+
+func (s *automationService) DeleteRule(ctx context.Context, userID, ruleID int32) error {
+	return s.automationRepo.DeleteRule(ctx, ruleID, userID)
+}
+
+// PreviewRule lists the jobs a saved rule would act on if it ran now,
+// whether or not it is enabled. Nothing is changed.
+func (s *automationService) PreviewRule(ctx context.Context, userID, ruleID int32) (*domain.RulePreview, error) {
+	rule, err := s.automationRepo.GetRule(ctx, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.preview(ctx, rule)
+}
+
+// PreviewDraft does the same for a rule that has not been saved.
+func (s *automationService) PreviewDraft(ctx context.Context, userID int32, req *domain.AutomationRuleRequest) (*domain.RulePreview, error) {
+	rule := req.ToDomain(userID)
+	if err := validateRule(&rule); err != nil {
+		return nil, err
+	}
+	return s.preview(ctx, &rule)
+}
+
+func (s *automationService) GetTimeline(ctx context.Context, userID, jobID int32) ([]domain.JobTimelineEvent, error) {
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserId != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	return s.automationRepo.ListJobTimeline(ctx, jobID)
+}
+
+// RunRules evaluates every enabled rule and applies what it matches. A
+// failure on one rule or job is logged and the run carries on. It is run
+// daily by the scheduler.
+func (s *automationService) RunRules(ctx context.Context) {
+	rules, err := s.automationRepo.ListEnabledRules(ctx)
+	if err != nil {
+		log.Printf("failed to list automation rules: %v", err)
+		return
+	}
+
+	now := time.Now()
+	applied := 0
+	for i := range rules {
+		rule := &rules[i]
+		candidates, err := s.automationRepo.ListRuleCandidates(ctx, rule)
+		if err != nil {
+			log.Printf("failed to list candidates for automation rule %d: %v", rule.ID, err)
+			continue
+		}
+
+		for _, c := range candidates {
+			match, ok := evaluateRule(rule, c, now)
+			if !ok {
+				continue
+			}
+
+			message := fmt.Sprintf("%q: in %s for %d days", rule.Name, rule.Status, match.DaysInStatus)
+			updated, err := s.automationRepo.ApplyRuleActions(ctx, rule, c.JobID, match.Actions, message)
+			if err != nil {
+				log.Printf("failed to apply automation rule %d to job %d: %v", rule.ID, c.JobID, err)
+				continue
+			}
+			if !updated {
+				continue
+			}
+			applied++
+
+			for _, action := range match.Actions {
+				if action == domain.RuleActionNudge {
+					s.notifService.NotifyJobNudge(ctx, c.UserID, c.Title, c.CompanyName, match.DaysInStatus)
+				}
+			}
+		}
+	}
+	log.Printf("automation rules: %d rules run, %d jobs updated", len(rules), applied)
+}
+
+func (s *automationService) preview(ctx context.Context, rule *domain.AutomationRule) (*domain.RulePreview, error) {
+	candidates, err := s.automationRepo.ListRuleCandidates(ctx, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	matches := []domain.RuleMatch{}
+	for _, c := range candidates {
+		if match, ok := evaluateRule(rule, c, now); ok {
+			matches = append(matches, match)
+		}
+	}
+	return &domain.RulePreview{Rule: *rule, Matches: matches}, nil
+}
+
+// validateRule rejects rules that would never do anything.
+func validateRule(rule *domain.AutomationRule) error {
+	if !rule.MarkGhosted && !rule.Nudge && !rule.Archive {
+		return domain.ErrRuleHasNoAction
+	}
+	return nil
+}
+
+// evaluateRule decides what the rule does to the candidate now. The rule
+// fires once the job has been in the rule's status for more than AfterDays
+// days:
+//   - mark ghosted, unless the job already is;
+//   - nudge, unless the rule already nudged since the job entered the status;
+//   - archive straight away without a nudge, or GraceDays after the nudge.
+//
+// A job the rule archived in this stay in the status, which the user then
+// restored, is left alone until its status changes.
+func evaluateRule(rule *domain.AutomationRule, c domain.RuleCandidate, now time.Time) (domain.RuleMatch, bool) {
+	if c.Status != rule.Status {
+		return domain.RuleMatch{}, false
+	}
+	inStatus := now.Sub(c.StatusSince)
+	if inStatus <= daysDuration(rule.AfterDays) {
+		return domain.RuleMatch{}, false
+	}
+	if c.ArchivedAt != nil && !c.ArchivedAt.Before(c.StatusSince) {
+		return domain.RuleMatch{}, false
+	}
+
+	nudged := c.NudgedAt != nil && !c.NudgedAt.Before(c.StatusSince)
+
+	var actions []string
+	if rule.MarkGhosted && !c.Ghosted {
+		actions = append(actions, domain.RuleActionMarkGhosted)
+	}
+	if rule.Nudge && !nudged {
+		actions = append(actions, domain.RuleActionNudge)
+	}
+	if rule.Archive {
+		switch {
+		case !rule.Nudge:
+			actions = append(actions, domain.RuleActionArchive)
+		case nudged && now.Sub(*c.NudgedAt) >= daysDuration(rule.GraceDays):
+			actions = append(actions, domain.RuleActionArchive)
+		case !nudged && rule.GraceDays == 0:
+			actions = append(actions, domain.RuleActionArchive)
+		}
+	}
+	if len(actions) == 0 {
+		return domain.RuleMatch{}, false
+	}
+
+	return domain.RuleMatch{
+		JobID:        c.JobID,
+		Title:        c.Title,
+		CompanyName:  c.CompanyName,
+		Status:       c.Status,
+		StatusSince:  c.StatusSince,
+		DaysInStatus: int32(inStatus / (24 * time.Hour)),
+		Actions:      actions,
+	}, true
+}
+
+func daysDuration(n int32) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
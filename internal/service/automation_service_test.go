@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateRule(t *testing.T) {
+	now := time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) *time.Time {
+		ts := now.AddDate(0, 0, -n)
+		return &ts
+	}
+
+	ghostRule := &domain.AutomationRule{ID: 1, Status: domain.JobStatusApplied, AfterDays: 30, MarkGhosted: true, Archive: true}
+	nudgeRule := &domain.AutomationRule{ID: 2, Status: domain.JobStatusSaved, AfterDays: 14, Nudge: true, Archive: true, GraceDays: 7}
+
+	tests := []struct {
+		name      string
+		rule      *domain.AutomationRule
+		candidate domain.RuleCandidate
+		want      []string
+	}{
+		{
+			name:      "not stale yet",
+			rule:      ghostRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusApplied, StatusSince: *daysAgo(30)},
+		},
+		{
+			name:      "stale applied job is ghosted and archived",
+			rule:      ghostRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusApplied, StatusSince: *daysAgo(31)},
+			want:      []string{domain.RuleActionMarkGhosted, domain.RuleActionArchive},
+		},
+		{
+			name:      "different status",
+			rule:      ghostRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusInterview, StatusSince: *daysAgo(90)},
+		},
+		{
+			name: "restored after the rule archived it",
+			rule: ghostRule,
+			candidate: domain.RuleCandidate{
+				Status: domain.JobStatusApplied, StatusSince: *daysAgo(60), Ghosted: true, ArchivedAt: daysAgo(29),
+			},
+		},
+		{
+			name: "archived in an earlier stay in the status",
+			rule: ghostRule,
+			candidate: domain.RuleCandidate{
+				Status: domain.JobStatusApplied, StatusSince: *daysAgo(40), ArchivedAt: daysAgo(100),
+			},
+			want: []string{domain.RuleActionMarkGhosted, domain.RuleActionArchive},
+		},
+		{
+			name:      "first run nudges",
+			rule:      nudgeRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusSaved, StatusSince: *daysAgo(15)},
+			want:      []string{domain.RuleActionNudge},
+		},
+		{
+			name:      "within the grace period",
+			rule:      nudgeRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusSaved, StatusSince: *daysAgo(20), NudgedAt: daysAgo(6)},
+		},
+		{
+			name:      "archived once the grace period is over",
+			rule:      nudgeRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusSaved, StatusSince: *daysAgo(22), NudgedAt: daysAgo(7)},
+			want:      []string{domain.RuleActionArchive},
+		},
+		{
+			name:      "nudge from an earlier stay does not count",
+			rule:      nudgeRule,
+			candidate: domain.RuleCandidate{Status: domain.JobStatusSaved, StatusSince: *daysAgo(15), NudgedAt: daysAgo(40)},
+			want:      []string{domain.RuleActionNudge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, ok := evaluateRule(tt.rule, tt.candidate, now)
+			if tt.want == nil {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.want, match.Actions)
+		})
+	}
+}
+
+func TestEvaluateRule_DaysInStatus(t *testing.T) {
+	now := time.Date(2026, 6, 1, 7, 0, 0, 0, time.UTC)
+	rule := &domain.AutomationRule{Status: domain.JobStatusApplied, AfterDays: 30, MarkGhosted: true}
+
+	match, ok := evaluateRule(rule, domain.RuleCandidate{
+		JobID:       9,
+		Status:      domain.JobStatusApplied,
+		StatusSince: now.Add(-(45*24 + 5) * time.Hour),
+	}, now)
+
+	require.True(t, ok)
+	assert.Equal(t, int32(9), match.JobID)
+	assert.Equal(t, int32(45), match.DaysInStatus)
+}
+
+func TestAutomationService_CreateRuleWithoutAction(t *testing.T) {
+	svc := NewAutomationService(nil, nil, nil)
+
+	_, err := svc.CreateRule(context.Background(), 1, &domain.AutomationRuleRequest{
+		Name:      "Does nothing",
+		Status:    domain.JobStatusApplied,
+		AfterDays: 30,
+	})
+
+	assert.ErrorIs(t, err, domain.ErrRuleHasNoAction)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"strings"
+	"time"
+)
+
+type ContactService interface {
+	List(ctx context.Context, userID int32, filter domain.ContactFilter) ([]domain.Contact, error)
+	Get(ctx context.Context, userID, contactID int32) (*domain.ContactDetail, error)
+	Create(ctx context.Context, userID int32, req *domain.ContactRequest) (*domain.Contact, error)
+	Update(ctx context.Context, userID, contactID int32, req *domain.ContactRequest) (*domain.Contact, error)
+	Delete(ctx context.Context, userID, contactID int32) error
+	LinkJob(ctx context.Context, userID, contactID, jobID int32) (*domain.Contact, error)
+	UnlinkJob(ctx context.Context, userID, contactID, jobID int32) (*domain.Contact, error)
+	AddInteraction(ctx context.Context, userID, contactID int32, req *domain.ContactInteractionRequest) (*domain.ContactInteraction, error)
+	DeleteInteraction(ctx context.Context, userID, contactID, interactionID int32) error
+}
+
+type contactService struct {
+	contactRepo repository.ContactRepository
+	jobRepo     repository.JobRepository
+}
+
+func NewContactService(contactRepo repository.ContactRepository, jobRepo repository.JobRepository) ContactService {
+	return &contactService{
+		contactRepo: contactRepo,
+		jobRepo:     jobRepo,
+	}
+}
+
+func (s *contactService) List(ctx context.Context, userID int32, filter domain.ContactFilter) ([]domain.Contact, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	return s.contactRepo.List(ctx, userID, filter)
+}
+
+func (s *contactService) Get(ctx context.Context, userID, contactID int32) (*domain.ContactDetail, error) {
+	contact, err := s.contactRepo.GetByID(ctx, contactID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions, err := s.contactRepo.ListInteractions(ctx, contactID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ContactDetail{Contact: *contact, Interactions: interactions}, nil
+}
+
+func (s *contactService) Create(ctx context.Context, userID int32, req *domain.ContactRequest) (*domain.Contact, error) {
+	contact := req.ToDomain(userID)
+	contact.Name = strings.TrimSpace(contact.Name)
+	return s.contactRepo.Create(ctx, &contact)
+}
+
+func (s *contactService) Update(ctx context.Context, userID, contactID int32, req *domain.ContactRequest) (*domain.Contact, error) {
+	contact := req.ToDomain(userID)
+	contact.ID = contactID
+	contact.Name = strings.TrimSpace(contact.Name)
+	return s.contactRepo.Update(ctx, &contact)
+}
+
+func (s *contactService) Delete(ctx context.Context, userID, contactID int32) error {
+	return s.contactRepo.Delete(ctx, contactID, userID)
+}
+
+func (s *contactService) LinkJob(ctx context.Context, userID, contactID, jobID int32) (*domain.Contact, error) {
+	if err := s.verifyOwnership(ctx, userID, contactID, jobID); err != nil {
+		return nil, err
+	}
+	if err := s.contactRepo.LinkJob(ctx, contactID, jobID); err != nil {
+		return nil, err
+	}
+	return s.contactRepo.GetByID(ctx, contactID, userID)
+}
+
+func (s *contactService) UnlinkJob(ctx context.Context, userID, contactID, jobID int32) (*domain.Contact, error) {
+	if _, err := s.contactRepo.GetByID(ctx, contactID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.contactRepo.UnlinkJob(ctx, contactID, jobID); err != nil {
+		return nil, err
+	}
+	return s.contactRepo.GetByID(ctx, contactID, userID)
+}
+
+func (s *contactService) AddInteraction(ctx context.Context, userID, contactID int32, req *domain.ContactInteractionRequest) (*domain.ContactInteraction, error) {
+	if _, err := s.contactRepo.GetByID(ctx, contactID, userID); err != nil {
+		return nil, err
+	}
+
+	occurredAt := time.Now()
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+	}
+
+	return s.contactRepo.AddInteraction(ctx, userID, &domain.ContactInteraction{
+		ContactID:  contactID,
+		Type:       req.Type,
+		OccurredAt: occurredAt,
+		Notes:      strings.TrimSpace(req.Notes),
+	})
+}
+
+func (s *contactService) DeleteInteraction(ctx context.Context, userID, contactID, interactionID int32) error {
+	if _, err := s.contactRepo.GetByID(ctx, contactID, userID); err != nil {
+		return err
+	}
+	return s.contactRepo.DeleteInteraction(ctx, interactionID, contactID)
+}
+
+func (s *contactService) verifyOwnership(ctx context.Context, userID, contactID, jobID int32) error {
+	if _, err := s.contactRepo.GetByID(ctx, contactID, userID); err != nil {
+		return err
+	}
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.UserId != userID {
+		return domain.ErrInvalidJobID
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockContactRepository is a mock implementation of ContactRepository
+type MockContactRepository struct {
+	mock.Mock
+}
+
+func (m *MockContactRepository) Create(ctx context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	args := m.Called(ctx, contact)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) Update(ctx context.Context, contact *domain.Contact) (*domain.Contact, error) {
+	args := m.Called(ctx, contact)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) Delete(ctx context.Context, contactID, userID int32) error {
+	return m.Called(ctx, contactID, userID).Error(0)
+}
+
+func (m *MockContactRepository) GetByID(ctx context.Context, contactID, userID int32) (*domain.Contact, error) {
+	args := m.Called(ctx, contactID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) List(ctx context.Context, userID int32, filter domain.ContactFilter) ([]domain.Contact, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) LinkJob(ctx context.Context, contactID, jobID int32) error {
+	return m.Called(ctx, contactID, jobID).Error(0)
+}
+
+func (m *MockContactRepository) UnlinkJob(ctx context.Context, contactID, jobID int32) error {
+	return m.Called(ctx, contactID, jobID).Error(0)
+}
+
+func (m *MockContactRepository) AddInteraction(ctx context.Context, userID int32, interaction *domain.ContactInteraction) (*domain.ContactInteraction, error) {
+	args := m.Called(ctx, userID, interaction)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ContactInteraction), args.Error(1)
+}
+
+func (m *MockContactRepository) ListInteractions(ctx context.Context, contactID int32) ([]domain.ContactInteraction, error) {
+	args := m.Called(ctx, contactID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ContactInteraction), args.Error(1)
+}
+
+func (m *MockContactRepository) DeleteInteraction(ctx context.Context, interactionID, contactID int32) error {
+	return m.Called(ctx, interactionID, contactID).Error(0)
+}
+
+func TestContactService_LinkJob(t *testing.T) {
+	ctx := context.Background()
+	contact := &domain.Contact{ID: 7, UserID: 1, Name: "Ada Recruiter"}
+
+	t.Run("rejects another user's job", func(t *testing.T) {
+		contactRepo := new(MockContactRepository)
+		jobRepo := new(MockJobRepository)
+		svc := NewContactService(contactRepo, jobRepo)
+		contactRepo.On("GetByID", ctx, int32(7), int32(1)).Return(contact, nil).Once()
+		jobRepo.On("GetJobByID", ctx, int32(42)).Return(&domain.Job{ID: 42, UserId: 2}, nil).Once()
+
+		_, err := svc.LinkJob(ctx, 1, 7, 42)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidJobID)
+		contactRepo.AssertNotCalled(t, "LinkJob", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("links own job", func(t *testing.T) {
+		contactRepo := new(MockContactRepository)
+		jobRepo := new(MockJobRepository)
+		svc := NewContactService(contactRepo, jobRepo)
+		linked := &domain.Contact{ID: 7, UserID: 1, Name: "Ada Recruiter", JobIDs: []int32{42}}
+		contactRepo.On("GetByID", ctx, int32(7), int32(1)).Return(contact, nil).Once()
+		jobRepo.On("GetJobByID", ctx, int32(42)).Return(&domain.Job{ID: 42, UserId: 1}, nil).Once()
+		contactRepo.On("LinkJob", ctx, int32(7), int32(42)).Return(nil).Once()
+		contactRepo.On("GetByID", ctx, int32(7), int32(1)).Return(linked, nil).Once()
+
+		got, err := svc.LinkJob(ctx, 1, 7, 42)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int32{42}, got.JobIDs)
+		contactRepo.AssertExpectations(t)
+	})
+}
+
+func TestContactService_AddInteraction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unknown contact", func(t *testing.T) {
+		contactRepo := new(MockContactRepository)
+		svc := NewContactService(contactRepo, new(MockJobRepository))
+		contactRepo.On("GetByID", ctx, int32(9), int32(1)).Return(nil, domain.ErrContactNotFound).Once()
+
+		_, err := svc.AddInteraction(ctx, 1, 9, &domain.ContactInteractionRequest{Type: domain.InteractionTypeCall})
+
+		assert.ErrorIs(t, err, domain.ErrContactNotFound)
+	})
+
+	t.Run("defaults occurred_at to now", func(t *testing.T) {
+		contactRepo := new(MockContactRepository)
+		svc := NewContactService(contactRepo, new(MockJobRepository))
+		contactRepo.On("GetByID", ctx, int32(7), int32(1)).Return(&domain.Contact{ID: 7, UserID: 1}, nil).Once()
+		contactRepo.On("AddInteraction", ctx, int32(1), mock.MatchedBy(func(i *domain.ContactInteraction) bool {
+			return i.ContactID == 7 &&
+				i.Type == domain.InteractionTypeCoffeeChat &&
+				i.Notes == "Talked about the platform team" &&
+				time.Since(i.OccurredAt) < time.Minute
+		})).Return(&domain.ContactInteraction{ID: 1, ContactID: 7}, nil).Once()
+
+		_, err := svc.AddInteraction(ctx, 1, 7, &domain.ContactInteractionRequest{
+			Type:  domain.InteractionTypeCoffeeChat,
+			Notes: "  Talked about the platform team ",
+		})
+
+		require.NoError(t, err)
+		contactRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+type GoalService interface {
+	ListGoals(ctx context.Context, userID int32) ([]domain.GoalProgress, error)
+	CreateGoal(ctx context.Context, userID int32, req *domain.GoalRequest) (*domain.GoalProgress, error)
+	UpdateGoal(ctx context.Context, userID, goalID int32, req *domain.GoalRequest) (*domain.GoalProgress, error)
+	DeleteGoal(ctx context.Context, userID, goalID int32) error
+	CheckIn(ctx context.Context, userID, goalID int32, req *domain.GoalCheckInRequest) (*domain.GoalProgress, error)
+
+	// Scheduled jobs
+	SendGoalNotifications(ctx context.Context)
+}
+
+type goalService struct {
+	goalRepo     repository.GoalRepository
+	userRepo     repository.UserRepository
+	notifService NotificationService
+}
+
+// NewGoalService measures goals over weeks and months of each user's own
+// time zone, read from userRepo.
+func NewGoalService(goalRepo repository.GoalRepository, userRepo repository.UserRepository, notifService NotificationService) GoalService {
+	return &goalService{goalRepo: goalRepo, userRepo: userRepo, notifService: notifService}
+}
+
+// ListGoals returns the user's goals with their progress in the current period.
+func (s *goalService) ListGoals(ctx context.Context, userID int32) ([]domain.GoalProgress, error) {
+	goals, err := s.goalRepo.ListGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, userID, goals, time.Now())
+}
+
+func (s *goalService) CreateGoal(ctx context.Context, userID int32, req *domain.GoalRequest) (*domain.GoalProgress, error) {
+	goal := req.ToDomain(userID)
+	created, err := s.goalRepo.CreateGoal(ctx, &goal)
+	if err != nil {
+		return nil, err
+	}
+	return s.progressOne(ctx, created)
+}
+
+func (s *goalService) UpdateGoal(ctx context.Context, userID, goalID int32, req *domain.GoalRequest) (*domain.GoalProgress, error) {
+	goal := req.ToDomain(userID)
+	goal.ID = goalID
+	updated, err := s.goalRepo.UpdateGoal(ctx, &goal)
+	if err != nil {
+		return nil, err
+	}
+	return s.progressOne(ctx, updated)
+}
+
+func (s *goalService) DeleteGoal(ctx context.Context, userID, goalID int32) error {
+	return s.goalRepo.DeleteGoal(ctx, goalID, userID)
+}
+
+// CheckIn logs progress on a manual goal, such as one mock interview done.
+func (s *goalService) CheckIn(ctx context.Context, userID, goalID int32, req *domain.GoalCheckInRequest) (*domain.GoalProgress, error) {
+	goal, err := s.goalRepo.GetGoal(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if goal.Metric != domain.GoalMetricManual {
+		return nil, domain.ErrGoalNotManual
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = 1
+	}
+	if err := s.goalRepo.AddCheckIn(ctx, goalID, amount, req.Note); err != nil {
+		return nil, err
+	}
+	return s.progressOne(ctx, goal)
+}
+
+// goalNotificationHour is the hour of each user's day goal notifications go
+// out at.
+const goalNotificationHour = 19
+
+// SendGoalNotifications tells users about goals they reached this period and
+// goals at risk close to the end of it, at 7PM their time. Each goal gets at
+// most one of each per period. It is run hourly by the scheduler.
+func (s *goalService) SendGoalNotifications(ctx context.Context) {
+	userIDs, err := s.goalRepo.ListUsersWithGoals(ctx, goalNotificationHour)
+	if err != nil {
+		log.Printf("failed to list users with goals: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		goals, err := s.goalRepo.ListGoals(ctx, userID)
+		if err != nil {
+			log.Printf("failed to list goals for user %d: %v", userID, err)
+			continue
+		}
+		progress, err := s.progress(ctx, userID, goals, now)
+		if err != nil {
+			log.Printf("failed to compute goal progress for user %d: %v", userID, err)
+			continue
+		}
+
+		for _, p := range progress {
+			switch {
+			case p.Status == domain.GoalStatusReached && !notifiedFor(p.ReachedNotifiedFor, p.PeriodStart):
+				s.notifService.NotifyGoalReached(ctx, userID, p.Title, p.Target)
+				if err := s.goalRepo.MarkReachedNotified(ctx, p.ID, p.PeriodStart); err != nil {
+					log.Printf("failed to mark goal %d as notified: %v", p.ID, err)
+				}
+			case p.Status == domain.GoalStatusAtRisk && p.DaysLeft <= atRiskWindow(p.Period) &&
+				!notifiedFor(p.AtRiskNotifiedFor, p.PeriodStart):
+				s.notifService.NotifyGoalAtRisk(ctx, userID, p.Title, p.Current, p.Target, p.DaysLeft)
+				if err := s.goalRepo.MarkAtRiskNotified(ctx, p.ID, p.PeriodStart); err != nil {
+					log.Printf("failed to mark goal %d as notified: %v", p.ID, err)
+				}
+			}
+		}
+	}
+}
+
+func (s *goalService) progressOne(ctx context.Context, goal *domain.Goal) (*domain.GoalProgress, error) {
+	progress, err := s.progress(ctx, goal.UserID, []domain.Goal{*goal}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &progress[0], nil
+}
+
+// progress loads the activity for each period the goals use and measures the
+// goals against it. Periods start at midnight in the user's time zone.
+func (s *goalService) progress(ctx context.Context, userID int32, goals []domain.Goal, now time.Time) ([]domain.GoalProgress, error) {
+	now = now.In(userLocation(ctx, s.userRepo, userID))
+	activity := map[string]*domain.GoalActivity{}
+	progress := make([]domain.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		start, end := goalPeriod(goal.Period, now)
+		a, ok := activity[goal.Period]
+		if !ok {
+			var err error
+			a, err = s.goalRepo.GetActivity(ctx, userID, start, end)
+			if err != nil {
+				return nil, err
+			}
+			activity[goal.Period] = a
+		}
+		progress = append(progress, buildGoalProgress(goal, goalCurrent(goal, a), start, end, now))
+	}
+	return progress, nil
+}
+
+// goalPeriod returns the period containing now: the week from Monday, or the
+// calendar month, in now's location. The end is exclusive.
+func goalPeriod(period string, now time.Time) (time.Time, time.Time) {
+	today := normalizeDate(now)
+	if period == domain.GoalPeriodMonthly {
+		start := today.AddDate(0, 0, 1-today.Day())
+		return start, start.AddDate(0, 1, 0)
+	}
+
+	weekday := int(today.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	start := today.AddDate(0, 0, -(weekday - 1))
+	return start, start.AddDate(0, 0, 7)
+}
+
+func goalCurrent(goal domain.Goal, activity *domain.GoalActivity) int32 {
+	switch goal.Metric {
+	case domain.GoalMetricApplications:
+		return activity.Applications
+	case domain.GoalMetricInterviews:
+		return activity.Interviews
+	case domain.GoalMetricFocusMinutes:
+		return activity.FocusSeconds / 60
+	case domain.GoalMetricFocusSessions:
+		return activity.FocusSessions
+	case domain.GoalMetricNetworking:
+		return activity.Networking
+	case domain.GoalMetricManual:
+		return activity.CheckIns[goal.ID]
+	default:
+		return 0
+	}
+}
+
+// buildGoalProgress measures current against the target. A goal is at risk
+// when it is behind a steady pace, counting whole units: with 10 applications
+// a week, 3 are expected by Wednesday noon (3.57 rounded down).
+func buildGoalProgress(goal domain.Goal, current int32, start, end, now time.Time) domain.GoalProgress {
+	p := domain.GoalProgress{
+		Goal:        goal,
+		Current:     current,
+		Percent:     int32(math.Min(100, float64(current)*100/float64(goal.Target))),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		DaysLeft:    int32(math.Ceil(end.Sub(now).Hours() / 24)),
+	}
+
+	elapsed := now.Sub(start).Seconds() / end.Sub(start).Seconds()
+	expected := math.Floor(float64(goal.Target) * elapsed)
+	switch {
+	case current >= goal.Target:
+		p.Status = domain.GoalStatusReached
+	case float64(current) < expected:
+		p.Status = domain.GoalStatusAtRisk
+	default:
+		p.Status = domain.GoalStatusOnTrack
+	}
+	return p
+}
+
+// atRiskWindow is how many days before the end of the period an at-risk goal
+// is worth a notification.
+func atRiskWindow(period string) int32 {
+	if period == domain.GoalPeriodMonthly {
+		return 5
+	}
+	return 2
+}
+
+func notifiedFor(notified *time.Time, periodStart time.Time) bool {
+	return notified != nil && calendarDay(*notified).Equal(calendarDay(periodStart))
+}
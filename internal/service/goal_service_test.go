@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalPeriod(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 6, 3, 15, 30, 0, 0, time.UTC)
+
+	start, end := goalPeriod(domain.GoalPeriodWeekly, now)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 6, 8, 0, 0, 0, 0, time.UTC), end)
+
+	start, end = goalPeriod(domain.GoalPeriodMonthly, now)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), end)
+
+	// Sunday belongs to the week that started on Monday.
+	start, _ = goalPeriod(domain.GoalPeriodWeekly, time.Date(2026, 6, 7, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), start)
+}
+
+func TestGoalPeriodInUserTimeZone(t *testing.T) {
+	nairobi, err := time.LoadLocation("Africa/Nairobi")
+	assert.NoError(t, err)
+
+	// Sunday 22:30 UTC is already Monday in Nairobi, the start of a new week.
+	now := time.Date(2026, 6, 7, 22, 30, 0, 0, time.UTC).In(nairobi)
+	start, end := goalPeriod(domain.GoalPeriodWeekly, now)
+	assert.True(t, start.Equal(time.Date(2026, 6, 8, 0, 0, 0, 0, nairobi)))
+	assert.True(t, end.Equal(time.Date(2026, 6, 15, 0, 0, 0, 0, nairobi)))
+	assert.True(t, start.Equal(time.Date(2026, 6, 7, 21, 0, 0, 0, time.UTC)))
+}
+
+func TestBuildGoalProgress(t *testing.T) {
+	start := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	goal := domain.Goal{ID: 1, Title: "Apply to 10 jobs", Metric: domain.GoalMetricApplications, Target: 10, Period: domain.GoalPeriodWeekly}
+	wednesdayNoon := time.Date(2026, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		current  int32
+		now      time.Time
+		status   string
+		percent  int32
+		daysLeft int32
+	}{
+		{"monday morning with nothing done", 0, start.Add(9 * time.Hour), domain.GoalStatusOnTrack, 0, 7},
+		{"keeping pace", 3, wednesdayNoon, domain.GoalStatusOnTrack, 30, 5},
+		{"behind pace", 2, wednesdayNoon, domain.GoalStatusAtRisk, 20, 5},
+		{"reached", 12, wednesdayNoon, domain.GoalStatusReached, 100, 5},
+		{"last evening", 8, time.Date(2026, 6, 7, 19, 0, 0, 0, time.UTC), domain.GoalStatusAtRisk, 80, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := buildGoalProgress(goal, tt.current, start, end, tt.now)
+			assert.Equal(t, tt.status, p.Status)
+			assert.Equal(t, tt.percent, p.Percent)
+			assert.Equal(t, tt.daysLeft, p.DaysLeft)
+		})
+	}
+}
+
+func TestGoalCurrent(t *testing.T) {
+	activity := &domain.GoalActivity{
+		Applications: 4,
+		FocusSeconds: 5*3600 + 59,
+		CheckIns:     map[int32]int32{7: 2},
+	}
+
+	assert.Equal(t, int32(4), goalCurrent(domain.Goal{Metric: domain.GoalMetricApplications}, activity))
+	assert.Equal(t, int32(300), goalCurrent(domain.Goal{Metric: domain.GoalMetricFocusMinutes}, activity))
+	assert.Equal(t, int32(2), goalCurrent(domain.Goal{ID: 7, Metric: domain.GoalMetricManual}, activity))
+	assert.Equal(t, int32(0), goalCurrent(domain.Goal{ID: 8, Metric: domain.GoalMetricManual}, activity))
+}
+
+func TestSendGoalNotificationsAtUsersEvening(t *testing.T) {
+	ctx := context.Background()
+	goalRepo := new(MockGoalRepository)
+	// Only users for whom it is now 7PM are picked.
+	goalRepo.On("ListUsersWithGoals", ctx, 19).Return([]int32{}, nil)
+
+	NewGoalService(goalRepo, nil, nil).SendGoalNotifications(ctx)
+	goalRepo.AssertExpectations(t)
+}
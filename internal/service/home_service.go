@@ -5,7 +5,10 @@ import (
 	"aiki/internal/pkg/response"
 	"aiki/internal/repository"
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 )
 
@@ -19,9 +22,17 @@ type HomeService interface {
 	StartSession(ctx context.Context, userID int32, req *domain.StartSessionRequest) (*domain.FocusSession, error)
 	PauseSession(ctx context.Context, userID int32, sessionID int32, elapsed int32) (*domain.FocusSession, error)
 	ResumeSession(ctx context.Context, userID int32, sessionID int32) (*domain.FocusSession, error)
-	EndSession(ctx context.Context, userID int32, sessionID int32, elapsed int32, completed bool) (*domain.FocusSession, error)
+	EndSession(ctx context.Context, userID int32, sessionID int32, req *domain.UpdateSessionRequest) (*domain.FocusSession, error)
 	GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error)
-	GetSessionHistory(ctx context.Context, userID int32, limit, offset int32) ([]domain.FocusSession, error)
+	GetSessionHistory(ctx context.Context, userID int32, filter domain.SessionFilter, limit, offset int32) ([]domain.FocusSession, error)
+	GetJobFocusTime(ctx context.Context, userID int32) ([]domain.JobFocusTime, error)
+	ExpireStaleSessions(ctx context.Context)
+
+	// Pomodoro presets
+	ListSessionPresets(ctx context.Context, userID int32) ([]domain.SessionPreset, error)
+	CreateSessionPreset(ctx context.Context, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error)
+	UpdateSessionPreset(ctx context.Context, userID, presetID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error)
+	DeleteSessionPreset(ctx context.Context, userID, presetID int32) error
 
 	// Streak
 	GetStreak(ctx context.Context, userID int32) (*domain.Streak, error)
@@ -36,12 +47,37 @@ type HomeService interface {
 
 type homeService struct {
 	homeRepo     repository.HomeRepository
+	userRepo     repository.UserRepository
+	jobRepo      repository.JobRepository
+	goalRepo     repository.GoalRepository
 	notifService NotificationService
+	goalService  GoalService
+	pauseLimit   time.Duration
 }
 
-// NewHomeService now requires a NotificationService for firing notifications.
-func NewHomeService(homeRepo repository.HomeRepository, notifService NotificationService) HomeService {
-	return &homeService{homeRepo: homeRepo, notifService: notifService}
+// NewHomeService now requires a NotificationService for firing notifications
+// and a GoalService for the goal progress on the home screen. The user
+// repository gives the time zone each user's days are counted in; the job and
+// goal repositories check what a session is linked to. A session left paused
+// for longer than pauseLimit is abandoned by ExpireStaleSessions.
+func NewHomeService(
+	homeRepo repository.HomeRepository,
+	userRepo repository.UserRepository,
+	jobRepo repository.JobRepository,
+	goalRepo repository.GoalRepository,
+	notifService NotificationService,
+	goalService GoalService,
+	pauseLimit time.Duration,
+) HomeService {
+	return &homeService{
+		homeRepo:     homeRepo,
+		userRepo:     userRepo,
+		jobRepo:      jobRepo,
+		goalRepo:     goalRepo,
+		notifService: notifService,
+		goalService:  goalService,
+		pauseLimit:   pauseLimit,
+	}
 }
 
 // ─────────────────────────────────────────
@@ -54,12 +90,12 @@ func (s *homeService) GetHomeScreenData(ctx context.Context, userID int32) (*dom
 		return nil, err
 	}
 
-	activeSession, err := s.homeRepo.GetActiveSession(ctx, userID)
+	activeSession, err := s.GetActiveSession(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
+	now := time.Now().In(userLocation(ctx, s.userRepo, userID))
 	weekday := int(now.Weekday())
 	if weekday == 0 {
 		weekday = 7
@@ -88,12 +124,18 @@ func (s *homeService) GetHomeScreenData(ctx context.Context, userID int32) (*dom
 		return nil, err
 	}
 
+	goals, err := s.goalService.ListGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &domain.HomeScreenData{
 		Streak:         streak,
 		ActiveSession:  activeSession,
 		WeeklyProgress: *weeklyProgress,
 		RecentBadges:   recentBadges,
 		TotalBadges:    totalBadges,
+		Goals:          goals,
 	}, nil
 }
 
@@ -101,6 +143,16 @@ func (s *homeService) GetHomeScreenData(ctx context.Context, userID int32) (*dom
 // Focus Sessions
 // ─────────────────────────────────────────
 
+// The server times focus sessions itself, from the segments their clock ran.
+// The client's own count is only a hint, which can lower the server's but
+// never raise it. A count that strays from the server's by more than
+// sessionClockTolerance seconds, or 1/sessionClockToleranceRatio of the time,
+// flags the session.
+const (
+	sessionClockTolerance      = 30
+	sessionClockToleranceRatio = 20
+)
+
 func (s *homeService) StartSession(ctx context.Context, userID int32, req *domain.StartSessionRequest) (*domain.FocusSession, error) {
 	existing, err := s.homeRepo.GetActiveSession(ctx, userID)
 	if err != nil {
@@ -109,70 +161,342 @@ func (s *homeService) StartSession(ctx context.Context, userID int32, req *domai
 	if existing != nil {
 		return nil, response.ErrSessionAlreadyActive
 	}
-	return s.homeRepo.CreateSession(ctx, userID, req.DurationSeconds)
+	if req.JobID != nil {
+		job, err := s.jobRepo.GetJobByID(ctx, *req.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.UserId != userID {
+			return nil, domain.ErrInvalidJobID
+		}
+	}
+	if req.GoalID != nil {
+		if _, err := s.goalRepo.GetGoal(ctx, *req.GoalID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.resolvePlan(ctx, userID, req); err != nil {
+		return nil, err
+	}
+	req.Intention = strings.TrimSpace(req.Intention)
+
+	now := time.Now()
+	session, err := s.homeRepo.CreateSession(ctx, userID, req, now)
+	if err != nil {
+		return nil, err
+	}
+	return s.withPhase(ctx, session, now)
 }
 
-func (s *homeService) PauseSession(ctx context.Context, userID int32, sessionID int32, elapsed int32) (*domain.FocusSession, error) {
-	session, err := s.homeRepo.GetSessionByID(ctx, sessionID)
+// resolvePlan settles the mode of a new session. A pomodoro session takes its
+// plan from the request or the user's preset, and its duration from the plan.
+func (s *homeService) resolvePlan(ctx context.Context, userID int32, req *domain.StartSessionRequest) error {
+	if req.Mode == "" {
+		req.Mode = domain.SessionModeSingle
+		if req.Pomodoro != nil || req.PresetID != nil {
+			req.Mode = domain.SessionModePomodoro
+		}
+	}
+
+	if req.Mode == domain.SessionModeSingle {
+		req.Pomodoro = nil
+		if req.DurationSeconds == 0 {
+			return domain.ErrInvalidSessionPlan
+		}
+		return nil
+	}
+
+	if req.PresetID != nil {
+		preset, err := s.homeRepo.GetSessionPreset(ctx, *req.PresetID, userID)
+		if err != nil {
+			return err
+		}
+		req.Pomodoro = &preset.PomodoroPlan
+	}
+	if req.Pomodoro == nil {
+		return domain.ErrInvalidSessionPlan
+	}
+	if err := checkPlan(*req.Pomodoro); err != nil {
+		return err
+	}
+	req.DurationSeconds = req.Pomodoro.TotalSeconds()
+	return nil
+}
+
+// maxSessionSeconds is the longest a session may be planned for.
+const maxSessionSeconds = 24 * 60 * 60
+
+func checkPlan(plan domain.PomodoroPlan) error {
+	if !plan.Valid() || plan.TotalSeconds() > maxSessionSeconds {
+		return domain.ErrInvalidSessionPlan
+	}
+	return nil
+}
+
+// withPhase fills in where a pomodoro session in progress stands at now.
+func (s *homeService) withPhase(ctx context.Context, session *domain.FocusSession, now time.Time) (*domain.FocusSession, error) {
+	if session == nil || session.Pomodoro == nil {
+		return session, nil
+	}
+	if session.Status != domain.SessionStatusActive && session.Status != domain.SessionStatusPaused {
+		return session, nil
+	}
+	segments, err := s.homeRepo.GetSessionSegments(ctx, session.ID)
 	if err != nil {
 		return nil, err
 	}
-	if session.UserID != userID {
-		return nil, domain.ErrUnauthorized
+	session.Phase = phaseAt(*session.Pomodoro, focusSeconds(segments, now), session.Status == domain.SessionStatusActive, now)
+	return session, nil
+}
+
+// phaseAt is where a plan stands after clock seconds. While the clock runs the
+// current phase ends at a set time.
+func phaseAt(plan domain.PomodoroPlan, clock int32, running bool, now time.Time) *domain.PomodoroState {
+	state := plan.At(clock)
+	if running && state.Phase != domain.SessionPhaseDone {
+		endsAt := now.Add(time.Duration(state.PhaseRemainingSeconds) * time.Second)
+		state.PhaseEndsAt = &endsAt
+	}
+	return &state
+}
+
+// PauseSession stops the session's clock. elapsed is the client's count.
+func (s *homeService) PauseSession(ctx context.Context, userID int32, sessionID int32, elapsed int32) (*domain.FocusSession, error) {
+	session, err := s.userSession(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
 	}
 	if session.Status != domain.SessionStatusActive {
 		return nil, response.ErrInvalidSessionStatus
 	}
-	return s.homeRepo.UpdateSession(ctx, sessionID, elapsed, domain.SessionStatusPaused, nil)
+	now := time.Now()
+	paused, err := s.stopSession(ctx, session, domain.SessionStop{
+		Status:          domain.SessionStatusPaused,
+		ReportedSeconds: elapsed,
+		At:              now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.withPhase(ctx, paused, now)
 }
 
 func (s *homeService) ResumeSession(ctx context.Context, userID int32, sessionID int32) (*domain.FocusSession, error) {
-	session, err := s.homeRepo.GetSessionByID(ctx, sessionID)
+	session, err := s.userSession(ctx, userID, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	if session.UserID != userID {
-		return nil, domain.ErrUnauthorized
-	}
 	if session.Status != domain.SessionStatusPaused {
 		return nil, response.ErrInvalidSessionStatus
 	}
-	return s.homeRepo.UpdateSession(ctx, sessionID, session.ElapsedSeconds, domain.SessionStatusActive, nil)
+	now := time.Now()
+	resumed, err := s.homeRepo.ResumeSession(ctx, sessionID, now)
+	if err != nil {
+		return nil, err
+	}
+	return s.withPhase(ctx, resumed, now)
 }
 
-func (s *homeService) EndSession(ctx context.Context, userID int32, sessionID int32, elapsed int32, completed bool) (*domain.FocusSession, error) {
-	session, err := s.homeRepo.GetSessionByID(ctx, sessionID)
+// EndSession stops the session's clock for good, keeping the user's
+// reflection and focus score. req.ElapsedSeconds is the client's count; the
+// streak, progress and badges get the time the server settled on.
+func (s *homeService) EndSession(ctx context.Context, userID int32, sessionID int32, req *domain.UpdateSessionRequest) (*domain.FocusSession, error) {
+	session, err := s.userSession(ctx, userID, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	if session.UserID != userID {
-		return nil, domain.ErrUnauthorized
-	}
 
+	completed := req.Status == domain.SessionStatusCompleted
 	status := domain.SessionStatusAbandoned
 	if completed {
 		status = domain.SessionStatusCompleted
 	}
 
 	now := time.Now()
-	updatedSession, err := s.homeRepo.UpdateSession(ctx, sessionID, elapsed, status, &now)
+	updatedSession, err := s.stopSession(ctx, session, domain.SessionStop{
+		Status:          status,
+		ReportedSeconds: req.ElapsedSeconds,
+		Reflection:      strings.TrimSpace(req.Reflection),
+		FocusScore:      req.FocusScore,
+		At:              now,
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Run side effects in a goroutine so the response is returned immediately
 	if completed {
-		go s.handleSessionCompleted(context.Background(), userID, elapsed, now)
+		go s.handleSessionCompleted(context.Background(), userID, updatedSession.ElapsedSeconds, now, false)
 	}
 
 	return updatedSession, nil
 }
 
+// staleSessionBatch is how many stale sessions ExpireStaleSessions ends a run.
+const staleSessionBatch = 500
+
+// ExpireStaleSessions ends the sessions of clients that went away without
+// ending them, so the user can start another. Active sessions are completed
+// at the moment their planned duration ran out; sessions paused for longer
+// than the pause limit are abandoned. It is run by the scheduler.
+func (s *homeService) ExpireStaleSessions(ctx context.Context) {
+	now := time.Now()
+	sessions, err := s.homeRepo.ListStaleSessions(ctx, now, now.Add(-s.pauseLimit), staleSessionBatch)
+	if err != nil {
+		log.Printf("failed to list stale focus sessions: %v", err)
+		return
+	}
+
+	var completed, abandoned int
+	for i := range sessions {
+		session := &sessions[i]
+		if session.Status == domain.SessionStatusActive {
+			if s.completeStaleSession(ctx, session, now) {
+				completed++
+			}
+			continue
+		}
+		ended, err := s.stopSession(ctx, session, domain.SessionStop{Status: domain.SessionStatusAbandoned, At: now})
+		if err != nil {
+			// The user may have resumed or ended it since it was listed.
+			if !errors.Is(err, response.ErrInvalidSessionStatus) {
+				log.Printf("failed to abandon focus session %d: %v", session.ID, err)
+			}
+			continue
+		}
+		s.notifService.NotifySessionExpired(ctx, session.UserID, false, ended.ElapsedSeconds)
+		abandoned++
+	}
+	if completed+abandoned > 0 {
+		log.Printf("focus sessions: auto-completed %d, abandoned %d", completed, abandoned)
+	}
+}
+
+// completeStaleSession completes an active session as of when its planned
+// duration ran out and runs the usual side effects.
+func (s *homeService) completeStaleSession(ctx context.Context, session *domain.FocusSession, now time.Time) bool {
+	segments, err := s.homeRepo.GetSessionSegments(ctx, session.ID)
+	if err != nil {
+		log.Printf("failed to load focus session %d: %v", session.ID, err)
+		return false
+	}
+	at := plannedEnd(segments, session.DurationSeconds, now)
+	ended, err := s.stopSession(ctx, session, domain.SessionStop{Status: domain.SessionStatusCompleted, At: at})
+	if err != nil {
+		if !errors.Is(err, response.ErrInvalidSessionStatus) {
+			log.Printf("failed to complete focus session %d: %v", session.ID, err)
+		}
+		return false
+	}
+	s.handleSessionCompleted(ctx, session.UserID, ended.ElapsedSeconds, at, true)
+	return true
+}
+
+// plannedEnd returns when the session's clock reached duration seconds, or
+// now if it has not yet.
+func plannedEnd(segments []domain.SessionSegment, duration int32, now time.Time) time.Time {
+	left := time.Duration(duration) * time.Second
+	for _, seg := range segments {
+		end := now
+		if seg.EndedAt != nil {
+			end = *seg.EndedAt
+		}
+		d := end.Sub(seg.StartedAt)
+		if d <= 0 {
+			continue
+		}
+		if d >= left {
+			return seg.StartedAt.Add(left)
+		}
+		left -= d
+	}
+	return now
+}
+
+// userSession returns the session if it belongs to the user.
+func (s *homeService) userSession(ctx context.Context, userID, sessionID int32) (*domain.FocusSession, error) {
+	session, err := s.homeRepo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, domain.ErrUnauthorized
+	}
+	return session, nil
+}
+
+// stopSession stops the session's clock at stop.At and settles its focus
+// time against the client's count, stop.ReportedSeconds. Of a pomodoro
+// session's time, only work counts as focus; the rest is break.
+func (s *homeService) stopSession(ctx context.Context, session *domain.FocusSession, stop domain.SessionStop) (*domain.FocusSession, error) {
+	segments, err := s.homeRepo.GetSessionSegments(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	clock := focusSeconds(segments, stop.At)
+	stop.ElapsedSeconds, stop.BreakSeconds, stop.Flagged = settleSession(session.Pomodoro, clock, stop.ReportedSeconds)
+	if stop.Flagged {
+		log.Printf("focus session %d of user %d flagged: client reported %ds, server clock ran %ds", session.ID, session.UserID, stop.ReportedSeconds, clock)
+	}
+
+	return s.homeRepo.StopSession(ctx, session.ID, stop)
+}
+
+// settleSession splits a session's clock into focus and break time and
+// settles the focus time against the client's count. The client counts only
+// focus, so for a pomodoro session it is compared with the plan's work, not
+// with a clock that ran through breaks too.
+func settleSession(plan *domain.PomodoroPlan, clock, reported int32) (focus, breaks int32, flagged bool) {
+	focus = clock
+	if plan != nil {
+		focus = plan.At(clock).WorkSeconds
+		breaks = clock - focus
+	}
+	focus, flagged = settleElapsed(focus, reported)
+	return focus, breaks, flagged
+}
+
+// focusSeconds totals the time the session's clock ran until now.
+func focusSeconds(segments []domain.SessionSegment, now time.Time) int32 {
+	var total time.Duration
+	for _, seg := range segments {
+		end := now
+		if seg.EndedAt != nil {
+			end = *seg.EndedAt
+		}
+		if d := end.Sub(seg.StartedAt); d > 0 {
+			total += d
+		}
+	}
+	return int32(total / time.Second)
+}
+
+// settleElapsed decides a session's focus time from the server's count and
+// the client's, and whether they disagree enough to flag the session. A
+// client that reports nothing leaves the server's count.
+func settleElapsed(computed, reported int32) (elapsed int32, flagged bool) {
+	if reported <= 0 {
+		return computed, false
+	}
+	diff := reported - computed
+	if diff < 0 {
+		diff = -diff
+	}
+	tolerance := max(sessionClockTolerance, computed/sessionClockToleranceRatio)
+	return min(computed, reported), diff > tolerance
+}
+
 // handleSessionCompleted updates streak, progress, badges and fires notifications.
-// Runs in a goroutine — errors are non-fatal.
-func (s *homeService) handleSessionCompleted(ctx context.Context, userID int32, focusSeconds int32, completedAt time.Time) {
-	// 1. Update daily progress
-	today := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(), 0, 0, 0, 0, completedAt.Location())
+// Runs in a goroutine, or from the scheduler for an expired session — errors are non-fatal.
+func (s *homeService) handleSessionCompleted(ctx context.Context, userID int32, focusSeconds int32, completedAt time.Time, expired bool) {
+	// Only focus time counts: for a pomodoro session that is its work, not
+	// its breaks. A session with none counts for nothing.
+	if focusSeconds <= 0 {
+		return
+	}
+
+	// 1. Update daily progress, on the day it is for the user
+	today := localDate(completedAt, userLocation(ctx, s.userRepo, userID))
 	_ = s.homeRepo.UpsertDailyProgress(ctx, userID, today, focusSeconds, 1)
 
 	// 2. Update streak
@@ -180,14 +504,23 @@ func (s *homeService) handleSessionCompleted(ctx context.Context, userID int32,
 	newStreakVal := int32(1)
 	if err == nil {
 		newStreakVal = s.calculateNewStreak(streak, today)
-		updatedStreak, err := s.homeRepo.UpsertStreak(ctx, userID, newStreakVal, max32(newStreakVal, streak.LongestStreak), &today)
+		lastDate := today
+		if streak.LastSessionDate != nil && streak.LastSessionDate.After(today) {
+			// The user moved west and is now a day behind their last session.
+			lastDate = *streak.LastSessionDate
+		}
+		updatedStreak, err := s.homeRepo.UpsertStreak(ctx, userID, newStreakVal, max32(newStreakVal, streak.LongestStreak), &lastDate)
 		if err == nil {
 			streak = updatedStreak
 		}
 	}
 
-	// 3. Notify session completed
-	s.notifService.NotifySessionCompleted(ctx, userID, focusSeconds)
+	// 3. Notify session completed, or completed for the user if they were away
+	if expired {
+		s.notifService.NotifySessionExpired(ctx, userID, true, focusSeconds)
+	} else {
+		s.notifService.NotifySessionCompleted(ctx, userID, focusSeconds)
+	}
 
 	// 4. Notify streak milestone if applicable
 	s.notifService.NotifyStreakMilestone(ctx, userID, newStreakVal)
@@ -196,17 +529,21 @@ func (s *homeService) handleSessionCompleted(ctx context.Context, userID int32,
 	s.checkAndAwardBadges(ctx, userID, streak)
 }
 
+// calculateNewStreak returns the streak after a session on today, a date in
+// the user's time zone. A user who moved west can be on a day before their
+// last session's; that day was already counted.
 func (s *homeService) calculateNewStreak(streak *domain.Streak, today time.Time) int32 {
-	if streak.LastSessionDate == nil {
+	if streak.LastSessionDate == nil || streak.CurrentStreak == 0 {
 		return 1
 	}
 	lastDate := *streak.LastSessionDate
-	lastDate = time.Date(lastDate.Year(), lastDate.Month(), lastDate.Day(), 0, 0, 0, 0, lastDate.Location())
+	lastDate = time.Date(lastDate.Year(), lastDate.Month(), lastDate.Day(), 0, 0, 0, 0, time.UTC)
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
 	diff := int(today.Sub(lastDate).Hours() / 24)
-	switch diff {
-	case 0:
+	switch {
+	case diff <= 0:
 		return streak.CurrentStreak
-	case 1:
+	case diff == 1:
 		return streak.CurrentStreak + 1
 	default:
 		return 1
@@ -219,8 +556,8 @@ func (s *homeService) checkAndAwardBadges(ctx context.Context, userID int32, str
 		return
 	}
 
-	now := time.Now()
-	allTime, _ := s.homeRepo.GetProgressSummary(ctx, userID, time.Time{}, now)
+	// Flagged sessions do not count towards badges.
+	allTime, _ := s.homeRepo.GetBadgeProgress(ctx, userID)
 
 	earnedBadges, _ := s.homeRepo.GetUserBadges(ctx, userID)
 	earnedMap := make(map[int32]bool, len(earnedBadges))
@@ -261,15 +598,54 @@ func (s *homeService) checkAndAwardBadges(ctx context.Context, userID int32, str
 // Remaining methods
 // ─────────────────────────────────────────
 
+// GetActiveSession returns the session in progress, with its pomodoro phase.
 func (s *homeService) GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error) {
-	return s.homeRepo.GetActiveSession(ctx, userID)
+	session, err := s.homeRepo.GetActiveSession(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withPhase(ctx, session, time.Now())
 }
 
-func (s *homeService) GetSessionHistory(ctx context.Context, userID int32, limit, offset int32) ([]domain.FocusSession, error) {
+func (s *homeService) GetSessionHistory(ctx context.Context, userID int32, filter domain.SessionFilter, limit, offset int32) ([]domain.FocusSession, error) {
 	if limit <= 0 || limit > 100 {
 		limit = 20
 	}
-	return s.homeRepo.GetUserSessionHistory(ctx, userID, limit, offset)
+	filter.Query = strings.TrimSpace(filter.Query)
+	return s.homeRepo.GetUserSessionHistory(ctx, userID, filter, limit, offset)
+}
+
+// ─────────────────────────────────────────
+// Pomodoro presets
+// ─────────────────────────────────────────
+
+func (s *homeService) ListSessionPresets(ctx context.Context, userID int32) ([]domain.SessionPreset, error) {
+	return s.homeRepo.ListSessionPresets(ctx, userID)
+}
+
+func (s *homeService) CreateSessionPreset(ctx context.Context, userID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error) {
+	if err := checkPlan(req.PomodoroPlan); err != nil {
+		return nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	return s.homeRepo.CreateSessionPreset(ctx, userID, req)
+}
+
+func (s *homeService) UpdateSessionPreset(ctx context.Context, userID, presetID int32, req *domain.SessionPresetRequest) (*domain.SessionPreset, error) {
+	if err := checkPlan(req.PomodoroPlan); err != nil {
+		return nil, err
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	return s.homeRepo.UpdateSessionPreset(ctx, presetID, userID, req)
+}
+
+func (s *homeService) DeleteSessionPreset(ctx context.Context, userID, presetID int32) error {
+	return s.homeRepo.DeleteSessionPreset(ctx, presetID, userID)
+}
+
+// GetJobFocusTime returns how much focus time went into each tracked job.
+func (s *homeService) GetJobFocusTime(ctx context.Context, userID int32) ([]domain.JobFocusTime, error) {
+	return s.homeRepo.GetJobFocusTime(ctx, userID)
 }
 
 func (s *homeService) GetStreak(ctx context.Context, userID int32) (*domain.Streak, error) {
@@ -285,7 +661,7 @@ func (s *homeService) GetAllBadges(ctx context.Context) ([]domain.BadgeDefinitio
 }
 
 func (s *homeService) GetProgressSummary(ctx context.Context, userID int32, period string) (*domain.ProgressSummary, error) {
-	now := time.Now()
+	now := time.Now().In(userLocation(ctx, s.userRepo, userID))
 	var from time.Time
 
 	switch period {
@@ -321,6 +697,13 @@ func (s *homeService) GetProgressSummary(ctx context.Context, userID int32, peri
 // Helpers
 // ─────────────────────────────────────────
 
+// localDate returns the day t falls on in loc, as midnight UTC like the dates
+// read back from the database.
+func localDate(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
 func max32(a, b int32) int32 {
 	if a > b {
 		return a
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFocusSeconds(t *testing.T) {
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	pausedAt := start.Add(10 * time.Minute)
+	now := start.Add(time.Hour)
+
+	segments := []domain.SessionSegment{
+		{StartedAt: start, EndedAt: &pausedAt},
+		// Still running: counts until now.
+		{StartedAt: start.Add(50 * time.Minute)},
+	}
+	assert.Equal(t, int32(20*60), focusSeconds(segments, now))
+
+	assert.Equal(t, int32(0), focusSeconds(nil, now))
+
+	// A segment started after now, from clock skew, adds nothing.
+	future := []domain.SessionSegment{{StartedAt: now.Add(time.Minute)}}
+	assert.Equal(t, int32(0), focusSeconds(future, now))
+}
+
+func TestSettleElapsed(t *testing.T) {
+	tests := []struct {
+		name               string
+		computed, reported int32
+		wantElapsed        int32
+		wantFlagged        bool
+	}{
+		{"no hint", 1500, 0, 1500, false},
+		{"hint agrees", 1500, 1495, 1495, false},
+		{"hint a little high", 1500, 1520, 1500, false},
+		{"hint lowers", 1500, 1200, 1200, true},
+		{"claims a whole day", 600, 86400, 600, true},
+		{"within five percent of a long session", 7200, 7500, 7200, false},
+		{"past five percent of a long session", 7200, 7600, 7200, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elapsed, flagged := settleElapsed(tt.computed, tt.reported)
+			assert.Equal(t, tt.wantElapsed, elapsed)
+			assert.Equal(t, tt.wantFlagged, flagged)
+		})
+	}
+}
+
+func TestPlannedEnd(t *testing.T) {
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	pausedAt := start.Add(10 * time.Minute)
+	resumedAt := start.Add(30 * time.Minute)
+	now := start.Add(2 * time.Hour)
+
+	segments := []domain.SessionSegment{
+		{StartedAt: start, EndedAt: &pausedAt},
+		{StartedAt: resumedAt},
+	}
+	// Ten minutes before the pause and fifteen after it.
+	assert.Equal(t, resumedAt.Add(15*time.Minute), plannedEnd(segments, 25*60, now))
+
+	// Ran out within the first segment.
+	assert.Equal(t, start.Add(5*time.Minute), plannedEnd(segments, 5*60, now))
+
+	// Not yet run out.
+	assert.Equal(t, now, plannedEnd(segments, 3*60*60, now))
+}
+
+func TestPhaseAt(t *testing.T) {
+	plan := domain.PomodoroPlan{
+		WorkSeconds:       25 * 60,
+		ShortBreakSeconds: 5 * 60,
+		LongBreakSeconds:  15 * 60,
+		Cycles:            4,
+		LongBreakEvery:    2,
+	}
+	// 4 work intervals, short, long and short breaks between them.
+	assert.Equal(t, int32((4*25+5+15+5)*60), plan.TotalSeconds())
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	state := phaseAt(plan, 10*60, true, now)
+	assert.Equal(t, domain.SessionPhaseWork, state.Phase)
+	assert.Equal(t, int32(1), state.Cycle)
+	assert.Equal(t, int32(15*60), state.PhaseRemainingSeconds)
+	assert.Equal(t, now.Add(15*time.Minute), *state.PhaseEndsAt)
+	assert.Equal(t, int32(10*60), state.WorkSeconds)
+
+	// Breaks add nothing to the work done.
+	state = phaseAt(plan, 27*60, true, now)
+	assert.Equal(t, domain.SessionPhaseShortBreak, state.Phase)
+	assert.Equal(t, int32(1), state.Cycle)
+	assert.Equal(t, int32(25*60), state.WorkSeconds)
+
+	// The second break is long; a paused clock has no deadline.
+	state = phaseAt(plan, (2*25+5+1)*60, false, now)
+	assert.Equal(t, domain.SessionPhaseLongBreak, state.Phase)
+	assert.Equal(t, int32(2), state.Cycle)
+	assert.Equal(t, int32(14*60), state.PhaseRemainingSeconds)
+	assert.Nil(t, state.PhaseEndsAt)
+
+	// No break follows the last cycle.
+	state = phaseAt(plan, plan.TotalSeconds()+60, true, now)
+	assert.Equal(t, domain.SessionPhaseDone, state.Phase)
+	assert.Equal(t, int32(4), state.Cycle)
+	assert.Equal(t, int32(4*25*60), state.WorkSeconds)
+	assert.Nil(t, state.PhaseEndsAt)
+}
+
+func TestCheckPlan(t *testing.T) {
+	plan := domain.PomodoroPlan{WorkSeconds: 1500, ShortBreakSeconds: 300, Cycles: 4}
+	assert.NoError(t, checkPlan(plan))
+
+	// A long break needs a length.
+	plan.LongBreakEvery = 2
+	assert.ErrorIs(t, checkPlan(plan), domain.ErrInvalidSessionPlan)
+	plan.LongBreakSeconds = 900
+	assert.NoError(t, checkPlan(plan))
+
+	// The plan must fit in a day.
+	plan.WorkSeconds, plan.Cycles = 4*60*60, 16
+	assert.ErrorIs(t, checkPlan(plan), domain.ErrInvalidSessionPlan)
+}
+
+func TestLocalDate(t *testing.T) {
+	nairobi, err := time.LoadLocation("Africa/Nairobi")
+	assert.NoError(t, err)
+	toronto, err := time.LoadLocation("America/Toronto")
+	assert.NoError(t, err)
+
+	// 22:30 UTC is already the next day in Nairobi but not in Toronto.
+	at := time.Date(2026, 6, 1, 22, 30, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), localDate(at, nairobi))
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), localDate(at, toronto))
+}
+
+func TestCalculateNewStreak(t *testing.T) {
+	s := &homeService{}
+	today := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	streakOn := func(last time.Time) *domain.Streak {
+		return &domain.Streak{CurrentStreak: 3, LastSessionDate: &last}
+	}
+
+	assert.Equal(t, int32(1), s.calculateNewStreak(&domain.Streak{}, today))
+	assert.Equal(t, int32(3), s.calculateNewStreak(streakOn(today), today))
+	assert.Equal(t, int32(4), s.calculateNewStreak(streakOn(today.AddDate(0, 0, -1)), today))
+	assert.Equal(t, int32(1), s.calculateNewStreak(streakOn(today.AddDate(0, 0, -2)), today))
+	// After a move west, today can fall before the last session's day.
+	assert.Equal(t, int32(3), s.calculateNewStreak(streakOn(today.AddDate(0, 0, 1)), today))
+}
+
+func TestSettleSession(t *testing.T) {
+	plan := &domain.PomodoroPlan{WorkSeconds: 25 * 60, ShortBreakSeconds: 5 * 60, Cycles: 2}
+
+	// The clock ran through the break; the client counted only the work.
+	focus, breaks, flagged := settleSession(plan, 55*60, 50*60)
+	assert.Equal(t, int32(50*60), focus)
+	assert.Equal(t, int32(5*60), breaks)
+	assert.False(t, flagged)
+
+	// Stopped during the break.
+	focus, breaks, flagged = settleSession(plan, 27*60, 25*60)
+	assert.Equal(t, int32(25*60), focus)
+	assert.Equal(t, int32(2*60), breaks)
+	assert.False(t, flagged)
+
+	// A client count well below the work done lowers it and flags the session.
+	focus, breaks, flagged = settleSession(plan, 55*60, 40*60)
+	assert.Equal(t, int32(40*60), focus)
+	assert.Equal(t, int32(5*60), breaks)
+	assert.True(t, flagged)
+
+	// A single session has no breaks.
+	focus, breaks, flagged = settleSession(nil, 25*60, 25*60)
+	assert.Equal(t, int32(25*60), focus)
+	assert.Equal(t, int32(0), breaks)
+	assert.False(t, flagged)
+}
+
+// MockHomeRepository mocks the HomeRepository calls a session makes; the
+// embedded interface panics on any other.
+type MockHomeRepository struct {
+	repository.HomeRepository
+	mock.Mock
+}
+
+func (m *MockHomeRepository) GetActiveSession(ctx context.Context, userID int32) (*domain.FocusSession, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockHomeRepository) CreateSession(ctx context.Context, userID int32, req *domain.StartSessionRequest, at time.Time) (*domain.FocusSession, error) {
+	args := m.Called(ctx, userID, req, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockHomeRepository) GetSessionByID(ctx context.Context, sessionID int32) (*domain.FocusSession, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+func (m *MockHomeRepository) GetSessionSegments(ctx context.Context, sessionID int32) ([]domain.SessionSegment, error) {
+	args := m.Called(ctx, sessionID)
+	return args.Get(0).([]domain.SessionSegment), args.Error(1)
+}
+
+func (m *MockHomeRepository) StopSession(ctx context.Context, sessionID int32, stop domain.SessionStop) (*domain.FocusSession, error) {
+	args := m.Called(ctx, sessionID, stop)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FocusSession), args.Error(1)
+}
+
+// MockGoalRepository mocks the GoalRepository calls sessions and goal
+// notifications make.
+type MockGoalRepository struct {
+	repository.GoalRepository
+	mock.Mock
+}
+
+func (m *MockGoalRepository) GetGoal(ctx context.Context, goalID, userID int32) (*domain.Goal, error) {
+	args := m.Called(ctx, goalID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Goal), args.Error(1)
+}
+
+func (m *MockGoalRepository) ListUsersWithGoals(ctx context.Context, localHour int) ([]int32, error) {
+	args := m.Called(ctx, localHour)
+	return args.Get(0).([]int32), args.Error(1)
+}
+
+func newSessionTestService(homeRepo *MockHomeRepository, jobRepo *MockJobRepository, goalRepo *MockGoalRepository) HomeService {
+	return NewHomeService(homeRepo, nil, jobRepo, goalRepo, nil, nil, time.Hour)
+}
+
+func TestHomeService_StartSessionChecksLinks(t *testing.T) {
+	ctx := context.Background()
+	userID, jobID, goalID := int32(1), int32(10), int32(20)
+
+	t.Run("job of another user", func(t *testing.T) {
+		homeRepo, jobRepo, goalRepo := new(MockHomeRepository), new(MockJobRepository), new(MockGoalRepository)
+		homeRepo.On("GetActiveSession", ctx, userID).Return(nil, nil)
+		jobRepo.On("GetJobByID", ctx, jobID).Return(&domain.Job{ID: jobID, UserId: 2}, nil)
+
+		svc := newSessionTestService(homeRepo, jobRepo, goalRepo)
+		_, err := svc.StartSession(ctx, userID, &domain.StartSessionRequest{DurationSeconds: 1500, JobID: &jobID})
+		assert.ErrorIs(t, err, domain.ErrInvalidJobID)
+		homeRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("goal of another user", func(t *testing.T) {
+		homeRepo, jobRepo, goalRepo := new(MockHomeRepository), new(MockJobRepository), new(MockGoalRepository)
+		homeRepo.On("GetActiveSession", ctx, userID).Return(nil, nil)
+		goalRepo.On("GetGoal", ctx, goalID, userID).Return(nil, domain.ErrGoalNotFound)
+
+		svc := newSessionTestService(homeRepo, jobRepo, goalRepo)
+		_, err := svc.StartSession(ctx, userID, &domain.StartSessionRequest{DurationSeconds: 1500, GoalID: &goalID})
+		assert.ErrorIs(t, err, domain.ErrGoalNotFound)
+		homeRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("own job and goal", func(t *testing.T) {
+		homeRepo, jobRepo, goalRepo := new(MockHomeRepository), new(MockJobRepository), new(MockGoalRepository)
+		homeRepo.On("GetActiveSession", ctx, userID).Return(nil, nil)
+		jobRepo.On("GetJobByID", ctx, jobID).Return(&domain.Job{ID: jobID, UserId: userID}, nil)
+		goalRepo.On("GetGoal", ctx, goalID, userID).Return(&domain.Goal{ID: goalID, UserID: userID}, nil)
+		linked := mock.MatchedBy(func(req *domain.StartSessionRequest) bool {
+			return *req.JobID == jobID && *req.GoalID == goalID && req.Intention == "tailor CV"
+		})
+		homeRepo.On("CreateSession", ctx, userID, linked, mock.Anything).
+			Return(&domain.FocusSession{ID: 5, UserID: userID, JobID: &jobID, GoalID: &goalID}, nil)
+
+		svc := newSessionTestService(homeRepo, jobRepo, goalRepo)
+		session, err := svc.StartSession(ctx, userID, &domain.StartSessionRequest{
+			DurationSeconds: 1500, JobID: &jobID, GoalID: &goalID, Intention: "  tailor CV ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), session.ID)
+		homeRepo.AssertExpectations(t)
+	})
+}
+
+func TestHomeService_EndSessionWritesReflection(t *testing.T) {
+	ctx := context.Background()
+	userID, sessionID := int32(1), int32(5)
+	homeRepo := new(MockHomeRepository)
+	homeRepo.On("GetSessionByID", ctx, sessionID).
+		Return(&domain.FocusSession{ID: sessionID, UserID: userID, Status: domain.SessionStatusActive}, nil)
+	homeRepo.On("GetSessionSegments", ctx, sessionID).
+		Return([]domain.SessionSegment{{StartedAt: time.Now().Add(-10 * time.Minute)}}, nil)
+	score := int32(3)
+	withReflection := mock.MatchedBy(func(stop domain.SessionStop) bool {
+		return stop.Status == domain.SessionStatusAbandoned && stop.Reflection == "got distracted" &&
+			stop.FocusScore != nil && *stop.FocusScore == score
+	})
+	homeRepo.On("StopSession", ctx, sessionID, withReflection).
+		Return(&domain.FocusSession{ID: sessionID, Status: domain.SessionStatusAbandoned}, nil)
+
+	svc := newSessionTestService(homeRepo, new(MockJobRepository), new(MockGoalRepository))
+	_, err := svc.EndSession(ctx, userID, sessionID, &domain.UpdateSessionRequest{
+		Status: domain.SessionStatusAbandoned, Reflection: " got distracted ", FocusScore: &score,
+	})
+	require.NoError(t, err)
+	homeRepo.AssertExpectations(t)
+
+	// Someone else's session cannot be ended.
+	_, err = svc.EndSession(ctx, 2, sessionID, &domain.UpdateSessionRequest{Status: domain.SessionStatusAbandoned})
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/jobimport"
+	"context"
+	"log"
+	"strings"
+)
+
+type JobImportService interface {
+	Import(ctx context.Context, req *domain.ImportJobRequest) (*domain.JobImportResult, error)
+}
+
+type jobImportService struct {
+	client *jobimport.Client
+}
+
+func NewJobImportService(client *jobimport.Client) JobImportService {
+	return &jobImportService{client: client}
+}
+
+// Import parses the supplied HTML, or fetches req.URL when no HTML is given,
+// and returns a prefilled job for the client to confirm.
+func (s *jobImportService) Import(ctx context.Context, req *domain.ImportJobRequest) (*domain.JobImportResult, error) {
+	pageURL := strings.TrimSpace(req.URL)
+	rawHTML := req.HTML
+
+	if strings.TrimSpace(rawHTML) == "" {
+		if pageURL == "" {
+			return nil, domain.ErrInvalidInput
+		}
+		body, finalURL, err := s.client.Fetch(ctx, pageURL)
+		if err != nil {
+			log.Printf("job import fetch failed for %s: %v", pageURL, err)
+			return nil, domain.ErrJobImportFetchFailed
+		}
+		rawHTML = string(body)
+		pageURL = finalURL
+	}
+
+	imported, err := jobimport.Parse([]byte(rawHTML), pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := imported.ToResult()
+	return &result, nil
+}
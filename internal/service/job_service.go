@@ -2,45 +2,90 @@ package service
 
 import (
 	"aiki/internal/domain"
+	"aiki/internal/pkg/jobmatch"
 	"aiki/internal/repository"
 	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
 )
 
 //go:generate mockgen -source=job_service.go -destination=mocks/mock_job_service.go -package=mocks
 
 type JobService interface {
-	Create(ctx context.Context, job *domain.Job) (int32, error)
+	Create(ctx context.Context, job *domain.Job) (int32, []domain.DuplicateMatch, error)
 	Update(ctx context.Context, jobId int32, job *domain.Job) error
 	Delete(ctx context.Context, jobId int32) error
 	GetByID(ctx context.Context, jobId int32) (*domain.Job, error)
-	GetAllByUserID(ctx context.Context, userId int32) ([]domain.Job, error)
+	GetDetail(ctx context.Context, jobId int32) (*domain.JobDetail, error)
+	GetAllByUserID(ctx context.Context, userId int32, filter domain.JobFilter) ([]domain.Job, error)
+	GetTags(ctx context.Context, userId int32) ([]domain.TagCount, error)
+	Archive(ctx context.Context, userId, jobId int32) (*domain.Job, error)
+	Restore(ctx context.Context, userId, jobId int32) (*domain.Job, error)
+	ListTrash(ctx context.Context, userId int32) ([]domain.TrashedJob, error)
+	PurgeTrash(ctx context.Context)
+	Merge(ctx context.Context, userId, targetId, duplicateId int32) (*domain.Job, error)
 }
 
 type jobService struct {
-	jobRepo repository.JobRepository
+	jobRepo        repository.JobRepository
+	pipelineRepo   repository.PipelineRepository
+	contactRepo    repository.ContactRepository
+	offerRepo      repository.OfferRepository
+	attachmentRepo repository.AttachmentRepository
+
+	trashRetention time.Duration
 }
 
-func NewJobService(jobRepo repository.JobRepository) JobService {
+func NewJobService(
+	jobRepo repository.JobRepository,
+	pipelineRepo repository.PipelineRepository,
+	contactRepo repository.ContactRepository,
+	offerRepo repository.OfferRepository,
+	attachmentRepo repository.AttachmentRepository,
+	trashRetention time.Duration,
+) JobService {
 	return &jobService{
-		jobRepo: jobRepo,
+		jobRepo:        jobRepo,
+		pipelineRepo:   pipelineRepo,
+		contactRepo:    contactRepo,
+		offerRepo:      offerRepo,
+		attachmentRepo: attachmentRepo,
+		trashRetention: trashRetention,
 	}
 }
 
-func (s *jobService) Create(ctx context.Context, job *domain.Job) (int32, error) {
+// Create adds the job and reports existing jobs that look like the same role.
+// Duplicates never block creation; the client decides whether to merge.
+func (s *jobService) Create(ctx context.Context, job *domain.Job) (int32, []domain.DuplicateMatch, error) {
+	if job.Status == "" {
+		job.Status = domain.JobStatusApplied
+	}
+	if err := s.resolveStage(ctx, job, nil); err != nil {
+		return 0, nil, err
+	}
+
+	duplicates := findDuplicates(ctx, s.jobRepo, job)
+
 	jobId, err := s.jobRepo.Create(ctx, job)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
-	return jobId, nil
+	return jobId, duplicates, nil
 }
 
 func (s *jobService) Update(ctx context.Context, jobId int32, job *domain.Job) error {
 	// if job exists before updating
-	_, err := s.jobRepo.GetJobByID(ctx, jobId)
+	existing, err := s.jobRepo.GetJobByID(ctx, jobId)
 	if err != nil {
 		return err
 	}
 
+	if err := s.resolveStage(ctx, job, existing); err != nil {
+		return err
+	}
+
 	err = s.jobRepo.Update(ctx, jobId, job)
 	if err != nil {
 		return err
@@ -48,6 +93,7 @@ func (s *jobService) Update(ctx context.Context, jobId int32, job *domain.Job) e
 	return nil
 }
 
+// Delete moves the job to the trash; see Restore and PurgeTrash.
 func (s *jobService) Delete(ctx context.Context, jobId int32) error {
 	// Verify job exists before deleting
 	_, err := s.jobRepo.GetJobByID(ctx, jobId)
@@ -70,45 +116,211 @@ func (s *jobService) GetByID(ctx context.Context, jobId int32) (*domain.Job, err
 	return job, nil
 }
 
-func (s *jobService) GetAllByUserID(ctx context.Context, userId int32) ([]domain.Job, error) {
-	dbJobs, err := s.jobRepo.GetAllJobs(ctx, userId)
+// GetDetail returns the job with the contacts linked to it, its attachments
+// and its offer and description snapshot, if any.
+func (s *jobService) GetDetail(ctx context.Context, jobId int32) (*domain.JobDetail, error) {
+	job, err := s.jobRepo.GetJobByID(ctx, jobId)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert db.Job to domain.Job
-	jobs := make([]domain.Job, 0, len(dbJobs))
-	for _, dbJob := range dbJobs {
-		job := domain.Job{
-			ID:        dbJob.ID,
-			UserId:    dbJob.UserID,
-			Title:     dbJob.Title,
-			Status:    dbJob.Status,
-			CreatedAt: dbJob.CreatedAt.Time,
-		}
+	contacts, err := s.contactRepo.List(ctx, job.UserId, domain.ContactFilter{JobID: &job.ID})
+	if err != nil {
+		return nil, err
+	}
 
-		// Handle nullable fields
-		if dbJob.CompanyName != nil {
-			job.CompanyName = *dbJob.CompanyName
-		}
-		if dbJob.Notes != nil {
-			job.Notes = *dbJob.Notes
-		}
-		if dbJob.Location != nil {
-			job.Location = *dbJob.Location
-		}
-		if dbJob.Platform != nil {
-			job.Platform = *dbJob.Platform
-		}
-		if dbJob.Link != nil {
-			job.Link = *dbJob.Link
+	attachments, err := s.attachmentRepo.ListByJobID(ctx, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &domain.JobDetail{Job: *job, Contacts: contacts, Attachments: attachments}
+	offer, err := s.offerRepo.GetByJobID(ctx, jobId)
+	switch {
+	case err == nil:
+		detail.Offer = offer
+	case !errors.Is(err, domain.ErrOfferNotFound):
+		return nil, err
+	}
+
+	snapshot, err := s.attachmentRepo.GetSnapshot(ctx, jobId)
+	switch {
+	case err == nil:
+		detail.Snapshot = snapshot
+	case !errors.Is(err, domain.ErrSnapshotNotFound):
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+func (s *jobService) GetAllByUserID(ctx context.Context, userId int32, filter domain.JobFilter) ([]domain.Job, error) {
+	return s.jobRepo.GetAllJobs(ctx, userId, filter)
+}
+
+func (s *jobService) GetTags(ctx context.Context, userId int32) ([]domain.TagCount, error) {
+	return s.jobRepo.GetUserTags(ctx, userId)
+}
+
+func (s *jobService) Archive(ctx context.Context, userId, jobId int32) (*domain.Job, error) {
+	if err := s.jobRepo.ArchiveJob(ctx, userId, jobId); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetJobByID(ctx, jobId)
+}
+
+func (s *jobService) Restore(ctx context.Context, userId, jobId int32) (*domain.Job, error) {
+	if err := s.jobRepo.RestoreJob(ctx, userId, jobId); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetJobByID(ctx, jobId)
+}
+
+func (s *jobService) ListTrash(ctx context.Context, userId int32) ([]domain.TrashedJob, error) {
+	jobs, err := s.jobRepo.ListTrash(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	trashed := make([]domain.TrashedJob, 0, len(jobs))
+	for _, job := range jobs {
+		trashed = append(trashed, domain.TrashedJob{Job: job, PurgeAt: job.DeletedAt.Add(s.trashRetention)})
+	}
+	return trashed, nil
+}
+
+// PurgeTrash permanently removes jobs that have been in the trash longer than
+// the retention window. It is run daily by the scheduler.
+func (s *jobService) PurgeTrash(ctx context.Context) {
+	purged, err := s.jobRepo.PurgeTrash(ctx, time.Now().Add(-s.trashRetention))
+	if err != nil {
+		log.Printf("failed to purge trashed jobs: %v", err)
+		return
+	}
+	log.Printf("purged %d trashed jobs", purged)
+}
+
+// Merge folds the duplicate into the target job and deletes the duplicate.
+// See mergeJobFields for how conflicting fields are resolved.
+func (s *jobService) Merge(ctx context.Context, userId, targetId, duplicateId int32) (*domain.Job, error) {
+	if targetId == duplicateId {
+		return nil, domain.ErrInvalidInput
+	}
+
+	target, err := s.jobRepo.GetJobByID(ctx, targetId)
+	if err != nil {
+		return nil, err
+	}
+	duplicate, err := s.jobRepo.GetJobByID(ctx, duplicateId)
+	if err != nil {
+		return nil, err
+	}
+	if target.UserId != userId || duplicate.UserId != userId {
+		return nil, domain.ErrUnauthorized
+	}
+
+	merged := mergeJobFields(*target, *duplicate)
+	if err := s.jobRepo.MergeJobs(ctx, userId, targetId, duplicateId, &merged); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetJobByID(ctx, targetId)
+}
+
+// mergeJobFields combines two records of the same role. The target's values
+// win where both are set; notes are concatenated, the most advanced status
+// and highest priority are kept, and the earliest application date is used.
+func mergeJobFields(target, duplicate domain.Job) domain.Job {
+	merged := target
+
+	if merged.CompanyName == "" {
+		merged.CompanyName = duplicate.CompanyName
+	}
+	if merged.Location == "" {
+		merged.Location = duplicate.Location
+	}
+	if merged.Link == "" {
+		merged.Link = duplicate.Link
+	}
+	if merged.Platform == "" {
+		merged.Platform = duplicate.Platform
+	}
+
+	targetNotes := strings.TrimSpace(target.Notes)
+	duplicateNotes := strings.TrimSpace(duplicate.Notes)
+	switch {
+	case duplicateNotes == "" || duplicateNotes == targetNotes:
+	case targetNotes == "":
+		merged.Notes = duplicate.Notes
+	default:
+		merged.Notes = targetNotes + "\n\n" + duplicateNotes
+	}
+
+	merged.Status = domain.MoreAdvancedStatus(target.Status, duplicate.Status)
+	if duplicate.Priority > merged.Priority {
+		merged.Priority = duplicate.Priority
+	}
+	// Dates are YYYY-MM-DD, so they compare as strings.
+	if duplicate.DateApplied != "" && (merged.DateApplied == "" || duplicate.DateApplied < merged.DateApplied) {
+		merged.DateApplied = duplicate.DateApplied
+	}
+
+	return merged
+}
+
+// findDuplicates checks job against the user's other jobs. A failed lookup is
+// logged and treated as no duplicates so it never blocks saving the job.
+func findDuplicates(ctx context.Context, jobRepo repository.JobRepository, job *domain.Job) []domain.DuplicateMatch {
+	candidates, err := jobRepo.ListDuplicateCandidates(ctx, job.UserId)
+	if err != nil {
+		log.Printf("failed to check job for duplicates: %v", err)
+		return nil
+	}
+
+	posting := jobmatch.Posting{Title: job.Title, CompanyName: job.CompanyName, Location: job.Location, Link: job.Link}
+	var matches []domain.DuplicateMatch
+	for _, c := range candidates {
+		if c.ID == job.ID {
+			continue
 		}
-		if dbJob.DateApplied.Valid {
-			job.DateApplied = dbJob.DateApplied.Time.Format("2006-01-02")
+		reason := jobmatch.Match(posting, jobmatch.Posting{
+			Title:       c.Title,
+			CompanyName: c.CompanyName,
+			Location:    c.Location,
+			Link:        c.Link,
+		})
+		if reason == "" {
+			continue
 		}
+		matches = append(matches, domain.DuplicateMatch{
+			JobID:       c.ID,
+			Title:       c.Title,
+			CompanyName: c.CompanyName,
+			Location:    c.Location,
+			Status:      c.Status,
+			Link:        c.Link,
+			Archived:    c.ArchivedAt != nil,
+			Reason:      reason,
+		})
+	}
+	return matches
+}
 
-		jobs = append(jobs, job)
+// resolveStage validates an explicitly requested stage and adopts its base
+// status. Without one, the job keeps its current stage; the repository moves
+// it to the first stage for its status if the two no longer match.
+func (s *jobService) resolveStage(ctx context.Context, job *domain.Job, existing *domain.Job) error {
+	if job.StageID != nil {
+		stage, err := s.pipelineRepo.GetStage(ctx, *job.StageID, job.UserId)
+		if err != nil {
+			return err
+		}
+		job.Status = stage.BaseStatus
+		return nil
 	}
 
-	return jobs, nil
+	if existing != nil {
+		job.StageID = existing.StageID
+		return nil
+	}
+	return s.pipelineRepo.EnsureDefaultStages(ctx, job.UserId)
 }
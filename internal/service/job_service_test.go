@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobService_ListTrash(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	svc := NewJobService(jobRepo, nil, nil, nil, nil, 30*24*time.Hour)
+
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	jobRepo.On("ListTrash", mock.Anything, int32(1)).
+		Return([]domain.Job{{ID: 7, UserId: 1, DeletedAt: &deletedAt}}, nil)
+
+	trashed, err := svc.ListTrash(context.Background(), 1)
+
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, int32(7), trashed[0].ID)
+	assert.Equal(t, time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC), trashed[0].PurgeAt)
+}
+
+func TestJobService_Restore(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	svc := NewJobService(jobRepo, nil, nil, nil, nil, time.Hour)
+
+	jobRepo.On("RestoreJob", mock.Anything, int32(1), int32(7)).Return(nil)
+	jobRepo.On("GetJobByID", mock.Anything, int32(7)).Return(&domain.Job{ID: 7, UserId: 1}, nil)
+
+	job, err := svc.Restore(context.Background(), 1, 7)
+
+	require.NoError(t, err)
+	assert.Nil(t, job.DeletedAt)
+	jobRepo.AssertExpectations(t)
+}
+
+func TestJobService_RestoreNotFound(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	svc := NewJobService(jobRepo, nil, nil, nil, nil, time.Hour)
+
+	// Someone else's job, or one that has already been purged.
+	jobRepo.On("RestoreJob", mock.Anything, int32(1), int32(7)).Return(domain.ErrInvalidJobID)
+
+	_, err := svc.Restore(context.Background(), 1, 7)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidJobID)
+	jobRepo.AssertNotCalled(t, "GetJobByID", mock.Anything, mock.Anything)
+}
+
+func TestJobService_PurgeTrash(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	svc := NewJobService(jobRepo, nil, nil, nil, nil, 24*time.Hour)
+
+	jobRepo.On("PurgeTrash", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 24*time.Hour && time.Since(before) < 25*time.Hour
+	})).Return(int64(3), nil)
+
+	svc.PurgeTrash(context.Background())
+
+	jobRepo.AssertExpectations(t)
+}
+
+func TestJobService_CreateReportsDuplicates(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	pipelineRepo := new(MockPipelineRepository)
+	svc := NewJobService(jobRepo, pipelineRepo, nil, nil, nil, time.Hour)
+
+	pipelineRepo.On("EnsureDefaultStages", mock.Anything, int32(1)).Return(nil)
+	jobRepo.On("ListDuplicateCandidates", mock.Anything, int32(1)).Return([]domain.Job{
+		{ID: 4, UserId: 1, Title: "Sr. Backend Engineer", CompanyName: "Paystack Inc.", Status: domain.JobStatusSaved},
+		{ID: 5, UserId: 1, Title: "Designer", CompanyName: "Paystack"},
+	}, nil)
+	jobRepo.On("Create", mock.Anything, mock.Anything).Return(int32(9), nil)
+
+	job := &domain.Job{UserId: 1, Title: "Senior Backend Engineer", CompanyName: "Paystack", Status: domain.JobStatusApplied}
+	id, duplicates, err := svc.Create(context.Background(), job)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(9), id)
+	require.Len(t, duplicates, 1)
+	assert.Equal(t, int32(4), duplicates[0].JobID)
+	assert.Equal(t, "same_company_title", duplicates[0].Reason)
+}
+
+func TestMergeJobFields(t *testing.T) {
+	target := domain.Job{
+		ID: 1, Title: "Backend Engineer", CompanyName: "Paystack", Status: domain.JobStatusApplied,
+		Notes: "Applied via referral", DateApplied: "2026-03-10", Priority: domain.JobPriorityLow,
+	}
+	duplicate := domain.Job{
+		ID: 2, Title: "Backend Engineer", CompanyName: "Paystack", Location: "Lagos", Link: "https://example.com/job",
+		Status: domain.JobStatusInterview, Notes: "Recruiter: Ada", DateApplied: "2026-03-08", Priority: domain.JobPriorityHigh,
+	}
+
+	merged := mergeJobFields(target, duplicate)
+
+	assert.Equal(t, int32(1), merged.ID)
+	assert.Equal(t, "Lagos", merged.Location)
+	assert.Equal(t, "https://example.com/job", merged.Link)
+	assert.Equal(t, domain.JobStatusInterview, merged.Status)
+	assert.Equal(t, "Applied via referral\n\nRecruiter: Ada", merged.Notes)
+	assert.Equal(t, "2026-03-08", merged.DateApplied)
+	assert.Equal(t, domain.JobPriorityHigh, merged.Priority)
+
+	// An offer outranks a rejection; identical notes are not repeated.
+	target.Status, duplicate.Status = domain.JobStatusOffer, domain.JobStatusRejected
+	duplicate.Notes = target.Notes
+	merged = mergeJobFields(target, duplicate)
+	assert.Equal(t, domain.JobStatusOffer, merged.Status)
+	assert.Equal(t, "Applied via referral", merged.Notes)
+}
+
+func TestJobService_MergeOtherUsersJob(t *testing.T) {
+	jobRepo := new(MockJobRepository)
+	svc := NewJobService(jobRepo, nil, nil, nil, nil, time.Hour)
+
+	jobRepo.On("GetJobByID", mock.Anything, int32(1)).Return(&domain.Job{ID: 1, UserId: 1}, nil)
+	jobRepo.On("GetJobByID", mock.Anything, int32(2)).Return(&domain.Job{ID: 2, UserId: 99}, nil)
+
+	_, err := svc.Merge(context.Background(), 1, 1, 2)
+
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	jobRepo.AssertNotCalled(t, "MergeJobs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
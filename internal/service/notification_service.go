@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -23,12 +24,20 @@ type NotificationService interface {
 
 	// Internal triggers (called by other services)
 	NotifySessionCompleted(ctx context.Context, userID int32, focusSeconds int32)
+	NotifySessionExpired(ctx context.Context, userID int32, completed bool, focusSeconds int32)
 	NotifyStreakMilestone(ctx context.Context, userID int32, streak int32)
 	NotifyBadgeEarned(ctx context.Context, userID int32, badgeName string)
+	NotifyJobNudge(ctx context.Context, userID int32, jobTitle, companyName string, days int32)
+	NotifyGoalReached(ctx context.Context, userID int32, goalTitle string, target int32)
+	NotifyGoalAtRisk(ctx context.Context, userID int32, goalTitle string, current, target, daysLeft int32)
+	NotifySavedSearchMatches(ctx context.Context, userID int32, searchName string, matches []domain.SavedSearchMatch)
+	NotifySavedSearchDigest(ctx context.Context, userID int32, digests []domain.SavedSearchDigest)
 
 	// Scheduled jobs
 	SendDailyReminders(ctx context.Context)
 	SendStreakWarnings(ctx context.Context)
+	SendContactFollowUps(ctx context.Context)
+	SendOfferDeadlineReminders(ctx context.Context)
 }
 
 type notificationService struct {
@@ -103,6 +112,7 @@ func (s *notificationService) UpdatePreferences(ctx context.Context, userID int3
 		FollowUpReminder:   req.FollowUpReminder,
 		ApplicationCheckIn: req.ApplicationCheckIn,
 		InterviewReminder:  req.InterviewReminder,
+		OfferDeadline:      req.OfferDeadline,
 	}
 
 	return s.notifRepo.UpsertPreferences(ctx, prefs)
@@ -130,6 +140,20 @@ func (s *notificationService) NotifySessionCompleted(ctx context.Context, userID
 	s.createInAppNotification(ctx, userID, domain.NotificationTypeSessionCompleted, title, message)
 }
 
+// NotifySessionExpired tells the user a session they left behind was ended
+// for them: completed once its time ran out, or abandoned after a long pause.
+func (s *notificationService) NotifySessionExpired(ctx context.Context, userID int32, completed bool, focusSeconds int32) {
+	minutes := focusSeconds / 60
+	title := "Session Complete 🔥"
+	message := fmt.Sprintf("Your %d minute session ran its full time while you were away. It counts towards your streak!", minutes)
+	if !completed {
+		title = "Session Ended"
+		message = "Your paused session was left too long, so we ended it. Start a new one whenever you're ready."
+	}
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeSessionExpired, title, message)
+}
+
 func (s *notificationService) NotifyStreakMilestone(ctx context.Context, userID int32, streak int32) {
 	// Only notify on specific milestones
 	milestones := map[int32][2]string{
@@ -156,14 +180,93 @@ func (s *notificationService) NotifyBadgeEarned(ctx context.Context, userID int3
 	s.createInAppNotification(ctx, userID, domain.NotificationTypeBadgeEarned, title, message)
 }
 
+// NotifyJobNudge is sent by an automation rule for a job that has not moved
+// in a while.
+func (s *notificationService) NotifyJobNudge(ctx context.Context, userID int32, jobTitle, companyName string, days int32) {
+	job := jobTitle
+	if companyName != "" {
+		job = fmt.Sprintf("%s at %s", jobTitle, companyName)
+	}
+	title := "Still interested? 🤔"
+	message := fmt.Sprintf("%s hasn't moved in %d days. Update it, or let it be archived.", job, days)
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeJobNudge, title, message)
+}
+
+func (s *notificationService) NotifyGoalReached(ctx context.Context, userID int32, goalTitle string, target int32) {
+	title := "Goal Reached! 🎉"
+	message := fmt.Sprintf("You hit your goal \"%s\" (%d). Nicely done!", goalTitle, target)
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeGoalReached, title, message)
+}
+
+func (s *notificationService) NotifyGoalAtRisk(ctx context.Context, userID int32, goalTitle string, current, target, daysLeft int32) {
+	title := "Goal at Risk ⏳"
+	dayWord := "days"
+	if daysLeft == 1 {
+		dayWord = "day"
+	}
+	message := fmt.Sprintf("\"%s\": %d of %d done with %d %s left. There's still time to catch up!", goalTitle, current, target, daysLeft, dayWord)
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeGoalAtRisk, title, message)
+}
+
+// NotifySavedSearchMatches announces the postings a saved search found that
+// it had not seen before.
+func (s *notificationService) NotifySavedSearchMatches(ctx context.Context, userID int32, searchName string, matches []domain.SavedSearchMatch) {
+	if len(matches) == 0 {
+		return
+	}
+	title := fmt.Sprintf("New jobs for \"%s\" 🔎", searchName)
+	first := matches[0].Title
+	if matches[0].CompanyName != "" {
+		first = fmt.Sprintf("%s at %s", matches[0].Title, matches[0].CompanyName)
+	}
+	message := fmt.Sprintf("%s is a new match.", first)
+	if len(matches) > 1 {
+		message = fmt.Sprintf("%s and %d more new matches.", first, len(matches)-1)
+	}
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeSavedSearchMatch, title, message)
+}
+
+// NotifySavedSearchDigest rolls the day's new matches across the user's
+// digest searches into one notification.
+func (s *notificationService) NotifySavedSearchDigest(ctx context.Context, userID int32, digests []domain.SavedSearchDigest) {
+	var total int32
+	parts := make([]string, 0, len(digests))
+	for _, d := range digests {
+		total += d.NewMatches
+		parts = append(parts, fmt.Sprintf("%s (%d)", d.SearchName, d.NewMatches))
+	}
+	if total == 0 {
+		return
+	}
+
+	title := "Your Job Digest 📬"
+	jobWord := "jobs"
+	if total == 1 {
+		jobWord = "job"
+	}
+	message := fmt.Sprintf("%d new %s for your saved searches: %s.", total, jobWord, strings.Join(parts, ", "))
+
+	s.createInAppNotification(ctx, userID, domain.NotificationTypeSavedSearchDigest, title, message)
+}
+
 // ─────────────────────────────────────────
 // Scheduled Jobs
 // ─────────────────────────────────────────
 
-// SendDailyReminders sends a reminder to all users who haven't completed
-// a session today. Call this from a cron job (e.g. every day at 6PM).
+// Reminders go out at these hours of each user's own day.
+const (
+	dailyReminderHour = 18
+	streakWarningHour = 20
+)
+
+// SendDailyReminders sends a reminder to the users for whom it is now 6PM and
+// who haven't completed a session today. Call this from a cron job every hour.
 func (s *notificationService) SendDailyReminders(ctx context.Context) {
-	userIDs, err := s.notifRepo.GetDailyReminderRecipients(ctx)
+	userIDs, err := s.notifRepo.GetDailyReminderRecipients(ctx, dailyReminderHour)
 	if err != nil {
 		log.Printf("failed to get daily reminder recipients: %v", err)
 		return
@@ -179,10 +282,11 @@ func (s *notificationService) SendDailyReminders(ctx context.Context) {
 	log.Printf("daily reminders sent to %d users", len(userIDs))
 }
 
-// SendStreakWarnings sends a warning to users with an active streak who
-// haven't sessioned today. Call this from a cron job (e.g. every day at 8PM).
+// SendStreakWarnings sends a warning to users with an active streak for whom
+// it is now 8PM and who haven't sessioned today. Call this from a cron job
+// every hour.
 func (s *notificationService) SendStreakWarnings(ctx context.Context) {
-	userIDs, err := s.notifRepo.GetStreakWarningRecipients(ctx)
+	userIDs, err := s.notifRepo.GetStreakWarningRecipients(ctx, streakWarningHour)
 	if err != nil {
 		log.Printf("failed to get streak warning recipients: %v", err)
 		return
@@ -198,6 +302,88 @@ func (s *notificationService) SendStreakWarnings(ctx context.Context) {
 	log.Printf("streak warnings sent to %d users", len(userIDs))
 }
 
+// SendContactFollowUps reminds users about contacts whose next-touch date has
+// arrived. Each date triggers one reminder; setting a new date re-arms it.
+// Call this from a cron job (e.g. every day at 9AM).
+func (s *notificationService) SendContactFollowUps(ctx context.Context) {
+	followUps, err := s.notifRepo.GetDueContactFollowUps(ctx)
+	if err != nil {
+		log.Printf("failed to get due contact follow-ups: %v", err)
+		return
+	}
+
+	sent := make([]int32, 0, len(followUps))
+	for _, f := range followUps {
+		title := fmt.Sprintf("Follow up with %s 🤝", f.Name)
+		message := fmt.Sprintf("It's time to reach out to %s again.", f.Name)
+		if f.Company != "" {
+			message = fmt.Sprintf("It's time to reach out to %s at %s again.", f.Name, f.Company)
+		}
+		if f.Note != "" {
+			message = fmt.Sprintf("%s Note: %s", message, f.Note)
+		}
+
+		s.createInAppNotification(ctx, f.UserID, domain.NotificationTypeContactFollowUp, title, message)
+		sent = append(sent, f.ContactID)
+	}
+
+	if err := s.notifRepo.MarkContactFollowUpsSent(ctx, sent); err != nil {
+		log.Printf("failed to mark contact follow-ups as sent: %v", err)
+		return
+	}
+
+	log.Printf("contact follow-up reminders sent for %d contacts", len(sent))
+}
+
+// offerDeadlineWindowDays is how far ahead of a response deadline the reminder goes out.
+const offerDeadlineWindowDays = 2
+
+// SendOfferDeadlineReminders warns users about open offers whose response
+// deadline is close. Each deadline triggers one reminder; moving the deadline
+// re-arms it. Call this from a cron job (e.g. every day at 9AM).
+func (s *notificationService) SendOfferDeadlineReminders(ctx context.Context) {
+	deadlines, err := s.notifRepo.GetUpcomingOfferDeadlines(ctx, offerDeadlineWindowDays)
+	if err != nil {
+		log.Printf("failed to get upcoming offer deadlines: %v", err)
+		return
+	}
+
+	// Deadlines are plain dates, decoded as UTC midnight.
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sent := make([]int32, 0, len(deadlines))
+	for _, d := range deadlines {
+		company := d.CompanyName
+		if company == "" {
+			company = d.Title
+		}
+
+		var when string
+		switch days := int(d.Deadline.Sub(today).Hours() / 24); {
+		case days <= 0:
+			when = "today"
+		case days == 1:
+			when = "tomorrow"
+		default:
+			when = fmt.Sprintf("in %d days", days)
+		}
+
+		title := "Offer Deadline Approaching ⏳"
+		message := fmt.Sprintf("Your offer from %s expires %s (%s). Make your decision before it lapses.",
+			company, when, d.Deadline.Format("Jan 2"))
+
+		s.createInAppNotification(ctx, d.UserID, domain.NotificationTypeOfferDeadline, title, message)
+		sent = append(sent, d.JobID)
+	}
+
+	if err := s.notifRepo.MarkOfferDeadlinesNotified(ctx, sent); err != nil {
+		log.Printf("failed to mark offer deadlines as notified: %v", err)
+		return
+	}
+
+	log.Printf("offer deadline reminders sent for %d offers", len(sent))
+}
+
 // getDailyReminderMessage rotates motivational messages
 func (s *notificationService) getDailyReminderMessage() string {
 	messages := []string{
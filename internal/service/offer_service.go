@@ -0,0 +1,133 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/pkg/currency"
+	"aiki/internal/repository"
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+type OfferService interface {
+	Get(ctx context.Context, userID, jobID int32) (*domain.JobOffer, error)
+	Save(ctx context.Context, userID, jobID int32, req *domain.JobOfferRequest) (*domain.JobOffer, error)
+	Delete(ctx context.Context, userID, jobID int32) error
+	Compare(ctx context.Context, userID int32, jobIDs []int32, targetCurrency string) (*domain.OfferComparison, error)
+}
+
+type offerService struct {
+	offerRepo       repository.OfferRepository
+	jobRepo         repository.JobRepository
+	rates           currency.Rates
+	defaultCurrency string
+}
+
+func NewOfferService(offerRepo repository.OfferRepository, jobRepo repository.JobRepository, rates currency.Rates, defaultCurrency string) OfferService {
+	return &offerService{
+		offerRepo:       offerRepo,
+		jobRepo:         jobRepo,
+		rates:           rates,
+		defaultCurrency: currency.Normalize(defaultCurrency),
+	}
+}
+
+func (s *offerService) Get(ctx context.Context, userID, jobID int32) (*domain.JobOffer, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	return s.offerRepo.GetByJobID(ctx, jobID)
+}
+
+func (s *offerService) Save(ctx context.Context, userID, jobID int32, req *domain.JobOfferRequest) (*domain.JobOffer, error) {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+
+	offer := req.ToDomain(userID, jobID)
+	offer.Currency = currency.Normalize(offer.Currency)
+	offer.Equity = strings.TrimSpace(offer.Equity)
+	offer.Benefits = strings.TrimSpace(offer.Benefits)
+	return s.offerRepo.Upsert(ctx, &offer)
+}
+
+func (s *offerService) Delete(ctx context.Context, userID, jobID int32) error {
+	if err := s.verifyJob(ctx, userID, jobID); err != nil {
+		return err
+	}
+	return s.offerRepo.Delete(ctx, jobID)
+}
+
+// Compare normalises the selected offers (all of them when jobIDs is empty)
+// to yearly figures in targetCurrency, falling back to the configured default.
+func (s *offerService) Compare(ctx context.Context, userID int32, jobIDs []int32, targetCurrency string) (*domain.OfferComparison, error) {
+	target := currency.Normalize(targetCurrency)
+	if target == "" {
+		target = s.defaultCurrency
+	}
+	if !s.rates.Supports(target) {
+		return nil, domain.ErrUnsupportedCurrency
+	}
+
+	offers, err := s.offerRepo.ListForComparison(ctx, userID, jobIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareOffers(offers, s.rates, target, time.Now()), nil
+}
+
+// compareOffers fills in the annual figures and orders the offers by total
+// yearly value, highest first. Offers that cannot be converted go last.
+func compareOffers(offers []domain.ComparedOffer, rates currency.Rates, target string, now time.Time) *domain.OfferComparison {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	for i := range offers {
+		c := &offers[i]
+		o := c.Offer
+
+		base, okBase := rates.Convert(o.AnnualBase(), o.Currency, target)
+		bonus, okBonus := rates.Convert(o.Bonus, o.Currency, target)
+		equity, okEquity := rates.Convert(o.EquityAnnualValue, o.Currency, target)
+		if okBase && okBonus && okEquity {
+			c.Converted = true
+			c.AnnualBase = roundCents(base)
+			c.AnnualBonus = roundCents(bonus)
+			c.AnnualEquity = roundCents(equity)
+			c.TotalAnnual = roundCents(base + bonus + equity)
+		}
+
+		if o.ResponseDeadline != "" {
+			if deadline, err := time.Parse("2006-01-02", o.ResponseDeadline); err == nil {
+				days := int(math.Round(deadline.Sub(today).Hours() / 24))
+				c.DaysToDeadline = &days
+			}
+		}
+	}
+
+	sort.SliceStable(offers, func(a, b int) bool {
+		if offers[a].Converted != offers[b].Converted {
+			return offers[a].Converted
+		}
+		return offers[a].TotalAnnual > offers[b].TotalAnnual
+	})
+
+	return &domain.OfferComparison{Currency: target, Offers: offers}
+}
+
+func (s *offerService) verifyJob(ctx context.Context, userID, jobID int32) error {
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.UserId != userID {
+		return domain.ErrUnauthorized
+	}
+	return nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+	"aiki/internal/pkg/currency"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareOffers(t *testing.T) {
+	rates := currency.Rates{"USD": 1, "EUR": 1.25, "NGN": 0.001}
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	offers := []domain.ComparedOffer{
+		{JobID: 1, Offer: domain.JobOffer{BaseSalary: 8000, Currency: "EUR", PayPeriod: domain.PayPeriodMonth, Bonus: 4000, ResponseDeadline: "2026-03-12"}},
+		{JobID: 2, Offer: domain.JobOffer{BaseSalary: 100000, Currency: "USD", PayPeriod: domain.PayPeriodYear, EquityAnnualValue: 30000}},
+		{JobID: 3, Offer: domain.JobOffer{BaseSalary: 5000, Currency: "XOF", PayPeriod: domain.PayPeriodMonth}},
+		{JobID: 4, Offer: domain.JobOffer{BaseSalary: 60, Currency: "USD", PayPeriod: domain.PayPeriodHour, ResponseDeadline: "2026-03-09"}},
+	}
+
+	got := compareOffers(offers, rates, "USD", now)
+
+	require.Len(t, got.Offers, 4)
+	assert.Equal(t, "USD", got.Currency)
+
+	order := []int32{}
+	for _, o := range got.Offers {
+		order = append(order, o.JobID)
+	}
+	// EUR 96k + 4k bonus = USD 125k; USD 130k incl. equity; 60/h = 124.8k; XOF has no rate.
+	assert.Equal(t, []int32{2, 1, 4, 3}, order)
+
+	eur := got.Offers[1]
+	assert.True(t, eur.Converted)
+	assert.Equal(t, 120000.0, eur.AnnualBase)
+	assert.Equal(t, 5000.0, eur.AnnualBonus)
+	assert.Equal(t, 125000.0, eur.TotalAnnual)
+	require.NotNil(t, eur.DaysToDeadline)
+	assert.Equal(t, 2, *eur.DaysToDeadline)
+
+	hourly := got.Offers[2]
+	assert.Equal(t, 124800.0, hourly.TotalAnnual)
+	require.NotNil(t, hourly.DaysToDeadline)
+	assert.Equal(t, -1, *hourly.DaysToDeadline)
+
+	xof := got.Offers[3]
+	assert.False(t, xof.Converted)
+	assert.Zero(t, xof.TotalAnnual)
+	assert.Nil(t, xof.DaysToDeadline)
+}
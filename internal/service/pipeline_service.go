@@ -0,0 +1,195 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/repository"
+	"context"
+	"sort"
+	"strings"
+)
+
+type PipelineService interface {
+	ListStages(ctx context.Context, userID int32) ([]domain.PipelineStage, error)
+	CreateStage(ctx context.Context, userID int32, req *domain.PipelineStageRequest) (*domain.PipelineStage, error)
+	UpdateStage(ctx context.Context, userID, stageID int32, req *domain.PipelineStageRequest) (*domain.PipelineStage, error)
+	DeleteStage(ctx context.Context, userID, stageID int32) error
+	ReorderStages(ctx context.Context, userID int32, stageIDs []int32) ([]domain.PipelineStage, error)
+	GetBoard(ctx context.Context, userID int32, filter domain.JobFilter) (*domain.JobBoard, error)
+	MoveJob(ctx context.Context, userID, jobID int32, req *domain.MoveJobRequest) (*domain.Job, error)
+}
+
+type pipelineService struct {
+	pipelineRepo repository.PipelineRepository
+	jobRepo      repository.JobRepository
+}
+
+func NewPipelineService(pipelineRepo repository.PipelineRepository, jobRepo repository.JobRepository) PipelineService {
+	return &pipelineService{
+		pipelineRepo: pipelineRepo,
+		jobRepo:      jobRepo,
+	}
+}
+
+func (s *pipelineService) ListStages(ctx context.Context, userID int32) ([]domain.PipelineStage, error) {
+	if err := s.pipelineRepo.EnsureDefaultStages(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.pipelineRepo.ListStages(ctx, userID)
+}
+
+func (s *pipelineService) CreateStage(ctx context.Context, userID int32, req *domain.PipelineStageRequest) (*domain.PipelineStage, error) {
+	if err := s.pipelineRepo.EnsureDefaultStages(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.pipelineRepo.CreateStage(ctx, domain.PipelineStage{
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.Name),
+		BaseStatus: req.BaseStatus,
+		Color:      req.Color,
+	})
+}
+
+func (s *pipelineService) UpdateStage(ctx context.Context, userID, stageID int32, req *domain.PipelineStageRequest) (*domain.PipelineStage, error) {
+	existing, err := s.pipelineRepo.GetStage(ctx, stageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-mapping the only stage for a status would leave that status without a column.
+	if existing.BaseStatus != req.BaseStatus {
+		if err := s.ensureNotLastForStatus(ctx, userID, existing.BaseStatus); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.pipelineRepo.UpdateStage(ctx, domain.PipelineStage{
+		ID:         stageID,
+		UserID:     userID,
+		Name:       strings.TrimSpace(req.Name),
+		BaseStatus: req.BaseStatus,
+		Color:      req.Color,
+	})
+}
+
+func (s *pipelineService) DeleteStage(ctx context.Context, userID, stageID int32) error {
+	stage, err := s.pipelineRepo.GetStage(ctx, stageID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.ensureNotLastForStatus(ctx, userID, stage.BaseStatus); err != nil {
+		return err
+	}
+	return s.pipelineRepo.DeleteStage(ctx, stageID, userID)
+}
+
+// ReorderStages expects every one of the user's stages exactly once, in the new order.
+func (s *pipelineService) ReorderStages(ctx context.Context, userID int32, stageIDs []int32) ([]domain.PipelineStage, error) {
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stageIDs) != len(stages) {
+		return nil, domain.ErrInvalidStageOrder
+	}
+
+	owned := make(map[int32]bool, len(stages))
+	for _, st := range stages {
+		owned[st.ID] = true
+	}
+	for _, id := range stageIDs {
+		if !owned[id] {
+			return nil, domain.ErrInvalidStageOrder
+		}
+		delete(owned, id)
+	}
+
+	if err := s.pipelineRepo.ReorderStages(ctx, userID, stageIDs); err != nil {
+		return nil, err
+	}
+	return s.pipelineRepo.ListStages(ctx, userID)
+}
+
+// GetBoard groups the user's jobs into their stage columns. Jobs without a
+// stage (e.g. an unknown legacy status) land in the first column for their
+// status, or the first column overall.
+func (s *pipelineService) GetBoard(ctx context.Context, userID int32, filter domain.JobFilter) (*domain.JobBoard, error) {
+	stages, err := s.ListStages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.jobRepo.GetAllJobs(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &domain.JobBoard{Columns: make([]domain.BoardColumn, len(stages))}
+	byStage := make(map[int32]int, len(stages))
+	byStatus := make(map[string]int, len(stages))
+	for i, st := range stages {
+		board.Columns[i] = domain.BoardColumn{Stage: st, Jobs: []domain.Job{}}
+		byStage[st.ID] = i
+		if _, ok := byStatus[st.BaseStatus]; !ok {
+			byStatus[st.BaseStatus] = i
+		}
+	}
+	if len(stages) == 0 {
+		return board, nil
+	}
+
+	for _, job := range jobs {
+		col, ok := -1, false
+		if job.StageID != nil {
+			col, ok = byStage[*job.StageID]
+		}
+		if !ok {
+			if col, ok = byStatus[job.Status]; !ok {
+				col = 0
+			}
+		}
+		board.Columns[col].Jobs = append(board.Columns[col].Jobs, job)
+	}
+
+	for i := range board.Columns {
+		colJobs := board.Columns[i].Jobs
+		sort.SliceStable(colJobs, func(a, b int) bool {
+			if colJobs[a].BoardPosition != colJobs[b].BoardPosition {
+				return colJobs[a].BoardPosition < colJobs[b].BoardPosition
+			}
+			return colJobs[a].ID < colJobs[b].ID
+		})
+	}
+	return board, nil
+}
+
+// MoveJob handles a drag-and-drop: the job takes the destination stage's base
+// status and is inserted at the requested position.
+func (s *pipelineService) MoveJob(ctx context.Context, userID, jobID int32, req *domain.MoveJobRequest) (*domain.Job, error) {
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserId != userID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	stage, err := s.pipelineRepo.GetStage(ctx, req.StageID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.jobRepo.MoveJob(ctx, userID, jobID, stage.ID, stage.BaseStatus, req.Position); err != nil {
+		return nil, err
+	}
+	return s.jobRepo.GetJobByID(ctx, jobID)
+}
+
+func (s *pipelineService) ensureNotLastForStatus(ctx context.Context, userID int32, status string) error {
+	count, err := s.pipelineRepo.CountStagesForStatus(ctx, userID, status)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return domain.ErrLastStageForStatus
+	}
+	return nil
+}
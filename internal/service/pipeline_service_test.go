@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockPipelineRepository is a mock implementation of PipelineRepository
+type MockPipelineRepository struct {
+	mock.Mock
+}
+
+func (m *MockPipelineRepository) EnsureDefaultStages(ctx context.Context, userID int32) error {
+	return m.Called(ctx, userID).Error(0)
+}
+
+func (m *MockPipelineRepository) ListStages(ctx context.Context, userID int32) ([]domain.PipelineStage, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.PipelineStage), args.Error(1)
+}
+
+func (m *MockPipelineRepository) GetStage(ctx context.Context, stageID, userID int32) (*domain.PipelineStage, error) {
+	args := m.Called(ctx, stageID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PipelineStage), args.Error(1)
+}
+
+func (m *MockPipelineRepository) CreateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error) {
+	args := m.Called(ctx, stage)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PipelineStage), args.Error(1)
+}
+
+func (m *MockPipelineRepository) UpdateStage(ctx context.Context, stage domain.PipelineStage) (*domain.PipelineStage, error) {
+	args := m.Called(ctx, stage)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PipelineStage), args.Error(1)
+}
+
+func (m *MockPipelineRepository) DeleteStage(ctx context.Context, stageID, userID int32) error {
+	return m.Called(ctx, stageID, userID).Error(0)
+}
+
+func (m *MockPipelineRepository) ReorderStages(ctx context.Context, userID int32, stageIDs []int32) error {
+	return m.Called(ctx, userID, stageIDs).Error(0)
+}
+
+func (m *MockPipelineRepository) CountStagesForStatus(ctx context.Context, userID int32, status string) (int32, error) {
+	args := m.Called(ctx, userID, status)
+	return args.Get(0).(int32), args.Error(1)
+}
+
+// MockJobRepository is a mock implementation of JobRepository
+type MockJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRepository) Create(ctx context.Context, job *domain.Job) (int32, error) {
+	args := m.Called(ctx, job)
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (m *MockJobRepository) Update(ctx context.Context, jobId int32, job *domain.Job) error {
+	return m.Called(ctx, jobId, job).Error(0)
+}
+
+func (m *MockJobRepository) DeleteJob(ctx context.Context, jobId int32) error {
+	return m.Called(ctx, jobId).Error(0)
+}
+
+func (m *MockJobRepository) GetJobByID(ctx context.Context, jobId int32) (*domain.Job, error) {
+	args := m.Called(ctx, jobId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) GetAllJobs(ctx context.Context, userId int32, filter domain.JobFilter) ([]domain.Job, error) {
+	args := m.Called(ctx, userId, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) GetUserTags(ctx context.Context, userId int32) ([]domain.TagCount, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.TagCount), args.Error(1)
+}
+
+func (m *MockJobRepository) MoveJob(ctx context.Context, userId, jobId, stageId int32, status string, position int32) error {
+	return m.Called(ctx, userId, jobId, stageId, status, position).Error(0)
+}
+
+func (m *MockJobRepository) ArchiveJob(ctx context.Context, userId, jobId int32) error {
+	return m.Called(ctx, userId, jobId).Error(0)
+}
+
+func (m *MockJobRepository) RestoreJob(ctx context.Context, userId, jobId int32) error {
+	return m.Called(ctx, userId, jobId).Error(0)
+}
+
+func (m *MockJobRepository) ListTrash(ctx context.Context, userId int32) ([]domain.Job, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockJobRepository) ListDuplicateCandidates(ctx context.Context, userId int32) ([]domain.Job, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) MergeJobs(ctx context.Context, userId, targetId, sourceId int32, merged *domain.Job) error {
+	return m.Called(ctx, userId, targetId, sourceId, merged).Error(0)
+}
+
+func int32Ptr(v int32) *int32 { return &v }
+
+var testStages = []domain.PipelineStage{
+	{ID: 10, UserID: 1, Name: "Saved", BaseStatus: domain.JobStatusSaved, Position: 0},
+	{ID: 11, UserID: 1, Name: "Applied", BaseStatus: domain.JobStatusApplied, Position: 1},
+	{ID: 12, UserID: 1, Name: "Phone screen", BaseStatus: domain.JobStatusInterview, Position: 2},
+	{ID: 13, UserID: 1, Name: "Onsite", BaseStatus: domain.JobStatusInterview, Position: 3},
+}
+
+func TestPipelineService_GetBoard(t *testing.T) {
+	pipelineRepo := new(MockPipelineRepository)
+	jobRepo := new(MockJobRepository)
+	svc := NewPipelineService(pipelineRepo, jobRepo)
+	ctx := context.Background()
+
+	jobs := []domain.Job{
+		{ID: 1, Status: domain.JobStatusInterview, StageID: int32Ptr(13), BoardPosition: 1},
+		{ID: 2, Status: domain.JobStatusInterview, StageID: int32Ptr(13), BoardPosition: 0},
+		{ID: 3, Status: domain.JobStatusInterview}, // no stage: first interview column
+		{ID: 4, Status: "withdrawn"},               // unknown status: first column
+	}
+
+	pipelineRepo.On("EnsureDefaultStages", ctx, int32(1)).Return(nil)
+	pipelineRepo.On("ListStages", ctx, int32(1)).Return(testStages, nil)
+	jobRepo.On("GetAllJobs", ctx, int32(1), domain.JobFilter{}).Return(jobs, nil)
+
+	board, err := svc.GetBoard(ctx, 1, domain.JobFilter{})
+
+	require.NoError(t, err)
+	require.Len(t, board.Columns, 4)
+	ids := func(col domain.BoardColumn) []int32 {
+		out := []int32{}
+		for _, j := range col.Jobs {
+			out = append(out, j.ID)
+		}
+		return out
+	}
+	assert.Equal(t, []int32{4}, ids(board.Columns[0]))
+	assert.Equal(t, []int32{}, ids(board.Columns[1]))
+	assert.Equal(t, []int32{3}, ids(board.Columns[2]))
+	assert.Equal(t, []int32{2, 1}, ids(board.Columns[3]))
+}
+
+func TestPipelineService_ReorderStages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rejects incomplete order", func(t *testing.T) {
+		pipelineRepo := new(MockPipelineRepository)
+		svc := NewPipelineService(pipelineRepo, new(MockJobRepository))
+		pipelineRepo.On("EnsureDefaultStages", ctx, int32(1)).Return(nil)
+		pipelineRepo.On("ListStages", ctx, int32(1)).Return(testStages, nil)
+
+		_, err := svc.ReorderStages(ctx, 1, []int32{13, 12, 11, 11})
+
+		assert.ErrorIs(t, err, domain.ErrInvalidStageOrder)
+		pipelineRepo.AssertNotCalled(t, "ReorderStages", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("applies full order", func(t *testing.T) {
+		pipelineRepo := new(MockPipelineRepository)
+		svc := NewPipelineService(pipelineRepo, new(MockJobRepository))
+		order := []int32{13, 12, 11, 10}
+		pipelineRepo.On("EnsureDefaultStages", ctx, int32(1)).Return(nil)
+		pipelineRepo.On("ListStages", ctx, int32(1)).Return(testStages, nil)
+		pipelineRepo.On("ReorderStages", ctx, int32(1), order).Return(nil).Once()
+
+		_, err := svc.ReorderStages(ctx, 1, order)
+
+		require.NoError(t, err)
+		pipelineRepo.AssertExpectations(t)
+	})
+}
+
+func TestPipelineService_DeleteStage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refuses last stage for a status", func(t *testing.T) {
+		pipelineRepo := new(MockPipelineRepository)
+		svc := NewPipelineService(pipelineRepo, new(MockJobRepository))
+		pipelineRepo.On("GetStage", ctx, int32(11), int32(1)).Return(&testStages[1], nil)
+		pipelineRepo.On("CountStagesForStatus", ctx, int32(1), domain.JobStatusApplied).Return(int32(1), nil)
+
+		err := svc.DeleteStage(ctx, 1, 11)
+
+		assert.ErrorIs(t, err, domain.ErrLastStageForStatus)
+		pipelineRepo.AssertNotCalled(t, "DeleteStage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("deletes when another stage shares the status", func(t *testing.T) {
+		pipelineRepo := new(MockPipelineRepository)
+		svc := NewPipelineService(pipelineRepo, new(MockJobRepository))
+		pipelineRepo.On("GetStage", ctx, int32(13), int32(1)).Return(&testStages[3], nil)
+		pipelineRepo.On("CountStagesForStatus", ctx, int32(1), domain.JobStatusInterview).Return(int32(2), nil)
+		pipelineRepo.On("DeleteStage", ctx, int32(13), int32(1)).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteStage(ctx, 1, 13))
+		pipelineRepo.AssertExpectations(t)
+	})
+}
+
+func TestPipelineService_MoveJob(t *testing.T) {
+	ctx := context.Background()
+	pipelineRepo := new(MockPipelineRepository)
+	jobRepo := new(MockJobRepository)
+	svc := NewPipelineService(pipelineRepo, jobRepo)
+
+	t.Run("other user's job", func(t *testing.T) {
+		jobRepo.On("GetJobByID", ctx, int32(5)).Return(&domain.Job{ID: 5, UserId: 2}, nil).Once()
+
+		_, err := svc.MoveJob(ctx, 1, 5, &domain.MoveJobRequest{StageID: 12})
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+
+	t.Run("adopts stage status", func(t *testing.T) {
+		job := &domain.Job{ID: 6, UserId: 1, Status: domain.JobStatusApplied}
+		jobRepo.On("GetJobByID", ctx, int32(6)).Return(job, nil).Twice()
+		pipelineRepo.On("GetStage", ctx, int32(12), int32(1)).Return(&testStages[2], nil).Once()
+		jobRepo.On("MoveJob", ctx, int32(1), int32(6), int32(12), domain.JobStatusInterview, int32(2)).Return(nil).Once()
+
+		_, err := svc.MoveJob(ctx, 1, 6, &domain.MoveJobRequest{StageID: 12, Position: 2})
+
+		require.NoError(t, err)
+		jobRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"aiki/internal/domain"
+	"aiki/internal/jobsource"
+	"aiki/internal/pkg/jobmatch"
+	"aiki/internal/repository"
+	"context"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// savedSearchMatchLimit caps how many matches are returned for a search.
+const savedSearchMatchLimit = 100
+
+type SavedSearchService interface {
+	ListSearches(ctx context.Context, userID int32) ([]domain.SavedSearch, error)
+	CreateSearch(ctx context.Context, userID int32, req *domain.SavedSearchRequest) (*domain.SavedSearch, error)
+	UpdateSearch(ctx context.Context, userID, searchID int32, req *domain.SavedSearchRequest) (*domain.SavedSearch, error)
+	DeleteSearch(ctx context.Context, userID, searchID int32) error
+	ListMatches(ctx context.Context, userID, searchID int32) ([]domain.SavedSearchMatch, error)
+	RunSearch(ctx context.Context, userID, searchID int32) (*domain.SavedSearchRun, error)
+
+	// Scheduled jobs
+	RunDueSearches(ctx context.Context)
+	SendDigests(ctx context.Context)
+}
+
+type savedSearchService struct {
+	searchRepo   repository.SavedSearchRepository
+	jobSource    jobsource.Source
+	notifService NotificationService
+}
+
+func NewSavedSearchService(
+	searchRepo repository.SavedSearchRepository,
+	jobSource jobsource.Source,
+	notifService NotificationService,
+) SavedSearchService {
+	return &savedSearchService{
+		searchRepo:   searchRepo,
+		jobSource:    jobSource,
+		notifService: notifService,
+	}
+}
+
+func (s *savedSearchService) ListSearches(ctx context.Context, userID int32) ([]domain.SavedSearch, error) {
+	return s.searchRepo.ListSearches(ctx, userID)
+}
+
+func (s *savedSearchService) CreateSearch(ctx context.Context, userID int32, req *domain.SavedSearchRequest) (*domain.SavedSearch, error) {
+	search := req.ToDomain(userID)
+	return s.searchRepo.CreateSearch(ctx, &search)
+}
+
+func (s *savedSearchService) UpdateSearch(ctx context.Context, userID, searchID int32, req *domain.SavedSearchRequest) (*domain.SavedSearch, error) {
+	search := req.ToDomain(userID)
+	search.ID = searchID
+	return s.searchRepo.UpdateSearch(ctx, &search)
+}
+
+func (s *savedSearchService) DeleteSearch(ctx context.Context, userID, searchID int32) error {
+	return s.searchRepo.DeleteSearch(ctx, searchID, userID)
+}
+
+func (s *savedSearchService) ListMatches(ctx context.Context, userID, searchID int32) ([]domain.SavedSearchMatch, error) {
+	search, err := s.searchRepo.GetSearch(ctx, searchID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.searchRepo.ListMatches(ctx, search.ID, savedSearchMatchLimit)
+}
+
+// RunSearch runs the search now. Matches it finds are reported in the result
+// rather than as a notification, since the user is looking at them.
+func (s *savedSearchService) RunSearch(ctx context.Context, userID, searchID int32) (*domain.SavedSearchRun, error) {
+	search, err := s.searchRepo.GetSearch(ctx, searchID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := s.search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	added, err := s.searchRepo.RecordMatches(ctx, search.ID, jobs, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshed, err := s.searchRepo.GetSearch(ctx, searchID, userID); err == nil {
+		search = refreshed
+	}
+	return &domain.SavedSearchRun{Search: *search, NewMatches: added, TotalFound: len(jobs)}, nil
+}
+
+// RunDueSearches re-runs every search whose turn has come and announces the
+// postings each has not returned before. The first run of a search only sets
+// the baseline: everything it finds is recorded as seen without an alert.
+// Call this from a cron job (e.g. every day at 6AM).
+func (s *savedSearchService) RunDueSearches(ctx context.Context) {
+	searches, err := s.searchRepo.ListDueSearches(ctx)
+	if err != nil {
+		log.Printf("failed to list due saved searches: %v", err)
+		return
+	}
+
+	var announced int
+	for _, search := range searches {
+		jobs, err := s.search(ctx, &search)
+		if err != nil {
+			log.Printf("saved search %d failed: %v", search.ID, err)
+			continue
+		}
+
+		baseline := search.LastRunAt == nil
+		alerted := baseline || search.Alert != domain.SearchAlertDigest
+		added, err := s.searchRepo.RecordMatches(ctx, search.ID, jobs, alerted)
+		if err != nil {
+			log.Printf("failed to record matches for saved search %d: %v", search.ID, err)
+			continue
+		}
+
+		if !baseline && search.Alert == domain.SearchAlertInstant && len(added) > 0 {
+			s.notifService.NotifySavedSearchMatches(ctx, search.UserID, search.Name, added)
+			announced++
+		}
+	}
+
+	log.Printf("saved searches: ran %d, announced new matches for %d", len(searches), announced)
+}
+
+// SendDigests sends each user one notification covering the new matches of
+// all their digest searches. Call this from a cron job (e.g. every day at 8AM,
+// after RunDueSearches).
+func (s *savedSearchService) SendDigests(ctx context.Context) {
+	digests, err := s.searchRepo.ListPendingDigests(ctx)
+	if err != nil {
+		log.Printf("failed to list pending saved search digests: %v", err)
+		return
+	}
+
+	// Digests arrive ordered by user.
+	var users int
+	for start := 0; start < len(digests); {
+		end := start
+		for end < len(digests) && digests[end].UserID == digests[start].UserID {
+			end++
+		}
+		s.notifService.NotifySavedSearchDigest(ctx, digests[start].UserID, digests[start:end])
+		users++
+		start = end
+	}
+
+	if err := s.searchRepo.MarkDigestSent(ctx, digests); err != nil {
+		log.Printf("failed to mark saved search digests as sent: %v", err)
+		return
+	}
+
+	log.Printf("saved search digests sent to %d users", users)
+}
+
+func (s *savedSearchService) search(ctx context.Context, search *domain.SavedSearch) ([]domain.SerpJob, error) {
+	jobs, err := s.jobSource.Search(ctx, jobsource.Query{Title: search.Query, Location: search.Location})
+	if err != nil {
+		return nil, err
+	}
+	return filterSavedSearchJobs(search, jobs), nil
+}
+
+// filterSavedSearchJobs applies the filters the job boards cannot: remote
+// only, excluded companies and the salary floor. A posting that states no
+// salary is kept, since most do not.
+func filterSavedSearchJobs(search *domain.SavedSearch, jobs []domain.SerpJob) []domain.SerpJob {
+	excluded := make(map[string]bool, len(search.ExcludedCompanies))
+	for _, company := range search.ExcludedCompanies {
+		if key := jobmatch.CompanyKey(company); key != "" {
+			excluded[key] = true
+		}
+	}
+
+	kept := make([]domain.SerpJob, 0, len(jobs))
+	seen := make(map[string]bool, len(jobs))
+	for _, job := range jobs {
+		if seen[job.ExternalID] {
+			continue
+		}
+		if search.RemoteOnly && !jobsource.IsRemote(job.Location) {
+			continue
+		}
+		if excluded[jobmatch.CompanyKey(job.CompanyName)] {
+			continue
+		}
+		if search.MinSalary != nil {
+			if top, ok := salaryCeiling(job.Salary); ok && top < float64(*search.MinSalary) {
+				continue
+			}
+		}
+		seen[job.ExternalID] = true
+		kept = append(kept, job)
+	}
+	return kept
+}
+
+var salaryAmount = regexp.MustCompile(`(\d[\d,]*(?:\.\d+)?)\s*([kKmM]\b)?`)
+
+// salaryCeiling reads the top of the range in a free-text salary such as
+// "$90k - $120k" or "₦1.2M–₦1.8M a month". The pay period and currency are
+// not taken into account.
+func salaryCeiling(salary string) (float64, bool) {
+	var top float64
+	found := false
+	for _, m := range salaryAmount.FindAllStringSubmatch(salary, -1) {
+		amount, err := strconv.ParseFloat(strings.ReplaceAll(m[1], ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(m[2]) {
+		case "k":
+			amount *= 1_000
+		case "m":
+			amount *= 1_000_000
+		}
+		if amount > top {
+			top = amount
+		}
+		found = true
+	}
+	return top, found && top > 0
+}
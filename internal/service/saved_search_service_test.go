@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+
+	"aiki/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSalaryCeiling(t *testing.T) {
+	tests := []struct {
+		salary string
+		want   float64
+		ok     bool
+	}{
+		{"$90k - $120k", 120_000, true},
+		{"₦1.2M–₦1.8M a month", 1_800_000, true},
+		{"NGN 800000–1200000 per-month", 1_200_000, true},
+		{"£65,000 per annum", 65_000, true},
+		{"65000 monthly", 65_000, true},
+		{"Competitive", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.salary, func(t *testing.T) {
+			got, ok := salaryCeiling(tt.salary)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFilterSavedSearchJobs(t *testing.T) {
+	minSalary := int32(100_000)
+	search := &domain.SavedSearch{
+		RemoteOnly:        true,
+		MinSalary:         &minSalary,
+		ExcludedCompanies: []string{"Acme Inc."},
+	}
+	jobs := []domain.SerpJob{
+		{ExternalID: "1", CompanyName: "Paystack", Location: "Remote (Africa)", Salary: "$90k - $120k"},
+		{ExternalID: "2", CompanyName: "Kuda", Location: "Lagos, Nigeria"},
+		{ExternalID: "3", CompanyName: "ACME", Location: "Anywhere"},
+		{ExternalID: "4", CompanyName: "Monzo", Location: "Remote", Salary: "£60,000"},
+		{ExternalID: "5", CompanyName: "Doist", Location: "Work from home"},
+		{ExternalID: "1", CompanyName: "Paystack", Location: "Remote (Africa)", Salary: "$90k - $120k"},
+	}
+
+	got := filterSavedSearchJobs(search, jobs)
+
+	ids := make([]string, len(got))
+	for i, j := range got {
+		ids[i] = j.ExternalID
+	}
+	// 2 is on-site, 3 is an excluded company, 4 pays below the floor and the
+	// second 1 is a repeat. 5 states no salary, so it is kept.
+	assert.Equal(t, []string{"1", "5"}, ids)
+}
+
+func TestFilterSavedSearchJobs_NoFilters(t *testing.T) {
+	jobs := []domain.SerpJob{
+		{ExternalID: "1", Location: "Lagos"},
+		{ExternalID: "2", Location: "Remote", Salary: "$10k"},
+	}
+	assert.Len(t, filterSavedSearchJobs(&domain.SavedSearch{}, jobs), 2)
+}
@@ -2,122 +2,638 @@ package service
 
 import (
 	"aiki/internal/domain"
+	"aiki/internal/jobsource"
+	"aiki/internal/pkg/currency"
+	"aiki/internal/pkg/jobmatch"
+	"aiki/internal/pkg/jobtext"
+	"aiki/internal/pkg/jobtitle"
 	"aiki/internal/repository"
-	"aiki/internal/serp"
 	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"expvar"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
 const cacheTTL = 24 * time.Hour
 
+const (
+	defaultRecommendationLimit = 20
+	// maxUpstreamPagesPerRequest bounds how many upstream pages one request
+	// may fetch when the cache filters leave a page short.
+	maxUpstreamPagesPerRequest = 3
+	// maxRelatedTitles bounds how many related titles a search adds, so the
+	// profile's own title still dominates the results.
+	maxRelatedTitles = 2
+)
+
 type SerpJobService interface {
-	GetJobsForUser(ctx context.Context, userID int32, location string) (*domain.JobSearchResult, error)
-	SaveJobToTracker(ctx context.Context, userID int32, cacheID int32) (*domain.Job, error)
+	GetJobsForUser(ctx context.Context, userID int32, q *domain.RecommendationQuery) (*domain.JobSearchResult, error)
+	SaveJobToTracker(ctx context.Context, userID int32, cacheID int32) (*domain.SavedJob, error)
 	ApplyRecommendedJob(ctx context.Context, userID int32, cacheID int32, notes string) (*domain.DirectApplyResult, error)
+	GetSearchQuota(ctx context.Context) ([]domain.SearchQuotaUsage, error)
+	GetSearchStats(ctx context.Context) *domain.SearchStats
+	PrefetchRecommendations(ctx context.Context)
+
+	// Search preferences
+	GetSearchPreferences(ctx context.Context, userID int32) (*domain.SearchPreferences, error)
+	UpdateSearchPreferences(ctx context.Context, userID int32, req *domain.UpdateSearchPreferencesRequest) (*domain.SearchPreferences, error)
+
+	// Dismissals
+	DismissJob(ctx context.Context, userID, cacheID int32, req *domain.DismissJobRequest) (*domain.JobDismissal, error)
+	ListDismissals(ctx context.Context, userID int32) ([]domain.JobDismissal, error)
+	DeleteDismissal(ctx context.Context, userID, dismissalID int32) error
 }
 
 type serpJobService struct {
-	serpRepo   repository.SerpJobRepository
-	userRepo   repository.UserRepository
-	jobRepo    repository.JobRepository
-	serpClient *serp.Client
+	serpRepo       repository.SerpJobRepository
+	userRepo       repository.UserRepository
+	jobRepo        repository.JobRepository
+	attachmentRepo repository.AttachmentRepository
+	jobSource      jobsource.Source
+	rates          currency.Rates
+	quota          *jobsource.QuotaManager
+	prefetch       PrefetchOptions
+}
+
+// PrefetchOptions control the background refresh of recommendations. Each
+// run refreshes up to MaxUsers searches due to expire within Lead, for users
+// who looked at theirs within ActiveWindow. Workers fetch at once, and a new
+// fetch starts at most every Stagger, keeping the run under upstream rate
+// limits.
+type PrefetchOptions struct {
+	Workers      int
+	Stagger      time.Duration
+	Lead         time.Duration
+	ActiveWindow time.Duration
+	MaxUsers     int32
 }
 
 func NewSerpJobService(
 	serpRepo repository.SerpJobRepository,
 	userRepo repository.UserRepository,
 	jobRepo repository.JobRepository,
-	serpClient *serp.Client,
+	attachmentRepo repository.AttachmentRepository,
+	jobSource jobsource.Source,
+	rates currency.Rates,
+	quota *jobsource.QuotaManager,
+	prefetch PrefetchOptions,
 ) SerpJobService {
 	return &serpJobService{
-		serpRepo:   serpRepo,
-		userRepo:   userRepo,
-		jobRepo:    jobRepo,
-		serpClient: serpClient,
+		serpRepo:       serpRepo,
+		userRepo:       userRepo,
+		jobRepo:        jobRepo,
+		attachmentRepo: attachmentRepo,
+		jobSource:      jobSource,
+		rates:          rates,
+		quota:          quota,
+		prefetch:       prefetch,
 	}
 }
 
-func (s *serpJobService) GetJobsForUser(ctx context.Context, userID int32, location string) (*domain.JobSearchResult, error) {
-	loc := strings.TrimSpace(location)
+// GetJobsForUser returns a page of recommendations. The first page of a
+// search is served from the cache while it is fresh; later pages read the
+// cache and, in relevance order, fetch further upstream pages when it runs
+// short. Sorting by newest or pay orders the jobs fetched so far.
+func (s *serpJobService) GetJobsForUser(ctx context.Context, userID int32, q *domain.RecommendationQuery) (*domain.JobSearchResult, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
 
-	profile, err := s.userRepo.GetUserProfileByID(ctx, userID)
+	query, feedback, err := s.buildQuery(ctx, userID, domain.SerpSearchParams{
+		Location:       strings.TrimSpace(q.Location),
+		Locations:      cleanPlaces(q.Locations),
+		Country:        strings.ToLower(q.Country),
+		Language:       strings.ToLower(q.Language),
+		DatePosted:     q.DatePosted,
+		EmploymentType: q.EmploymentType,
+		RemoteOnly:     q.RemoteOnly,
+	})
 	if err != nil {
-		return nil, errors.New("please complete your profile (job title and experience level) before searching for jobs")
+		return nil, err
 	}
-	if profile.CurrentJob == "" {
-		return nil, errors.New("please add your current job title to your profile to get job recommendations")
+	key := searchKey(query)
+
+	state, err := s.serpRepo.GetSearchState(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && q.Cursor == "" {
+		if err := s.serpRepo.TouchSearchState(ctx, userID); err != nil {
+			log.Printf("failed to record recommendations view for user %d: %v", userID, err)
+		}
+	}
+
+	sort := q.Sort
+	if sort == "" {
+		sort = domain.RecommendationSortRelevance
 	}
 
-	// Return from cache if fresh and location filter matches the last successful search
-	lastFetch, err := s.serpRepo.GetLatestFetchTime(ctx, userID)
-	if err == nil && lastFetch != nil && time.Since(*lastFetch) < cacheTTL && profile.JobSearchLocation == loc {
-		cached, err := s.serpRepo.GetCachedJobs(ctx, userID, 20, 0)
+	var after *domain.SerpCacheCursor
+	fromCache := true
+	switch {
+	case q.Cursor != "":
+		after, err = decodeCursor(q.Cursor, key, sort)
+		if err != nil || state == nil || state.SearchKey != key {
+			return nil, domain.ErrInvalidCursor
+		}
+	case state == nil || state.SearchKey != key || time.Since(state.FetchedAt) >= cacheTTL:
+		fresh, err := s.startSearch(ctx, userID, query, key, feedback)
+		if err != nil {
+			log.Printf("job search failed for user %d: %v", userID, err)
+			// A stale copy of the same search beats nothing.
+			if state == nil || state.SearchKey != key {
+				switch {
+				case errors.Is(err, domain.ErrSearchRateLimited):
+					return nil, err
+				case errors.Is(err, jobsource.ErrQuotaExhausted):
+					return nil, domain.ErrSearchQuotaExhausted
+				}
+				return nil, errors.New("failed to fetch jobs, please try again later")
+			}
+			break
+		}
+		state = fresh
+		fromCache = false
+	}
+
+	filter := domain.SerpCacheFilter{SearchKey: key, HasSalary: q.HasSalary, Platform: strings.TrimSpace(q.Platform), Sort: sort}
+	jobs, err := s.serpRepo.ListSearchResults(ctx, userID, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Top up from upstream while the page is short and there is more. A
+	// sorted view does not, as new jobs could sort before the cursor.
+	relevance := sort == domain.RecommendationSortRelevance
+	for fetched := 0; relevance && len(jobs) <= int(limit) && state.NextPageToken != "" && fetched < maxUpstreamPagesPerRequest; fetched++ {
+		page, next, err := jobsource.SearchPage(ctx, s.jobSource, query, state.NextPageToken)
+		if err != nil {
+			log.Printf("failed to fetch next job page for user %d: %v", userID, err)
+			break
+		}
+		state, err = s.serpRepo.AppendSearchResults(ctx, userID, key, s.normalise(feedback.apply(page)), next)
 		if err != nil {
 			return nil, err
 		}
-		return &domain.JobSearchResult{
-			Jobs:       cached,
-			TotalCount: len(cached),
-			FromCache:  true,
-			FetchedAt:  *lastFetch,
-		}, nil
-	}
-
-	// Delete stale cache before a new Serp fetch
-	_ = s.serpRepo.DeleteOldCache(ctx, userID)
-
-	jobs, err := s.serpClient.FetchJobs(profile.CurrentJob, profile.ExperienceLevel, loc)
-	if err != nil {
-		log.Printf("serp api fetch failed for user %d: %v", userID, err)
-		if profile.JobSearchLocation == loc {
-			cached, cacheErr := s.serpRepo.GetCachedJobs(ctx, userID, 20, 0)
-			if cacheErr == nil && len(cached) > 0 {
-				return &domain.JobSearchResult{
-					Jobs:       cached,
-					TotalCount: len(cached),
-					FromCache:  true,
-					FetchedAt:  cached[0].FetchedAt,
-				}, nil
-			}
+		fromCache = false
+		if jobs, err = s.serpRepo.ListSearchResults(ctx, userID, filter, after, limit+1); err != nil {
+			return nil, err
+		}
+	}
+
+	hasMore := len(jobs) > int(limit) || (relevance && state.NextPageToken != "")
+	if len(jobs) > int(limit) {
+		jobs = jobs[:limit]
+	}
+	var nextCursor string
+	if hasMore {
+		next := after
+		if len(jobs) > 0 {
+			last := jobs[len(jobs)-1]
+			next = &domain.SerpCacheCursor{Position: last.Position, Value: last.SortValue}
+		}
+		nextCursor = encodeCursor(next, key, sort)
+	}
+
+	return &domain.JobSearchResult{
+		Jobs:       jobs,
+		TotalCount: len(jobs),
+		FromCache:  fromCache,
+		FetchedAt:  state.FetchedAt,
+		NextCursor: nextCursor,
+		HasMore:    hasMore,
+	}, nil
+}
+
+// buildQuery makes the user's recommendation search from their profile, the
+// filters, their search preferences and what their dismissals have taught.
+// Places and country in the filters replace the preferred ones.
+func (s *serpJobService) buildQuery(ctx context.Context, userID int32, params domain.SerpSearchParams) (jobsource.Query, *dismissalFeedback, error) {
+	profile, err := s.userRepo.GetUserProfileByID(ctx, userID)
+	if err != nil {
+		return jobsource.Query{}, nil, errors.New("please complete your profile (job title and experience level) before searching for jobs")
+	}
+	if profile.CurrentJob == "" {
+		return jobsource.Query{}, nil, errors.New("please add your current job title to your profile to get job recommendations")
+	}
+
+	dismissals, err := s.serpRepo.ListDismissals(ctx, userID)
+	if err != nil {
+		return jobsource.Query{}, nil, err
+	}
+	feedback := newDismissalFeedback(dismissals, profile.CurrentJob+" "+profile.ExperienceLevel)
+
+	prefs, err := s.serpRepo.GetSearchPreferences(ctx, userID)
+	if err != nil {
+		return jobsource.Query{}, nil, err
+	}
+
+	// Profiles saved before titles were normalised are read the same way.
+	title, level := jobtitle.Normalise(profile.CurrentJob, profile.ExperienceLevel)
+	related := jobtitle.Related(title)
+	related = related[:min(maxRelatedTitles, len(related))]
+
+	query := jobsource.Query{
+		Title:           title,
+		ExperienceLevel: level,
+		RelatedTitles:   related,
+		Country:         firstNonEmpty(params.Country, prefs.Country),
+		Language:        firstNonEmpty(params.Language, prefs.Language),
+		IncludeRemote:   prefs.OpenToRemote && !params.RemoteOnly,
+		DatePosted:      params.DatePosted,
+		EmploymentType:  params.EmploymentType,
+		RemoteOnly:      params.RemoteOnly,
+		ExcludeTerms:    feedback.excludeTerms,
+	}
+	setPlaces(&query, searchPlaces(params, prefs))
+	return query, feedback, nil
+}
+
+// searchPlaces lists the places to search: those asked for, else the
+// preferred ones, plus the whole country for a user open to relocating.
+func searchPlaces(params domain.SerpSearchParams, prefs *domain.SearchPreferences) []string {
+	var places []string
+	if params.Location != "" {
+		places = append(places, params.Location)
+	}
+	places = append(places, params.Locations...)
+	if len(places) == 0 {
+		places = append(places, prefs.Locations...)
+	}
+	if prefs.OpenToRelocation && len(places) > 0 {
+		places = append(places, "")
+	}
+	return dedupePlaces(places)
+}
+
+// setPlaces searches a single place as the query's Location, and several
+// together through Locations.
+func setPlaces(q *jobsource.Query, places []string) {
+	switch len(places) {
+	case 0:
+	case 1:
+		q.Location = places[0]
+	default:
+		q.Locations = places
+	}
+}
+
+// cleanPlaces trims places and drops blanks and repeats.
+func cleanPlaces(places []string) []string {
+	var kept []string
+	for _, p := range places {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
 		}
-		return nil, errors.New("failed to fetch jobs, please try again later")
 	}
+	return dedupePlaces(kept)
+}
+
+// dedupePlaces drops places that repeat an earlier one, ignoring case and
+// punctuation.
+func dedupePlaces(places []string) []string {
+	seen := map[string]bool{}
+	var kept []string
+	for _, p := range places {
+		key := jobmatch.LocationKey(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, p)
+	}
+	return kept
+}
 
-	if len(jobs) == 0 {
-		if err := s.userRepo.UpdateUserJobSearchLocation(ctx, userID, loc); err != nil {
-			log.Printf("failed to persist job search location for user %d: %v", userID, err)
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
 		}
-		return &domain.JobSearchResult{
-			Jobs:       []domain.SerpJobCache{},
-			TotalCount: 0,
-			FromCache:  false,
-			FetchedAt:  time.Now(),
-		}, nil
 	}
+	return ""
+}
+
+// startSearch fetches the first upstream page of a search the user asked
+// for, counting it against their refresh limit.
+func (s *serpJobService) startSearch(ctx context.Context, userID int32, query jobsource.Query, key string, feedback *dismissalFeedback) (*domain.SerpSearchState, error) {
+	if s.quota != nil {
+		if err := s.quota.AllowRefresh(ctx, userID); errors.Is(err, domain.ErrSearchRateLimited) {
+			return nil, err
+		} else if err != nil {
+			log.Printf("failed to check search refresh limit for user %d: %v", userID, err)
+		}
+	}
+	return s.fetchSearch(ctx, userID, query, key, feedback)
+}
 
-	cached, err := s.serpRepo.UpsertJobs(ctx, userID, jobs)
+// fetchSearch fetches the first upstream page of a search and makes it the
+// user's current one.
+func (s *serpJobService) fetchSearch(ctx context.Context, userID int32, query jobsource.Query, key string, feedback *dismissalFeedback) (*domain.SerpSearchState, error) {
+	jobs, next, err := jobsource.SearchPage(ctx, s.jobSource, query, "")
+	if err != nil {
+		return nil, err
+	}
+
+	params := domain.SerpSearchParams{
+		Location:       query.Location,
+		Locations:      query.Locations,
+		Country:        query.Country,
+		Language:       query.Language,
+		DatePosted:     query.DatePosted,
+		EmploymentType: query.EmploymentType,
+		RemoteOnly:     query.RemoteOnly,
+	}
+	state, err := s.serpRepo.ReplaceSearchResults(ctx, userID, key, params, s.normalise(feedback.apply(jobs)), next)
 	if err != nil {
 		log.Printf("failed to cache serp jobs for user %d: %v", userID, err)
 		return nil, fmt.Errorf("failed to cache job listings: %w", err)
 	}
-
-	if err := s.userRepo.UpdateUserJobSearchLocation(ctx, userID, loc); err != nil {
+	if err := s.serpRepo.DeleteOldCache(ctx, userID); err != nil {
+		log.Printf("failed to delete old serp cache for user %d: %v", userID, err)
+	}
+	location := query.Location
+	if len(query.Locations) > 0 {
+		location = strings.Join(cleanPlaces(query.Locations), "; ")
+	}
+	if err := s.userRepo.UpdateUserJobSearchLocation(ctx, userID, location); err != nil {
 		log.Printf("failed to persist job search location for user %d: %v", userID, err)
 	}
+	return state, nil
+}
 
-	return &domain.JobSearchResult{
-		Jobs:       cached,
-		TotalCount: len(cached),
-		FromCache:  false,
-		FetchedAt:  time.Now(),
-	}, nil
+// prefetchStats counts, across runs, the searches refreshed ahead of expiry
+// and those that failed, and records when the last run finished and what it
+// did. Admins read them from GetSearchStats.
+var prefetchStats = expvar.NewMap("recommendation_prefetch")
+
+// PrefetchRecommendations refreshes, ahead of expiry, the searches of users
+// who have looked at their recommendations recently, so their next visit is
+// served from the cache. A run stops early once a paid source's budget is
+// used up. Call this from the scheduler every PrefetchOptions.Lead/2 or so.
+func (s *serpJobService) PrefetchRecommendations(ctx context.Context) {
+	opts := s.prefetch
+	start := time.Now()
+	states, err := s.serpRepo.ListPrefetchCandidates(ctx, start.Add(opts.Lead-cacheTTL), start.Add(-opts.ActiveWindow), opts.MaxUsers)
+	if err != nil {
+		log.Printf("failed to list searches to prefetch: %v", err)
+		prefetchStats.Add("errors", 1)
+		return
+	}
+
+	refreshed, failed := prefetchAll(ctx, states, opts, func(ctx context.Context, state domain.SerpSearchState) error {
+		query, feedback, err := s.buildQuery(ctx, state.UserID, state.Params)
+		if err != nil {
+			return err
+		}
+		_, err = s.fetchSearch(ctx, state.UserID, query, searchKey(query), feedback)
+		return err
+	})
+
+	prefetchStats.Add("refreshed", int64(refreshed))
+	prefetchStats.Add("failed", int64(failed))
+	last := new(expvar.Map).Init()
+	last.Add("due", int64(len(states)))
+	last.Add("refreshed", int64(refreshed))
+	last.Add("failed", int64(failed))
+	last.Add("duration_ms", time.Since(start).Milliseconds())
+	last.Add("finished_at", time.Now().Unix())
+	prefetchStats.Set("last_run", last)
+	log.Printf("prefetched recommendations: %d due, %d refreshed, %d failed in %s",
+		len(states), refreshed, failed, time.Since(start).Round(time.Millisecond))
+}
+
+// prefetchAll runs refresh for each state on a pool of opts.Workers, starting
+// one at most every opts.Stagger. It stops handing out work when ctx ends or
+// a refresh finds the source's budget used up.
+func prefetchAll(ctx context.Context, states []domain.SerpSearchState, opts PrefetchOptions, refresh func(context.Context, domain.SerpSearchState) error) (refreshed, failed int) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := max(opts.Workers, 1)
+	work := make(chan domain.SerpSearchState)
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for state := range work {
+				if ctx.Err() != nil {
+					continue
+				}
+				err := refresh(ctx, state)
+				mu.Lock()
+				if err != nil {
+					failed++
+				} else {
+					refreshed++
+				}
+				mu.Unlock()
+				if err != nil {
+					log.Printf("failed to prefetch recommendations for user %d: %v", state.UserID, err)
+				}
+				if errors.Is(err, jobsource.ErrQuotaExhausted) {
+					cancel()
+				}
+			}
+		}()
+	}
+
+	var tick <-chan time.Time
+	if opts.Stagger > 0 {
+		ticker := time.NewTicker(opts.Stagger)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+feed:
+	for i, state := range states {
+		if i > 0 && tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		select {
+		case work <- state:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	return refreshed, failed
+}
+
+// GetSearchQuota reports this month's use of the paid job sources.
+func (s *serpJobService) GetSearchQuota(ctx context.Context) ([]domain.SearchQuotaUsage, error) {
+	if s.quota == nil {
+		return []domain.SearchQuotaUsage{}, nil
+	}
+	return s.quota.Usage(ctx)
+}
+
+// GetSearchStats reports the shared search cache and prefetch counters of
+// this process.
+func (s *serpJobService) GetSearchStats(ctx context.Context) *domain.SearchStats {
+	cache, hitRatio := jobsource.CacheStats()
+	return &domain.SearchStats{
+		CacheHitRatio: hitRatio,
+		Cache:         cache,
+		Prefetch:      json.RawMessage(prefetchStats.String()),
+	}
+}
+
+func (s *serpJobService) DismissJob(ctx context.Context, userID, cacheID int32, req *domain.DismissJobRequest) (*domain.JobDismissal, error) {
+	reason := req.Reason
+	if reason == "" {
+		reason = domain.DismissReasonNotRelevant
+	}
+	return s.serpRepo.DismissJob(ctx, userID, cacheID, reason)
 }
 
-func (s *serpJobService) SaveJobToTracker(ctx context.Context, userID int32, cacheID int32) (*domain.Job, error) {
+func (s *serpJobService) ListDismissals(ctx context.Context, userID int32) ([]domain.JobDismissal, error) {
+	return s.serpRepo.ListDismissals(ctx, userID)
+}
+
+func (s *serpJobService) DeleteDismissal(ctx context.Context, userID, dismissalID int32) error {
+	return s.serpRepo.DeleteDismissal(ctx, userID, dismissalID)
+}
+
+func (s *serpJobService) GetSearchPreferences(ctx context.Context, userID int32) (*domain.SearchPreferences, error) {
+	return s.serpRepo.GetSearchPreferences(ctx, userID)
+}
+
+// UpdateSearchPreferences replaces the user's search preferences. Their next
+// recommendations are a new search made with them.
+func (s *serpJobService) UpdateSearchPreferences(ctx context.Context, userID int32, req *domain.UpdateSearchPreferencesRequest) (*domain.SearchPreferences, error) {
+	prefs := domain.SearchPreferences{
+		Country:          strings.ToLower(strings.TrimSpace(req.Country)),
+		Language:         strings.ToLower(strings.TrimSpace(req.Language)),
+		Locations:        cleanPlaces(req.Locations),
+		OpenToRemote:     req.OpenToRemote,
+		OpenToRelocation: req.OpenToRelocation,
+	}
+	return s.serpRepo.UpsertSearchPreferences(ctx, userID, prefs)
+}
+
+// searchKey identifies a search by everything that changes what is fetched
+// upstream. Cache-only filters are left out, so changing them reuses it.
+func searchKey(q jobsource.Query) string {
+	return strings.ToLower(strings.Join([]string{
+		strings.TrimSpace(q.Title),
+		q.ExperienceLevel,
+		strings.Join(q.RelatedTitles, ","),
+		strings.TrimSpace(q.Location),
+		strings.Join(q.Locations, ";"),
+		q.Country,
+		q.Language,
+		strconv.FormatBool(q.IncludeRemote),
+		q.DatePosted,
+		q.EmploymentType,
+		strconv.FormatBool(q.RemoteOnly),
+		strings.Join(q.ExcludeTerms, ","),
+	}, "|"))
+}
+
+// A cursor is the position of the last job served and, in a sorted view,
+// its sort value. It is bound to the search and sort it came from so it
+// cannot be replayed against another one. A nil cursor is the first page.
+func encodeCursor(c *domain.SerpCacheCursor, key, sort string) string {
+	var position int32
+	var value string
+	if c != nil {
+		position = c.Position
+		if c.Value != nil {
+			value = strconv.FormatFloat(*c.Value, 'g', -1, 64)
+		}
+	}
+	raw := fmt.Sprintf("%d|%s:%s", position, value, cursorFingerprint(key, sort))
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor, key, sort string) (*domain.SerpCacheCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	payload, fingerprint, ok := strings.Cut(string(raw), ":")
+	if !ok || fingerprint != cursorFingerprint(key, sort) {
+		return nil, domain.ErrInvalidCursor
+	}
+	pos, value, _ := strings.Cut(payload, "|")
+	n, err := strconv.ParseInt(pos, 10, 32)
+	if err != nil || n < 0 {
+		return nil, domain.ErrInvalidCursor
+	}
+	c := &domain.SerpCacheCursor{Position: int32(n)}
+	if value != "" {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		c.Value = &v
+	}
+	return c, nil
+}
+
+func cursorFingerprint(key, sort string) string {
+	sum := sha1.Sum([]byte(key + "|" + sort))
+	return hex.EncodeToString(sum[:4])
+}
+
+// normalise reads each job's salary and posting date into structured form.
+// Jobs are ranked by pay on the top of their annual range in the exchange-rate
+// reference currency; one without a known currency and period has no rank.
+func (s *serpJobService) normalise(jobs []domain.SerpJob) []domain.SerpJob {
+	now := time.Now()
+	for i := range jobs {
+		job := &jobs[i]
+		fetchedAt := job.FetchedAt
+		if fetchedAt.IsZero() {
+			fetchedAt = now
+		}
+		if t, ok := jobtext.ParsePostedAt(job.PostedAt, fetchedAt); ok {
+			job.PostedTime = &t
+		}
+
+		salary, ok := jobtext.ParseSalary(job.Salary)
+		if !ok {
+			continue
+		}
+		job.SalaryRange = &domain.SalaryRange{
+			Min:       salary.Min,
+			Max:       salary.Max,
+			Currency:  salary.Currency,
+			Period:    salary.Period,
+			AnnualMin: salary.AnnualMin,
+			AnnualMax: salary.AnnualMax,
+		}
+		if rate, ok := s.rates[salary.Currency]; ok && salary.AnnualMax > 0 {
+			v := salary.AnnualMax * rate
+			job.SalarySortValue = &v
+		}
+	}
+	return jobs
+}
+
+// SaveJobToTracker adds the recommended job to the tracker, reporting any
+// tracked jobs that look like the same role.
+func (s *serpJobService) SaveJobToTracker(ctx context.Context, userID int32, cacheID int32) (*domain.SavedJob, error) {
 	cached, err := s.serpRepo.GetCachedJobByID(ctx, cacheID, userID)
 	if err != nil {
 		return nil, err
@@ -137,6 +653,8 @@ func (s *serpJobService) SaveJobToTracker(ctx context.Context, userID int32, cac
 		Status:      domain.JobStatusSaved,
 	}
 
+	duplicates := findDuplicates(ctx, s.jobRepo, newJob)
+
 	jobID, err := s.jobRepo.Create(ctx, newJob)
 	if err != nil {
 		return nil, err
@@ -147,8 +665,13 @@ func (s *serpJobService) SaveJobToTracker(ctx context.Context, userID int32, cac
 	if err := s.serpRepo.MarkSavedToTracker(ctx, cacheID, userID, jobID); err != nil {
 		return nil, err
 	}
+	s.snapshotDescription(ctx, userID, jobID, cached)
 
-	return s.jobRepo.GetJobByID(ctx, jobID)
+	job, err := s.jobRepo.GetJobByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SavedJob{Job: *job, Duplicates: duplicates}, nil
 }
 
 func (s *serpJobService) ApplyRecommendedJob(ctx context.Context, userID int32, cacheID int32, notes string) (*domain.DirectApplyResult, error) {
@@ -162,11 +685,19 @@ func (s *serpJobService) ApplyRecommendedJob(ctx context.Context, userID int32,
 		return nil, domain.ErrNoApplyLink
 	}
 
+	var existing *domain.Job
 	if cached.TrackerJobID != nil && *cached.TrackerJobID > 0 {
-		existing, err := s.jobRepo.GetJobByID(ctx, *cached.TrackerJobID)
-		if err != nil {
+		// A tracker job sitting in the trash is treated as gone; applying
+		// starts a fresh one.
+		existing, err = s.jobRepo.GetJobByID(ctx, *cached.TrackerJobID)
+		if errors.Is(err, domain.ErrInvalidJobID) {
+			existing = nil
+		} else if err != nil {
 			return nil, err
 		}
+	}
+
+	if existing != nil {
 		if existing.UserId != userID {
 			return nil, domain.ErrUnauthorized
 		}
@@ -212,6 +743,7 @@ func (s *serpJobService) ApplyRecommendedJob(ctx context.Context, userID int32,
 	if err := s.serpRepo.MarkSavedToTracker(ctx, cacheID, userID, jobID); err != nil {
 		return nil, err
 	}
+	s.snapshotDescription(ctx, userID, jobID, cached)
 
 	newJob.ID = jobID
 	full, err := s.jobRepo.GetJobByID(ctx, jobID)
@@ -220,3 +752,165 @@ func (s *serpJobService) ApplyRecommendedJob(ctx context.Context, userID int32,
 	}
 	return &domain.DirectApplyResult{Job: *full, ApplyURL: applyURL}, nil
 }
+
+// snapshotDescription keeps a copy of the posting with the tracked job, since
+// listings are taken down once a role closes. A failure is logged but does
+// not undo the save.
+func (s *serpJobService) snapshotDescription(ctx context.Context, userID, jobID int32, cached *domain.SerpJobCache) {
+	if strings.TrimSpace(cached.Description) == "" {
+		return
+	}
+	err := s.attachmentRepo.SaveSnapshot(ctx, &domain.JobDescriptionSnapshot{
+		JobID:       jobID,
+		UserID:      userID,
+		Title:       cached.Title,
+		CompanyName: cached.CompanyName,
+		Location:    cached.Location,
+		Platform:    cached.Platform,
+		Link:        cached.Link,
+		Description: cached.Description,
+		Salary:      cached.Salary,
+		PostedAt:    cached.PostedAt,
+	})
+	if err != nil {
+		log.Printf("failed to snapshot description for job %d: %v", jobID, err)
+	}
+}
+
+// seniorityWords are the title words that place a role at a level. A level
+// the user dismisses twice for wrong seniority is excluded from the search.
+var seniorityWords = map[string]bool{
+	"intern": true, "internship": true, "graduate": true, "junior": true, "entry": true,
+	"mid": true, "senior": true, "lead": true, "principal": true, "staff": true,
+	"head": true, "director": true, "manager": true, "vp": true, "chief": true,
+}
+
+const excludeTermAfterDismissals = 2
+
+// dismissalFeedback turns the user's dismissals into search adjustments:
+// dismissed postings and companies are dropped, seniority words they keep
+// rejecting are excluded from the query, and postings like the ones they
+// dismissed for location or relevance are ranked lower.
+type dismissalFeedback struct {
+	externalIDs  map[string]bool
+	companies    map[string]bool
+	locations    map[string]bool
+	titles       [][]string
+	seniority    map[string]bool
+	excludeTerms []string
+}
+
+// newDismissalFeedback never excludes a word of own, the user's title and
+// level, since that would search for nothing.
+func newDismissalFeedback(dismissals []domain.JobDismissal, own string) *dismissalFeedback {
+	f := &dismissalFeedback{
+		externalIDs: map[string]bool{},
+		companies:   map[string]bool{},
+		locations:   map[string]bool{},
+		seniority:   map[string]bool{},
+	}
+	ownWords := map[string]bool{}
+	for _, w := range strings.Fields(jobmatch.TitleKey(own)) {
+		ownWords[w] = true
+	}
+
+	seniorityCount := map[string]int{}
+	for _, d := range dismissals {
+		f.externalIDs[d.ExternalID] = true
+		switch d.Reason {
+		case domain.DismissReasonCompany:
+			if key := jobmatch.CompanyKey(d.CompanyName); key != "" {
+				f.companies[key] = true
+			}
+		case domain.DismissReasonWrongLocation:
+			if key := jobmatch.LocationKey(d.Location); key != "" {
+				f.locations[key] = true
+			}
+		case domain.DismissReasonWrongSeniority:
+			for _, w := range strings.Fields(jobmatch.TitleKey(d.Title)) {
+				if seniorityWords[w] && !ownWords[w] {
+					f.seniority[w] = true
+					seniorityCount[w]++
+				}
+			}
+		case domain.DismissReasonNotRelevant:
+			if words := strings.Fields(jobmatch.TitleKey(d.Title)); len(words) > 0 {
+				f.titles = append(f.titles, words)
+			}
+		}
+	}
+
+	for w, n := range seniorityCount {
+		if n >= excludeTermAfterDismissals {
+			f.excludeTerms = append(f.excludeTerms, w)
+		}
+	}
+	sort.Strings(f.excludeTerms)
+	return f
+}
+
+// apply drops dismissed postings and moves those that look like dismissed
+// ones to the back, keeping the upstream order otherwise.
+func (f *dismissalFeedback) apply(jobs []domain.SerpJob) []domain.SerpJob {
+	kept := make([]domain.SerpJob, 0, len(jobs))
+	penalty := make(map[string]int, len(jobs))
+	for _, job := range jobs {
+		if f.externalIDs[job.ExternalID] || f.companies[jobmatch.CompanyKey(job.CompanyName)] {
+			continue
+		}
+		penalty[job.ExternalID] = f.penalty(job)
+		kept = append(kept, job)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return penalty[kept[i].ExternalID] < penalty[kept[j].ExternalID]
+	})
+	return kept
+}
+
+func (f *dismissalFeedback) penalty(job domain.SerpJob) int {
+	var p int
+	if f.locations[jobmatch.LocationKey(job.Location)] {
+		p++
+	}
+	words := strings.Fields(jobmatch.TitleKey(job.Title))
+	for _, w := range words {
+		if f.seniority[w] {
+			p++
+			break
+		}
+	}
+	for _, dismissed := range f.titles {
+		if wordOverlap(words, dismissed) >= 0.5 {
+			p++
+			break
+		}
+	}
+	return p
+}
+
+// wordOverlap is the share of distinct words a and b have in common, from 0
+// to 1.
+func wordOverlap(a, b []string) float64 {
+	set := make(map[string]bool, len(a))
+	for _, w := range a {
+		set[w] = true
+	}
+	union := len(set)
+	var common int
+	seen := map[string]bool{}
+	for _, w := range b {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		if set[w] {
+			common++
+		} else {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return float64(common) / float64(union)
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"aiki/internal/domain"
+	"aiki/internal/jobsource"
+	"aiki/internal/pkg/currency"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecommendationCursor(t *testing.T) {
+	key := searchKey(jobsource.Query{Title: "Backend Engineer", Location: "Lagos", DatePosted: domain.DatePostedWeek})
+	relevance := domain.RecommendationSortRelevance
+
+	c, err := decodeCursor(encodeCursor(&domain.SerpCacheCursor{Position: 40}, key, relevance), key, relevance)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(40), c.Position)
+	assert.Nil(t, c.Value)
+
+	// A cursor from another search or another sort is refused.
+	other := searchKey(jobsource.Query{Title: "Backend Engineer", Location: "Lagos"})
+	_, err = decodeCursor(encodeCursor(&domain.SerpCacheCursor{Position: 40}, key, relevance), other, relevance)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	_, err = decodeCursor(encodeCursor(&domain.SerpCacheCursor{Position: 40}, key, relevance), key, domain.RecommendationSortPay)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+
+	_, err = decodeCursor("not a cursor", key, relevance)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestRecommendationCursor_SortValue(t *testing.T) {
+	key := searchKey(jobsource.Query{Title: "Backend Engineer"})
+	pay := domain.RecommendationSortPay
+	value := 123456.5
+
+	c, err := decodeCursor(encodeCursor(&domain.SerpCacheCursor{Position: 7, Value: &value}, key, pay), key, pay)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), c.Position)
+	if assert.NotNil(t, c.Value) {
+		assert.Equal(t, value, *c.Value)
+	}
+
+	// Past the last job with a salary the value is empty.
+	c, err = decodeCursor(encodeCursor(&domain.SerpCacheCursor{Position: 9}, key, pay), key, pay)
+	assert.NoError(t, err)
+	assert.Nil(t, c.Value)
+}
+
+func TestNormaliseJobs(t *testing.T) {
+	s := &serpJobService{rates: currency.DefaultRates()}
+	fetched := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	jobs := s.normalise([]domain.SerpJob{
+		{ExternalID: "1", Salary: "$50–$60 an hour", PostedAt: "3 days ago", FetchedAt: fetched},
+		{ExternalID: "2", Salary: "₦6M - ₦9M a year", PostedAt: "yesterday", FetchedAt: fetched},
+		{ExternalID: "3", Salary: "Competitive", PostedAt: "", FetchedAt: fetched},
+	})
+
+	if assert.NotNil(t, jobs[0].SalaryRange) {
+		assert.Equal(t, "USD", jobs[0].SalaryRange.Currency)
+		assert.Equal(t, "hour", jobs[0].SalaryRange.Period)
+		assert.Equal(t, 60.0*2080, jobs[0].SalaryRange.AnnualMax)
+	}
+	if assert.NotNil(t, jobs[0].SalarySortValue) {
+		assert.Equal(t, 60.0*2080, *jobs[0].SalarySortValue)
+	}
+	if assert.NotNil(t, jobs[0].PostedTime) {
+		assert.Equal(t, fetched.AddDate(0, 0, -3), *jobs[0].PostedTime)
+	}
+
+	// Naira is ranked on its USD value, well below the hourly USD job.
+	if assert.NotNil(t, jobs[1].SalarySortValue) {
+		assert.InDelta(t, 9_000_000*0.00065, *jobs[1].SalarySortValue, 0.01)
+	}
+
+	assert.Nil(t, jobs[2].SalaryRange)
+	assert.Nil(t, jobs[2].SalarySortValue)
+	assert.Nil(t, jobs[2].PostedTime)
+}
+
+func TestSearchKey_IgnoresCaseAndSpacing(t *testing.T) {
+	a := searchKey(jobsource.Query{Title: "Backend Engineer ", Location: "lagos"})
+	b := searchKey(jobsource.Query{Title: "backend engineer", Location: " Lagos"})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, searchKey(jobsource.Query{Title: "backend engineer", Location: "lagos", RemoteOnly: true}))
+}
+
+func TestSearchPlaces(t *testing.T) {
+	prefs := &domain.SearchPreferences{Locations: []string{"Lagos", "Abuja"}, OpenToRelocation: true}
+
+	// Preferred places, plus the whole country for a user open to relocating.
+	assert.Equal(t, []string{"Lagos", "Abuja", ""}, searchPlaces(domain.SerpSearchParams{}, prefs))
+
+	// Places asked for replace the preferred ones; repeats are dropped.
+	params := domain.SerpSearchParams{Location: "Accra", Locations: []string{"accra", "Kumasi"}}
+	assert.Equal(t, []string{"Accra", "Kumasi", ""}, searchPlaces(params, prefs))
+
+	assert.Empty(t, searchPlaces(domain.SerpSearchParams{}, &domain.SearchPreferences{OpenToRelocation: true}))
+}
+
+func TestDismissalFeedback(t *testing.T) {
+	dismissals := []domain.JobDismissal{
+		{ExternalID: "d1", Title: "Senior Backend Engineer", Reason: domain.DismissReasonWrongSeniority},
+		{ExternalID: "d2", Title: "Sr. Go Developer", Reason: domain.DismissReasonWrongSeniority},
+		{ExternalID: "d3", Title: "Lead Engineer", Reason: domain.DismissReasonWrongSeniority},
+		{ExternalID: "d4", Title: "Data Analyst", CompanyName: "Acme Inc.", Reason: domain.DismissReasonCompany},
+		{ExternalID: "d5", Title: "Backend Engineer", Location: "Abuja, Nigeria", Reason: domain.DismissReasonWrongLocation},
+		{ExternalID: "d6", Title: "QA Automation Engineer", Reason: domain.DismissReasonNotRelevant},
+	}
+	f := newDismissalFeedback(dismissals, "Backend Engineer junior")
+
+	// Senior was dismissed twice ("Sr." expands to it), lead only once.
+	assert.Equal(t, []string{"senior"}, f.excludeTerms)
+
+	jobs := []domain.SerpJob{
+		{ExternalID: "1", Title: "Backend Engineer", CompanyName: "Kuda", Location: "Abuja, Nigeria"},
+		{ExternalID: "2", Title: "Backend Engineer", CompanyName: "ACME", Location: "Lagos"},
+		{ExternalID: "d1", Title: "Senior Backend Engineer", CompanyName: "Paystack"},
+		{ExternalID: "3", Title: "QA Automation Engineer II", CompanyName: "Moniepoint", Location: "Lagos"},
+		{ExternalID: "4", Title: "Lead Backend Engineer", CompanyName: "Flutterwave", Location: "Lagos"},
+		{ExternalID: "5", Title: "Backend Engineer", CompanyName: "Paystack", Location: "Lagos"},
+	}
+	got := f.apply(jobs)
+
+	ids := make([]string, len(got))
+	for i, j := range got {
+		ids[i] = j.ExternalID
+	}
+	// d1 is dismissed and 2 is from a dismissed company. 1 is in a dismissed
+	// location, 3 looks like a dismissed title and 4 is at a dismissed
+	// seniority, so they move behind 5.
+	assert.Equal(t, []string{"5", "1", "3", "4"}, ids)
+}
+
+func TestDismissalFeedback_NeverExcludesOwnLevel(t *testing.T) {
+	dismissals := []domain.JobDismissal{
+		{ExternalID: "d1", Title: "Senior Backend Engineer", Reason: domain.DismissReasonWrongSeniority},
+		{ExternalID: "d2", Title: "Senior Go Developer", Reason: domain.DismissReasonWrongSeniority},
+	}
+	f := newDismissalFeedback(dismissals, "Backend Engineer senior")
+	assert.Empty(t, f.excludeTerms)
+}
+
+func TestPrefetchAll_BoundsWorkers(t *testing.T) {
+	states := make([]domain.SerpSearchState, 20)
+	for i := range states {
+		states[i].UserID = int32(i + 1)
+	}
+
+	var mu sync.Mutex
+	var running, peak int
+	refreshed, failed := prefetchAll(context.Background(), states, PrefetchOptions{Workers: 3}, func(ctx context.Context, state domain.SerpSearchState) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		if state.UserID%5 == 0 {
+			return errors.New("upstream failed")
+		}
+		return nil
+	})
+
+	assert.Equal(t, 16, refreshed)
+	assert.Equal(t, 4, failed)
+	assert.LessOrEqual(t, peak, 3)
+}
+
+func TestPrefetchAll_StopsWhenQuotaExhausted(t *testing.T) {
+	states := make([]domain.SerpSearchState, 10)
+	var calls int
+	refreshed, failed := prefetchAll(context.Background(), states, PrefetchOptions{Workers: 1}, func(ctx context.Context, state domain.SerpSearchState) error {
+		calls++
+		if calls == 3 {
+			return jobsource.ErrQuotaExhausted
+		}
+		return nil
+	})
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, refreshed)
+	assert.Equal(t, 1, failed)
+}
@@ -2,10 +2,15 @@ package service
 
 import (
 	"aiki/internal/domain"
+	"aiki/internal/pkg/jobtitle"
 	"aiki/internal/repository"
 	"context"
+	"log"
+	"time"
 )
 
+const defaultTitleSuggestionLimit = 8
+
 //go:generate mockgen -source=user_service.go -destination=mocks/mock_user_service.go -package=mocks
 
 type UserService interface {
@@ -17,6 +22,9 @@ type UserService interface {
 	GetUserProfile(ctx context.Context, id int32) (*domain.UserProfile, error)
 	UploadUserCV(ctx context.Context, id int32, data []byte) error
 	GetUserCV(ctx context.Context, id int32) ([]byte, error)
+	SuggestTitles(ctx context.Context, q *domain.TitleSuggestionQuery) *domain.TitleSuggestions
+	GetTimezone(ctx context.Context, id int32) (*domain.UserTimezone, error)
+	UpdateTimezone(ctx context.Context, id int32, req *domain.UserTimezone) (*domain.UserTimezone, error)
 }
 
 type userService struct {
@@ -66,7 +74,11 @@ func (s *userService) Update(ctx context.Context, id int32, req *domain.UpdateUs
 // 			user profile
 // ========================================================
 
+// CreateUserProfile stores the job title and experience level in their
+// standard form, e.g. "Snr. Software Engr" as Software Engineer at senior
+// level, so searches built from them use words job boards recognise.
 func (s *userService) CreateUserProfile(ctx context.Context, userProfile domain.UserProfile) (*domain.UserProfile, error) {
+	userProfile.CurrentJob, userProfile.ExperienceLevel = jobtitle.Normalise(userProfile.CurrentJob, userProfile.ExperienceLevel)
 	profile, err := s.userRepo.CreateUserProfile(ctx, userProfile.UserId, &userProfile.FullName, &userProfile.CurrentJob, &userProfile.ExperienceLevel, userProfile.Goals)
 	if err != nil {
 		return nil, err
@@ -91,6 +103,7 @@ func (s *userService) UpdateUserProfile(ctx context.Context, user domain.UserPro
 	if len(user.Goals) > 0 {
 		userProfile.Goals = user.Goals
 	}
+	userProfile.CurrentJob, userProfile.ExperienceLevel = jobtitle.Normalise(userProfile.CurrentJob, userProfile.ExperienceLevel)
 	profile, err := s.userRepo.UpdateUserProfile(ctx, userProfile.UserId, &userProfile.FullName, &userProfile.CurrentJob, &userProfile.ExperienceLevel, userProfile.Goals)
 	if err != nil {
 		return nil, err
@@ -122,3 +135,60 @@ func (s *userService) GetUserCV(ctx context.Context, id int32) ([]byte, error) {
 	}
 	return cv, nil
 }
+
+func (s *userService) GetTimezone(ctx context.Context, id int32) (*domain.UserTimezone, error) {
+	timezone, err := s.userRepo.GetTimezone(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.UserTimezone{Timezone: timezone}, nil
+}
+
+// UpdateTimezone moves the user's days into another time zone. "Local" is
+// whatever the server runs in, so it is not accepted.
+func (s *userService) UpdateTimezone(ctx context.Context, id int32, req *domain.UserTimezone) (*domain.UserTimezone, error) {
+	if req.Timezone == "Local" {
+		return nil, domain.ErrUnknownTimezone
+	}
+	if err := s.userRepo.UpdateTimezone(ctx, id, req.Timezone); err != nil {
+		return nil, err
+	}
+	return &domain.UserTimezone{Timezone: req.Timezone}, nil
+}
+
+// userLocation returns the time zone the user's days are counted in, or UTC
+// if it cannot be read.
+func userLocation(ctx context.Context, users repository.UserRepository, userID int32) *time.Location {
+	name, err := users.GetTimezone(ctx, userID)
+	if err != nil {
+		log.Printf("failed to get time zone of user %d: %v", userID, err)
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		log.Printf("unknown time zone %q of user %d: %v", name, userID, err)
+		return time.UTC
+	}
+	return loc
+}
+
+// SuggestTitles offers standard titles for the onboarding title field as the
+// user types, with the levels to pick from.
+func (s *userService) SuggestTitles(ctx context.Context, q *domain.TitleSuggestionQuery) *domain.TitleSuggestions {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultTitleSuggestionLimit
+	}
+
+	result := &domain.TitleSuggestions{
+		Titles: []domain.TitleSuggestion{},
+		Level:  jobtitle.Parse(q.Q).Level,
+	}
+	for _, o := range jobtitle.Suggest(q.Q, limit) {
+		result.Titles = append(result.Titles, domain.TitleSuggestion{Title: o.Title, Related: o.Related})
+	}
+	for _, l := range jobtitle.Levels() {
+		result.Levels = append(result.Levels, domain.ExperienceLevelOption{Value: l.Key, Label: l.Label})
+	}
+	return result
+}
@@ -145,3 +145,37 @@ func TestUserService_Update(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestUserService_CreateUserProfile_NormalisesTitle(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	fullName := "Ada Obi-Lovelace"
+	title, level := "Software Engineer", "senior"
+	saved := &domain.UserProfile{UserId: 1, FullName: fullName, CurrentJob: title, ExperienceLevel: level}
+	mockRepo.On("CreateUserProfile", ctx, int32(1), &fullName, &title, &level, []string(nil)).Return(saved, nil).Once()
+
+	profile, err := service.CreateUserProfile(ctx, domain.UserProfile{
+		UserId:          1,
+		FullName:        fullName,
+		CurrentJob:      "Snr. Software Engr",
+		ExperienceLevel: "Senior-level",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, saved, profile)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_SuggestTitles(t *testing.T) {
+	service := NewUserService(new(MockUserRepository))
+
+	got := service.SuggestTitles(context.Background(), &domain.TitleSuggestionQuery{Q: "snr back"})
+
+	require.NotEmpty(t, got.Titles)
+	assert.Equal(t, "Backend Engineer", got.Titles[0].Title)
+	assert.Contains(t, got.Titles[0].Related, "Go Engineer")
+	assert.Equal(t, "senior", got.Level)
+	assert.Len(t, got.Levels, 7)
+}
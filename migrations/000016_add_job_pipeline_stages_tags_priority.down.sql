@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS job_tags;
+
+DROP INDEX IF EXISTS idx_jobs_user_stage_position;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS board_position,
+    DROP COLUMN IF EXISTS stage_id,
+    DROP COLUMN IF EXISTS priority;
+
+DROP TRIGGER IF EXISTS update_pipeline_stages_updated_at ON pipeline_stages;
+DROP TABLE IF EXISTS pipeline_stages;
@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    id          SERIAL PRIMARY KEY,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name        VARCHAR(60) NOT NULL,
+    base_status VARCHAR(50) NOT NULL, -- saved | applied | interview | offer | rejected
+    position    INT NOT NULL DEFAULT 0,
+    color       VARCHAR(20),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_stages_user_position ON pipeline_stages(user_id, position);
+
+CREATE TRIGGER update_pipeline_stages_updated_at
+BEFORE UPDATE ON pipeline_stages
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS priority       SMALLINT NOT NULL DEFAULT 0, -- 0 none | 1 low | 2 medium | 3 high
+    ADD COLUMN IF NOT EXISTS stage_id       INT REFERENCES pipeline_stages(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS board_position INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_user_stage_position ON jobs(user_id, stage_id, board_position);
+
+CREATE TABLE IF NOT EXISTS job_tags (
+    job_id  INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag     VARCHAR(50) NOT NULL,
+    PRIMARY KEY (job_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_tags_user_tag ON job_tags(user_id, tag);
+
+-- Give every existing user the default pipeline and place their jobs on it.
+INSERT INTO pipeline_stages (user_id, name, base_status, position)
+SELECT u.id, d.name, d.base_status, d.position
+FROM users u
+CROSS JOIN (VALUES
+    ('Saved', 'saved', 0),
+    ('Applied', 'applied', 1),
+    ('Interview', 'interview', 2),
+    ('Offer', 'offer', 3),
+    ('Rejected', 'rejected', 4)
+) AS d(name, base_status, position)
+ON CONFLICT (user_id, name) DO NOTHING;
+
+UPDATE jobs j
+SET stage_id = ps.id
+FROM pipeline_stages ps
+WHERE ps.user_id = j.user_id
+  AND ps.base_status = j.status
+  AND j.stage_id IS NULL;
+
+UPDATE jobs j
+SET board_position = ranked.pos
+FROM (
+    SELECT id, (ROW_NUMBER() OVER (PARTITION BY user_id, stage_id ORDER BY created_at, id) - 1)::int AS pos
+    FROM jobs
+) ranked
+WHERE ranked.id = j.id;
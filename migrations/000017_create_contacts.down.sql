@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS contact_interactions;
+DROP TABLE IF EXISTS contact_jobs;
+
+DROP TRIGGER IF EXISTS update_contacts_updated_at ON contacts;
+DROP TABLE IF EXISTS contacts;
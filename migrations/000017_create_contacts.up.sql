@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS contacts (
+    id                  SERIAL PRIMARY KEY,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                VARCHAR(150) NOT NULL,
+    company             VARCHAR(150),
+    role                VARCHAR(150),
+    email               VARCHAR(255),
+    linkedin_url        TEXT,
+    notes               TEXT,
+    next_touch_at       DATE,
+    next_touch_note     VARCHAR(255),
+    next_touch_notified BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contacts_user_id    ON contacts(user_id);
+CREATE INDEX IF NOT EXISTS idx_contacts_next_touch ON contacts(next_touch_at) WHERE next_touch_notified = FALSE;
+
+CREATE TRIGGER update_contacts_updated_at
+BEFORE UPDATE ON contacts
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS contact_jobs (
+    contact_id INT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    job_id     INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (contact_id, job_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_jobs_job_id ON contact_jobs(job_id);
+
+CREATE TABLE IF NOT EXISTS contact_interactions (
+    id          SERIAL PRIMARY KEY,
+    contact_id  INT NOT NULL REFERENCES contacts(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type        VARCHAR(30) NOT NULL, -- email | call | coffee_chat | meeting | message | other
+    occurred_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    notes       TEXT,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_interactions_contact ON contact_interactions(contact_id, occurred_at DESC);
@@ -0,0 +1,5 @@
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS offer_deadline;
+
+DROP TRIGGER IF EXISTS update_job_offers_updated_at ON job_offers;
+DROP TABLE IF EXISTS job_offers;
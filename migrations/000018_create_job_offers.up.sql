@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS job_offers (
+    job_id              INT PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    base_salary         NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    currency            CHAR(3) NOT NULL,
+    pay_period          VARCHAR(10) NOT NULL DEFAULT 'year', -- year | month | week | day | hour
+    bonus               NUMERIC(14, 2) NOT NULL DEFAULT 0,   -- expected yearly bonus, in currency
+    equity              VARCHAR(500),                        -- free-form grant description
+    equity_annual_value NUMERIC(14, 2) NOT NULL DEFAULT 0,   -- estimated yearly vesting value, in currency
+    benefits            TEXT,
+    start_date          DATE,
+    response_deadline   DATE,
+    remote_policy       VARCHAR(20),                         -- onsite | hybrid | remote
+    deadline_notified   BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_offers_user_id  ON job_offers(user_id);
+CREATE INDEX IF NOT EXISTS idx_job_offers_deadline ON job_offers(response_deadline) WHERE deadline_notified = FALSE;
+
+CREATE TRIGGER update_job_offers_updated_at
+BEFORE UPDATE ON job_offers
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS offer_deadline BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS record_job_status_change ON jobs;
+DROP FUNCTION IF EXISTS record_job_status_change();
+DROP TABLE IF EXISTS job_status_events;
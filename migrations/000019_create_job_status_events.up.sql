@@ -0,0 +1,39 @@
+-- Every status a job passes through, so funnel analytics can tell when a job
+-- reached interview or offer even after it moved on (e.g. to rejected).
+CREATE TABLE IF NOT EXISTS job_status_events (
+    id          SERIAL PRIMARY KEY,
+    job_id      INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id     INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status   VARCHAR(50) NOT NULL,
+    changed_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_status_events_job_id  ON job_status_events(job_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_job_status_events_user_id ON job_status_events(user_id, changed_at);
+
+-- Recorded by trigger so every write path (tracker, board moves, recommended
+-- jobs) is captured.
+CREATE OR REPLACE FUNCTION record_job_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO job_status_events (job_id, user_id, from_status, to_status)
+        VALUES (NEW.id, NEW.user_id, NULL, NEW.status);
+    ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+        INSERT INTO job_status_events (job_id, user_id, from_status, to_status)
+        VALUES (NEW.id, NEW.user_id, OLD.status, NEW.status);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER record_job_status_change
+AFTER INSERT OR UPDATE OF status ON jobs
+FOR EACH ROW EXECUTE FUNCTION record_job_status_change();
+
+-- Existing jobs get a single event for their current status.
+INSERT INTO job_status_events (job_id, user_id, from_status, to_status, changed_at)
+SELECT id, user_id, NULL, status, COALESCE(updated_at, created_at)
+FROM jobs
+WHERE status IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_jobs_user_deleted_at;
+
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS archived_at;
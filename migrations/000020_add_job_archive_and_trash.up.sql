@@ -0,0 +1,7 @@
+-- Jobs are archived or moved to the trash instead of being deleted outright.
+-- Trashed jobs are purged by the scheduler once the retention window passes.
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_at  TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_user_deleted_at ON jobs(user_id, deleted_at);
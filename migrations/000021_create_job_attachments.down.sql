@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_description_snapshots;
+DROP TABLE IF EXISTS job_attachments;
@@ -0,0 +1,32 @@
+-- Files kept alongside a tracked job: offer letters, take-home assignments,
+-- the exact CV that was sent. Stored in the database like users.cv.
+CREATE TABLE IF NOT EXISTS job_attachments (
+    id           SERIAL PRIMARY KEY,
+    job_id       INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name    VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    kind         VARCHAR(20) NOT NULL DEFAULT 'other', -- offer_letter | assignment | cv | other
+    size_bytes   BIGINT NOT NULL,
+    data         BYTEA NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_attachments_job_id  ON job_attachments(job_id);
+CREATE INDEX IF NOT EXISTS idx_job_attachments_user_id ON job_attachments(user_id);
+
+-- The posting as it looked when the job was saved from recommendations.
+-- Written once and never updated, so it survives the posting being taken down.
+CREATE TABLE IF NOT EXISTS job_description_snapshots (
+    job_id       INT PRIMARY KEY REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title        TEXT NOT NULL,
+    company_name TEXT,
+    location     TEXT,
+    platform     VARCHAR(100),
+    link         TEXT,
+    description  TEXT NOT NULL,
+    salary       VARCHAR(100),
+    posted_at    VARCHAR(100),
+    captured_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
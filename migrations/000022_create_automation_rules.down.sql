@@ -0,0 +1,6 @@
+ALTER TABLE jobs
+    DROP COLUMN IF EXISTS ghosted_at;
+
+DROP TABLE IF EXISTS job_events;
+DROP TRIGGER IF EXISTS update_automation_rules_updated_at ON automation_rules;
+DROP TABLE IF EXISTS automation_rules;
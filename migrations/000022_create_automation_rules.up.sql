@@ -0,0 +1,43 @@
+-- Per-user rules the scheduler runs against stale jobs: "in <status> for more
+-- than <after_days> days" triggers any of mark ghosted, a one-off nudge and
+-- archive. With both nudge and archive, archiving waits grace_days after the
+-- nudge.
+CREATE TABLE IF NOT EXISTS automation_rules (
+    id           SERIAL PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         VARCHAR(100) NOT NULL,
+    enabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    status       VARCHAR(50) NOT NULL, -- saved | applied | interview | offer | rejected
+    after_days   INT NOT NULL,
+    mark_ghosted BOOLEAN NOT NULL DEFAULT FALSE,
+    nudge        BOOLEAN NOT NULL DEFAULT FALSE,
+    archive      BOOLEAN NOT NULL DEFAULT FALSE,
+    grace_days   INT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_automation_rules_user_id ON automation_rules(user_id);
+CREATE INDEX IF NOT EXISTS idx_automation_rules_enabled ON automation_rules(enabled) WHERE enabled = TRUE;
+
+CREATE TRIGGER update_automation_rules_updated_at
+BEFORE UPDATE ON automation_rules
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Changes made to a job by something other than the user, shown on the job's
+-- timeline next to its status history.
+CREATE TABLE IF NOT EXISTS job_events (
+    id         SERIAL PRIMARY KEY,
+    job_id     INT NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    user_id    INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule_id    INT REFERENCES automation_rules(id) ON DELETE SET NULL,
+    event_type VARCHAR(30) NOT NULL, -- ghosted | nudged | archived
+    message    TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_events_job_id  ON job_events(job_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_job_events_rule_id ON job_events(rule_id, job_id);
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS ghosted_at TIMESTAMP;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS goal_check_ins;
+DROP TRIGGER IF EXISTS update_user_goals_updated_at ON user_goals;
+DROP TABLE IF EXISTS user_goals;
@@ -0,0 +1,33 @@
+-- Structured goals such as "apply to 10 jobs per week". Progress is computed
+-- from activity (jobs, daily_progress, contact interactions); "manual" goals,
+-- e.g. mock interviews, count the check-ins the user logs. The *_notified_for
+-- columns hold the start of the last period a notification was sent for, so
+-- each goal is announced at most once per period.
+CREATE TABLE IF NOT EXISTS user_goals (
+    id                   SERIAL PRIMARY KEY,
+    user_id              INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title                VARCHAR(100) NOT NULL,
+    metric               VARCHAR(30) NOT NULL, -- applications | interviews | focus_minutes | focus_sessions | networking | manual
+    target               INT NOT NULL,
+    period               VARCHAR(10) NOT NULL, -- weekly | monthly
+    reached_notified_for DATE,
+    at_risk_notified_for DATE,
+    created_at           TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_goals_user_id ON user_goals(user_id);
+
+CREATE TRIGGER update_user_goals_updated_at
+BEFORE UPDATE ON user_goals
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS goal_check_ins (
+    id        SERIAL PRIMARY KEY,
+    goal_id   INT NOT NULL REFERENCES user_goals(id) ON DELETE CASCADE,
+    amount    INT NOT NULL DEFAULT 1,
+    note      TEXT,
+    logged_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_goal_check_ins_goal_id ON goal_check_ins(goal_id, logged_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS saved_search_matches;
+DROP TRIGGER IF EXISTS update_saved_searches_updated_at ON saved_searches;
+DROP TABLE IF EXISTS saved_searches;
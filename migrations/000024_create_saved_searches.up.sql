@@ -0,0 +1,44 @@
+-- Named job searches that the scheduler re-runs. Every posting a search has
+-- returned is kept in saved_search_matches, so a run can tell new matches
+-- from ones already seen. alerted_at is set once the user has been told about
+-- a match; digest searches leave it NULL until the daily digest goes out.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id                 SERIAL PRIMARY KEY,
+    user_id            INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name               VARCHAR(100) NOT NULL,
+    query              VARCHAR(255) NOT NULL,
+    location           VARCHAR(255),
+    remote_only        BOOLEAN NOT NULL DEFAULT FALSE,
+    min_salary         INT,
+    excluded_companies TEXT[] NOT NULL DEFAULT '{}',
+    frequency          VARCHAR(10) NOT NULL DEFAULT 'daily', -- daily | weekly
+    alert              VARCHAR(10) NOT NULL DEFAULT 'instant', -- instant | digest | off
+    last_run_at        TIMESTAMP,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+
+CREATE TRIGGER update_saved_searches_updated_at
+BEFORE UPDATE ON saved_searches
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS saved_search_matches (
+    id              SERIAL PRIMARY KEY,
+    saved_search_id INT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    external_id     TEXT NOT NULL,
+    title           TEXT NOT NULL,
+    company_name    TEXT,
+    location        TEXT,
+    link            TEXT,
+    platform        VARCHAR(100),
+    salary          VARCHAR(100),
+    posted_at       VARCHAR(100),
+    first_seen_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    alerted_at      TIMESTAMP,
+    UNIQUE (saved_search_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_search_matches_pending
+    ON saved_search_matches(saved_search_id) WHERE alerted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS serp_search_state;
+DROP INDEX IF EXISTS idx_serp_job_cache_search;
+ALTER TABLE serp_job_cache
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS search_key;
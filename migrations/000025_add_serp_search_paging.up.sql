@@ -0,0 +1,16 @@
+-- Recommendations page through upstream results. Cached rows are tagged with
+-- the search that returned them and their position in it, so a cursor can
+-- point into the list. serp_search_state keeps each user's current search and
+-- the upstream page token to continue it from.
+ALTER TABLE serp_job_cache
+    ADD COLUMN IF NOT EXISTS search_key TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS position   INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_serp_job_cache_search ON serp_job_cache(user_id, search_key, position);
+
+CREATE TABLE IF NOT EXISTS serp_search_state (
+    user_id         INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    search_key      TEXT NOT NULL,
+    next_page_token TEXT,
+    fetched_at      TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS job_dismissals;
+
+ALTER TABLE serp_job_cache DROP COLUMN IF EXISTS company_key;
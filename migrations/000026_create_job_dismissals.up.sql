@@ -0,0 +1,22 @@
+-- Recommended jobs a user is not interested in. A dismissal hides the posting;
+-- with reason 'company' it hides every posting from that company. The reasons
+-- also steer ranking and the terms searched for. company_key is the company
+-- name normalised for matching, also stored on cached jobs.
+ALTER TABLE serp_job_cache
+    ADD COLUMN IF NOT EXISTS company_key TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS job_dismissals (
+    id           SERIAL PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    external_id  TEXT NOT NULL,
+    title        TEXT NOT NULL,
+    company_name TEXT,
+    company_key  TEXT NOT NULL DEFAULT '',
+    location     TEXT,
+    reason       VARCHAR(20) NOT NULL DEFAULT 'not_relevant'
+                 CHECK (reason IN ('wrong_seniority', 'wrong_location', 'company', 'not_relevant')),
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, external_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_dismissals_company ON job_dismissals(user_id, company_key) WHERE reason = 'company';
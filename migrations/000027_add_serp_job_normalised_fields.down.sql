@@ -0,0 +1,9 @@
+ALTER TABLE serp_job_cache
+    DROP COLUMN IF EXISTS salary_min,
+    DROP COLUMN IF EXISTS salary_max,
+    DROP COLUMN IF EXISTS salary_currency,
+    DROP COLUMN IF EXISTS salary_period,
+    DROP COLUMN IF EXISTS salary_annual_min,
+    DROP COLUMN IF EXISTS salary_annual_max,
+    DROP COLUMN IF EXISTS salary_sort_value,
+    DROP COLUMN IF EXISTS posted_time;
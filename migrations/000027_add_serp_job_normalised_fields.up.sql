@@ -0,0 +1,13 @@
+-- Structured salary and posting date read from the free-text salary and
+-- posted_at, so recommendations can be sorted by pay and by newest. The
+-- annual figures assume full-time hours; salary_sort_value is the annual top
+-- of the range in the exchange-rate reference currency.
+ALTER TABLE serp_job_cache
+    ADD COLUMN IF NOT EXISTS salary_min        NUMERIC(14, 2),
+    ADD COLUMN IF NOT EXISTS salary_max        NUMERIC(14, 2),
+    ADD COLUMN IF NOT EXISTS salary_currency   VARCHAR(3),
+    ADD COLUMN IF NOT EXISTS salary_period     VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS salary_annual_min NUMERIC(14, 2),
+    ADD COLUMN IF NOT EXISTS salary_annual_max NUMERIC(14, 2),
+    ADD COLUMN IF NOT EXISTS salary_sort_value DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS posted_time       TIMESTAMP;
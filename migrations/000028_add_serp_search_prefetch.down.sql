@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_serp_search_state_viewed;
+
+ALTER TABLE serp_search_state
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS date_posted,
+    DROP COLUMN IF EXISTS employment_type,
+    DROP COLUMN IF EXISTS remote_only,
+    DROP COLUMN IF EXISTS viewed_at;
@@ -0,0 +1,11 @@
+-- The filters of each user's current recommendation search, so it can be
+-- refreshed in the background before its cache expires, and when the user
+-- last looked at it, so only recently active users are refreshed.
+ALTER TABLE serp_search_state
+    ADD COLUMN IF NOT EXISTS location        TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS date_posted     VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS employment_type VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS remote_only     BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS viewed_at       TIMESTAMP NOT NULL DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_serp_search_state_viewed ON serp_search_state(viewed_at, fetched_at);
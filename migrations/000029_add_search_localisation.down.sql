@@ -0,0 +1,10 @@
+ALTER TABLE serp_job_cache
+    DROP COLUMN IF EXISTS remote;
+
+ALTER TABLE serp_search_state
+    DROP COLUMN IF EXISTS locations,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS language;
+
+DROP TRIGGER IF EXISTS update_search_preferences_updated_at ON search_preferences;
+DROP TABLE IF EXISTS search_preferences;
@@ -0,0 +1,26 @@
+-- Where each user wants recommended jobs searched: a country and language,
+-- the cities or regions searched together, and whether jobs elsewhere in the
+-- country or remote roles suit them too.
+CREATE TABLE IF NOT EXISTS search_preferences (
+    user_id            INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    country            VARCHAR(2) NOT NULL DEFAULT '',
+    language           VARCHAR(8) NOT NULL DEFAULT '',
+    locations          TEXT[] NOT NULL DEFAULT '{}',
+    open_to_remote     BOOLEAN NOT NULL DEFAULT FALSE,
+    open_to_relocation BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_search_preferences_updated_at
+BEFORE UPDATE ON search_preferences
+FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- The places, country and language a background refresh must search again.
+ALTER TABLE serp_search_state
+    ADD COLUMN IF NOT EXISTS locations TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS country   VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS language  VARCHAR(8) NOT NULL DEFAULT '';
+
+-- Whether the listing is for a remote role, as the board or its text says.
+ALTER TABLE serp_job_cache
+    ADD COLUMN IF NOT EXISTS remote BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS reported_seconds,
+    DROP COLUMN IF EXISTS flagged;
+
+DROP TABLE IF EXISTS focus_session_segments;
@@ -0,0 +1,28 @@
+-- The stretches of each focus session during which its clock ran, timed by
+-- the server, so focus time no longer rests on what the client reports.
+CREATE TABLE IF NOT EXISTS focus_session_segments (
+    id         SERIAL PRIMARY KEY,
+    session_id INT NOT NULL REFERENCES focus_sessions(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at   TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_segments_session ON focus_session_segments(session_id, started_at);
+
+-- What the client's timer last said, and whether it disagreed with the
+-- server's; flagged sessions do not count towards badges.
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS reported_seconds INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS flagged          BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Sessions in progress keep the time they had so far, and active ones run on
+-- from when they were last started or resumed.
+INSERT INTO focus_session_segments (session_id, started_at, ended_at)
+SELECT id, started_at, started_at + make_interval(secs => elapsed_seconds)
+FROM focus_sessions
+WHERE status IN ('active', 'paused') AND elapsed_seconds > 0;
+
+INSERT INTO focus_session_segments (session_id, started_at)
+SELECT id, updated_at
+FROM focus_sessions
+WHERE status = 'active';
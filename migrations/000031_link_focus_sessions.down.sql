@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_focus_sessions_goal_id;
+DROP INDEX IF EXISTS idx_focus_sessions_job_id;
+
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS focus_score,
+    DROP COLUMN IF EXISTS reflection,
+    DROP COLUMN IF EXISTS intention,
+    DROP COLUMN IF EXISTS goal_id,
+    DROP COLUMN IF EXISTS job_id;
@@ -0,0 +1,12 @@
+-- What a focus session was for: a tracked job, a goal or a free-text
+-- intention, set when it starts; and how it went: a reflection note and a
+-- self-rated focus score, set when it ends.
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS job_id      INT REFERENCES jobs(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS goal_id     INT REFERENCES user_goals(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS intention   VARCHAR(200) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reflection  TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS focus_score SMALLINT CHECK (focus_score BETWEEN 1 AND 5);
+
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_job_id  ON focus_sessions(job_id) WHERE job_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_focus_sessions_goal_id ON focus_sessions(goal_id) WHERE goal_id IS NOT NULL;
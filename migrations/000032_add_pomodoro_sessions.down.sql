@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS update_focus_session_presets_updated_at ON focus_session_presets;
+DROP TABLE IF EXISTS focus_session_presets;
+
+ALTER TABLE focus_sessions
+    DROP COLUMN IF EXISTS break_seconds,
+    DROP COLUMN IF EXISTS long_break_every,
+    DROP COLUMN IF EXISTS cycles,
+    DROP COLUMN IF EXISTS long_break_seconds,
+    DROP COLUMN IF EXISTS short_break_seconds,
+    DROP COLUMN IF EXISTS work_seconds,
+    DROP COLUMN IF EXISTS mode;
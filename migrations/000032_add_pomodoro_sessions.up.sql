@@ -0,0 +1,31 @@
+-- Pomodoro sessions alternate work and break intervals for a number of
+-- cycles, with a long break every long_break_every cycles. Their plan is
+-- kept on the session; single sessions leave it NULL. elapsed_seconds counts
+-- work only and break_seconds the breaks.
+ALTER TABLE focus_sessions
+    ADD COLUMN IF NOT EXISTS mode                VARCHAR(20) NOT NULL DEFAULT 'single', -- single | pomodoro
+    ADD COLUMN IF NOT EXISTS work_seconds        INT,
+    ADD COLUMN IF NOT EXISTS short_break_seconds INT,
+    ADD COLUMN IF NOT EXISTS long_break_seconds  INT,
+    ADD COLUMN IF NOT EXISTS cycles              INT,
+    ADD COLUMN IF NOT EXISTS long_break_every    INT,
+    ADD COLUMN IF NOT EXISTS break_seconds       INT NOT NULL DEFAULT 0;
+
+-- Pomodoro plans a user saved to reuse.
+CREATE TABLE IF NOT EXISTS focus_session_presets (
+    id                  SERIAL PRIMARY KEY,
+    user_id             INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name                VARCHAR(60) NOT NULL,
+    work_seconds        INT NOT NULL,
+    short_break_seconds INT NOT NULL,
+    long_break_seconds  INT NOT NULL DEFAULT 0,
+    cycles              INT NOT NULL,
+    long_break_every    INT NOT NULL DEFAULT 0,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_focus_session_presets_user_id ON focus_session_presets(user_id);
+
+CREATE TRIGGER update_focus_session_presets_updated_at BEFORE UPDATE ON focus_session_presets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,22 @@
+-- Each user's IANA time zone, such as "Africa/Nairobi". Streak days,
+-- daily_progress dates, progress charts, goal periods, application analytics
+-- and reminders follow it.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Until now days were counted in the API server's time zone, so the
+-- streaks.last_session_date and daily_progress dates already recorded are
+-- days of that zone. Existing users start in it, which keeps those dates
+-- meaning the days they were recorded for. The zone comes from the
+-- aiki.server_timezone setting, which `make migrate-up SERVER_TZ=...` passes
+-- on the connection, and is UTC, the zone of the Docker image, without it.
+DO $$
+DECLARE
+    server_zone TEXT := COALESCE(NULLIF(current_setting('aiki.server_timezone', true), ''), 'UTC');
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_timezone_names WHERE name = server_zone) THEN
+        RAISE EXCEPTION 'aiki.server_timezone "%" is not a known time zone', server_zone;
+    END IF;
+    UPDATE users SET timezone = server_zone;
+END
+$$;